@@ -0,0 +1,161 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+func TestAndersonDarlingDistribution(t *testing.T) {
+	// the tabled critical values of A² in the limit
+	for _, c := range []struct{ z, p float64 }{{1.933, 0.10}, {2.492, 0.05}} {
+		if got := adSurvival(c.z); math.Abs(got-c.p) > 1e-4 {
+			t.Errorf("P(A² > %v) = %v; want %v", c.z, got, c.p)
+		}
+	}
+	// the 5 % point of the modified statistic against a fitted normal
+	if p := adNormalPValue(0.752); math.Abs(p-0.05) > 0.001 {
+		t.Errorf("fitted normal: p at 0.752 = %v; want 0.05", p)
+	}
+}
+
+func TestAndersonDarlingTest(t *testing.T) {
+	x := []float64{0.62, 0.05, 0.33, 0.91, 0.48}
+	res := AndersonDarlingTest(x, uniformCDF)
+	u := sorted(x)
+	want := -5.0
+	for i := range u {
+		want -= float64(2*i+1) * (math.Log(u[i]) + math.Log(1-u[4-i])) / 5
+	}
+	if math.Abs(res.Statistic-want) > 1e-12 || res.Method != "Anderson-Darling test" || res.StatisticName != "A" {
+		t.Errorf("A² = %v (%s); want %v", res.Statistic, res.Method, want)
+	}
+	asym := AndersonDarlingTest(x, uniformCDF, TestOptions{PValueMethod: PValueAsymptotic})
+	if math.Abs(asym.PValue-adSurvival(want)) > 1e-12 || math.Abs(asym.PValue-res.PValue) > 0.01 {
+		t.Errorf("asymptotic p = %v, finite-sample p = %v", asym.PValue, res.PValue)
+	}
+	// a value at the edge of the support is infinitely unlikely
+	if edge := AndersonDarlingTest([]float64{0, 0.5}, uniformCDF); !math.IsInf(edge.Statistic, 1) || edge.PValue != 0 {
+		t.Errorf("edge: A² = %v, p = %v", edge.Statistic, edge.PValue)
+	}
+}
+
+// The finite-sample p-values give the nominal level for uniform samples of
+// 10 and, with the Lilliefors correction, for normal samples of 20.
+func TestAndersonDarlingLevel(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	const reps = 5000
+	x := make([]float64, 10)
+	y := make([]float64, 20)
+	var plain, fitted float64
+	for r := 0; r < reps; r++ {
+		for i := range x {
+			x[i] = rng.Float64()
+		}
+		if AndersonDarlingTest(x, uniformCDF).Reject(0.05) {
+			plain++
+		}
+		for i := range y {
+			y[i] = 1 + 2*rng.NormFloat64()
+		}
+		cdf := probability.Normal{Mu: mean(y), Sigma: sampleStdDev(y)}.CDF
+		if AndersonDarlingTest(y, cdf, TestOptions{Lilliefors: true}).Reject(0.05) {
+			fitted++
+		}
+	}
+	if rate := plain / reps; math.Abs(rate-0.05) > 0.01 {
+		t.Errorf("rejection rate %v; want about 0.05", rate)
+	}
+	if rate := fitted / reps; math.Abs(rate-0.05) > 0.01 {
+		t.Errorf("Lilliefors rejection rate %v; want about 0.05", rate)
+	}
+}
+
+// midrankA2 is Scholz and Stephens' A²akN for k samples, computed as in
+// their paper from the distinct pooled values.
+func midrankA2(samples ...[]float64) float64 {
+	var all []float64
+	for _, s := range samples {
+		all = append(all, s...)
+	}
+	z := sorted(all)
+	n := float64(len(z))
+	var a2 float64
+	for _, s := range samples {
+		s = sorted(s)
+		var inner float64
+		for j := 0; j < len(z); {
+			v := z[j]
+			left := sort.SearchFloat64s(z, v)
+			right := sort.SearchFloat64s(z, math.Nextafter(v, math.Inf(1)))
+			l := float64(right - left)
+			b := float64(left) + l/2
+			sl := sort.SearchFloat64s(s, v)
+			sr := sort.SearchFloat64s(s, math.Nextafter(v, math.Inf(1)))
+			m := float64(sr) - float64(sr-sl)/2
+			d := n*m - b*float64(len(s))
+			inner += l / n * d * d / (b*(n-b) - n*l/4)
+			j = right
+		}
+		a2 += inner / float64(len(s))
+	}
+	return a2 * (n - 1) / n
+}
+
+func TestTwoSampleAndersonDarlingTest(t *testing.T) {
+	x := []float64{1.2, 3.4, 2.2, 5.0, 2.2}
+	y := []float64{0.3, 2.2, 1.1, 0.9, 1.2, 4.0}
+	res := TwoSampleAndersonDarlingTest(x, y)
+	if want := midrankA2(x, y); math.Abs(res.Statistic-want) > 1e-12 {
+		t.Errorf("A² = %v; want %v", res.Statistic, want)
+	}
+	want := labellingTail(x, y, func(a, b []float64) float64 { return midrankA2(a, b) })
+	if res.Method != "Exact two-sample Anderson-Darling test" || math.Abs(res.PValue-want) > 1e-12 {
+		t.Errorf("%s p = %v; want %v", res.Method, res.PValue, want)
+	}
+
+	rng := rand.New(rand.NewSource(6))
+	x, y = make([]float64, 11), make([]float64, 10)
+	for i := range x {
+		x[i] = rng.ExpFloat64()
+	}
+	for i := range y {
+		y[i] = rng.ExpFloat64()
+	}
+	exact := TwoSampleAndersonDarlingTest(x, y)
+	asym := TwoSampleAndersonDarlingTest(x, y, TestOptions{PValueMethod: PValueAsymptotic})
+	if exact.Method != "Exact two-sample Anderson-Darling test" || asym.Statistic != exact.Statistic ||
+		math.Abs(asym.PValue-exact.PValue) > 0.02 {
+		t.Errorf("%s p = %v; asymptotic p = %v", exact.Method, exact.PValue, asym.PValue)
+	}
+}
+
+func TestAndersonDarlingErrors(t *testing.T) {
+	big := make([]float64, 40)
+	for i := range big {
+		big[i] = float64(i)
+	}
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"empty", AndersonDarlingTest(nil, uniformCDF), ErrEmptyInput},
+		{"bad cdf", AndersonDarlingTest(sleep1, func(float64) float64 { return math.NaN() }), ErrInvalidParameter},
+		{"exact", AndersonDarlingTest(sleep1, uniformCDF, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+		{"Lilliefors small", AndersonDarlingTest(sleep1[:7], uniformCDF, TestOptions{Lilliefors: true}), ErrEmptyInput},
+		{"Lilliefors asymptotic", AndersonDarlingTest(sleep1, uniformCDF, TestOptions{Lilliefors: true, PValueMethod: PValueAsymptotic}), ErrInvalidParameter},
+		{"two-sample empty", TwoSampleAndersonDarlingTest(nil, sleep2), ErrEmptyInput},
+		{"two-sample tied", TwoSampleAndersonDarlingTest([]float64{1, 1}, []float64{1}), ErrInvalidParameter},
+		{"two-sample too large", TwoSampleAndersonDarlingTest(big, big, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+}
@@ -1,14 +1,54 @@
 package hypothesis
 
 import (
+	"fmt"
 	"math"
+	"strings"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+	"github.com/cyber-mountain-man/statistical-go/probability"
 )
 
+// ANOVAResult holds the outcome of a one-way ANOVA together with the
+// components of the standard ANOVA table.
+type ANOVAResult struct {
+	TestResult
+	SSB       float64 // between-group sum of squares
+	SSW       float64 // within-group sum of squares
+	DFBetween float64 // k - 1
+	DFWithin  float64 // N - k
+	MSB       float64 // SSB / DFBetween
+	MSW       float64 // SSW / DFWithin
+}
+
+// String formats the result as a standard ANOVA table.
+func (r ANOVAResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-10s %6s %12s %12s %10s %10s\n", "Source", "DF", "SS", "MS", "F", "P")
+	fmt.Fprintf(&b, "%-10s %6.0f %12.4f %12.4f %10.4f %10.4g\n", "Between", r.DFBetween, r.SSB, r.MSB, r.Statistic, r.PValue)
+	fmt.Fprintf(&b, "%-10s %6.0f %12.4f %12.4f\n", "Within", r.DFWithin, r.SSW, r.MSW)
+	fmt.Fprintf(&b, "%-10s %6.0f %12.4f\n", "Total", r.DFBetween+r.DFWithin, r.SSB+r.SSW)
+	return b.String()
+}
+
 // OneWayANOVA calculates the F-statistic and p-value for one-way ANOVA.
-// Each inner slice in `groups` represents the data for one group.
+// Each inner slice in `groups` represents the data for one group. Err is
+// set for fewer than two groups, an empty group, or no more observations
+// than groups. The effect size is η², SSB / (SSB + SSW). OneWayANOVATable
+// returns the sums of squares behind the test as well.
 func OneWayANOVA(groups [][]float64) TestResult {
-	if len(groups) < 2 {
-		panic("OneWayANOVA: requires at least two groups")
+	return oneWayANOVA("OneWayANOVA", groups).TestResult
+}
+
+// OneWayANOVATable is OneWayANOVA returning the full ANOVA table: the sums
+// of squares, degrees of freedom and mean squares between and within groups.
+func OneWayANOVATable(groups [][]float64) ANOVAResult {
+	return oneWayANOVA("OneWayANOVATable", groups)
+}
+
+func oneWayANOVA(name string, groups [][]float64) ANOVAResult {
+	if err := checkGroups(name, groups); err != nil {
+		return ANOVAResult{TestResult: failedWith(err)}
 	}
 
 	totalCount := 0
@@ -44,14 +84,57 @@ func OneWayANOVA(groups [][]float64) TestResult {
 	msb := ssb / dfBetween
 	msw := ssw / dfWithin
 
+	res := ANOVAResult{
+		SSB:       ssb,
+		SSW:       ssw,
+		DFBetween: dfBetween,
+		DFWithin:  dfWithin,
+		MSB:       msb,
+		MSW:       msw,
+	}
+
+	sizes := make([]int, len(groups))
+	for i, group := range groups {
+		sizes[i] = len(group)
+	}
+	res.TestResult = TestResult{
+		Statistic:      math.NaN(),
+		PValue:         math.NaN(),
+		Method:         "One-way analysis of means",
+		StatisticName:  "F",
+		DF:             dfBetween,
+		DF2:            dfWithin,
+		N:              sizes,
+		EffectSize:     ssb / (ssb + ssw),
+		EffectSizeName: "eta squared",
+	}
 	if msw == 0 {
-		return TestResult{Statistic: math.NaN(), PValue: math.NaN()}
+		return res
 	}
 
 	f := msb / msw
-	p := 1 - fDistributionCDF(f, dfBetween, dfWithin)
+	res.Statistic = f
+	res.PValue = probability.FDist{D1: dfBetween, D2: dfWithin}.Survival(f)
+	return res
+}
 
-	return TestResult{Statistic: f, PValue: p}
+// checkGroups validates the groups of a k-sample test: at least two, none
+// empty, and more observations than groups. The error names the test.
+func checkGroups(name string, groups [][]float64) error {
+	if len(groups) < 2 {
+		return errs.New(ErrEmptyInput, name+": requires at least two groups")
+	}
+	total := 0
+	for _, group := range groups {
+		if len(group) == 0 {
+			return errs.New(ErrEmptyInput, name+": groups must not be empty")
+		}
+		total += len(group)
+	}
+	if total <= len(groups) {
+		return errs.New(ErrEmptyInput, name+": need more observations than groups")
+	}
+	return nil
 }
 
 func mean(data []float64) float64 {
@@ -61,14 +144,3 @@ func mean(data []float64) float64 {
 	}
 	return sum / float64(len(data))
 }
-
-// fDistributionCDF is a placeholder using a normal approximation for demonstration.
-// In production, use a proper F-distribution CDF (e.g., from gonum/stat/distuv).
-func fDistributionCDF(f, dfn, dfd float64) float64 {
-	// Approximate F with standard normal CDF for large df
-	if dfn <= 0 || dfd <= 0 {
-		return 0
-	}
-	approxZ := (f - 1) / (2 / math.Sqrt(dfd))
-	return standardNormalCDF(approxZ)
-}
@@ -1,7 +1,9 @@
 package hypothesis
 
 import (
+	"errors"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -57,25 +59,79 @@ func TestOneWayANOVA(t *testing.T) {
 	}
 }
 
-func TestOneWayANOVAPanic(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for fewer than two groups")
-		}
-	}()
-	_ = OneWayANOVA([][]float64{
+func TestOneWayANOVATooFewGroups(t *testing.T) {
+	res := OneWayANOVA([][]float64{
 		{1.0, 2.0, 3.0},
 	}) // only one group
+	if !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("Expected error for fewer than two groups: err = %v", res.Err)
+	}
+}
+
+func TestOneWayANOVATable(t *testing.T) {
+	groups := [][]float64{
+		{4.0, 5.0, 6.0},
+		{10.0, 9.0, 11.0},
+		{7.0, 8.0, 9.0},
+	}
+	got := OneWayANOVATable(groups)
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"SSB", got.SSB, 38},
+		{"SSW", got.SSW, 6},
+		{"DFBetween", got.DFBetween, 2},
+		{"DFWithin", got.DFWithin, 6},
+		{"MSB", got.MSB, 19},
+		{"MSW", got.MSW, 1},
+		{"Statistic", got.Statistic, 19},
+		// F(2, 6): P(F > f) = (1 + 2f/6)^(-3)
+		{"PValue", got.PValue, math.Pow(1+2*19.0/6, -3)},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-10 {
+			t.Errorf("%s = %v; want %v", c.name, c.got, c.want)
+		}
+	}
+
+	table := got.String()
+	for _, want := range []string{"Source", "Between", "Within", "Total"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("ANOVA table missing %q:\n%s", want, table)
+		}
+	}
+
+	if res := OneWayANOVA(groups); res.Statistic != got.Statistic || res.PValue != got.PValue {
+		t.Errorf("OneWayANOVA() = %v, %v; want %v, %v", res.Statistic, res.PValue, got.Statistic, got.PValue)
+	}
+	if res := OneWayANOVATable(groups[:1]); !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("OneWayANOVATable(one group): err = %v", res.Err)
+	}
 }
 
-func TestFDistributionCDFInvalidDF(t *testing.T) {
-	p := fDistributionCDF(1.0, -1, 5) // dfn <= 0
-	if p != 0 {
-		t.Errorf("Expected 0 for invalid dfn, got %v", p)
+func TestOneWayANOVAEmptyGroup(t *testing.T) {
+	for _, groups := range [][][]float64{
+		{{1, 2}, {}},
+		{{1}, {2}},
+	} {
+		res := OneWayANOVA(groups)
+		if !errors.Is(res.Err, ErrEmptyInput) || !math.IsNaN(res.Statistic) {
+			t.Errorf("OneWayANOVA(%v): err = %v", groups, res.Err)
+		}
 	}
+}
 
-	p = fDistributionCDF(1.0, 5, 0) // dfd <= 0
-	if p != 0 {
-		t.Errorf("Expected 0 for invalid dfd, got %v", p)
+func TestOneWayANOVAResultDetails(t *testing.T) {
+	res := OneWayANOVATable([][]float64{{1, 2, 3}, {4, 5, 6, 7}, {8, 9}})
+	if res.DF != res.DFBetween || res.DF2 != res.DFWithin || res.StatisticName != "F" {
+		t.Errorf("DF = %v, %v; want %v, %v", res.DF, res.DF2, res.DFBetween, res.DFWithin)
+	}
+	if math.Abs(res.EffectSize-res.SSB/(res.SSB+res.SSW)) > 1e-15 {
+		t.Errorf("eta squared = %v", res.EffectSize)
+	}
+	if len(res.N) != 3 || res.N[0] != 3 || res.N[1] != 4 || res.N[2] != 2 {
+		t.Errorf("N = %v; want [3 4 2]", res.N)
 	}
 }
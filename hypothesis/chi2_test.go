@@ -1,6 +1,7 @@
 package hypothesis
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -20,21 +21,24 @@ func TestChiSquareGoodnessOfFit(t *testing.T) {
 }
 
 func TestChiSquareGoodnessOfFit_InvalidLength(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic due to mismatched lengths")
-		}
-	}()
-	ChiSquareGoodnessOfFit([]float64{10, 20}, []float64{15})
+	res := ChiSquareGoodnessOfFit([]float64{10, 20}, []float64{15})
+	if !errors.Is(res.Err, ErrLengthMismatch) {
+		t.Errorf("Expected error due to mismatched lengths: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareGoodnessOfFit_ZeroExpected(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic due to zero expected frequency")
-		}
-	}()
-	ChiSquareGoodnessOfFit([]float64{10}, []float64{0})
+	res := ChiSquareGoodnessOfFit([]float64{10, 5}, []float64{15, 0})
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error due to zero expected frequency: err = %v", res.Err)
+	}
+}
+
+func TestChiSquareGoodnessOfFit_OneCategory(t *testing.T) {
+	res := ChiSquareGoodnessOfFit([]float64{10}, []float64{10})
+	if !errors.Is(res.Err, ErrInvalidParameter) || !math.IsNaN(res.PValue) {
+		t.Errorf("Expected error for a single category: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence(t *testing.T) {
@@ -55,111 +59,134 @@ func TestChiSquareTestOfIndependence(t *testing.T) {
 }
 
 func TestChiSquareTestOfIndependence_InvalidRowSize(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic due to inconsistent row sizes")
-		}
-	}()
-	ChiSquareTestOfIndependence([][]float64{
+	res := ChiSquareTestOfIndependence([][]float64{
 		{10, 20},
 		{5},
 	})
+	if !errors.Is(res.Err, ErrLengthMismatch) {
+		t.Errorf("Expected error due to inconsistent row sizes: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_NegativeValue(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic due to negative value")
-		}
-	}()
-	ChiSquareTestOfIndependence([][]float64{
+	res := ChiSquareTestOfIndependence([][]float64{
 		{10, -5},
 		{15, 20},
 	})
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error due to negative value: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_InvalidTableDimensions(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic for malformed contingency table, but no panic occurred")
-		}
-	}()
-	ChiSquareTestOfIndependence([][]float64{
+	res := ChiSquareTestOfIndependence([][]float64{
 		{10, 20},
 		{5},
 	})
+	if !errors.Is(res.Err, ErrLengthMismatch) {
+		t.Errorf("Expected error for malformed contingency table: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_OneRow(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic due to insufficient table rows")
-		}
-	}()
 	table := [][]float64{{10, 20, 30}}
-	ChiSquareTestOfIndependence(table)
+	res := ChiSquareTestOfIndependence(table)
+	if !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("Expected error due to insufficient table rows: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_OneColumn(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic due to insufficient table columns")
-		}
-	}()
 	table := [][]float64{
 		{10},
 		{20},
 	}
-	ChiSquareTestOfIndependence(table)
+	res := ChiSquareTestOfIndependence(table)
+	if !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("Expected error due to insufficient table columns: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_ZeroMarginals(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic due to zero marginal totals")
-		}
-	}()
 	table := [][]float64{
 		{0, 0},
 		{0, 0},
 	}
-	ChiSquareTestOfIndependence(table)
+	res := ChiSquareTestOfIndependence(table)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error due to zero marginal totals: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_ZeroExpectedCell(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected a panic due to zero row total, but function did not panic")
-		}
-	}()
-
 	table := [][]float64{
 		{0, 0},
 		{0, 10},
 	}
 
-	_ = ChiSquareTestOfIndependence(table)
+	res := ChiSquareTestOfIndependence(table)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected an error due to zero row total: err = %v", res.Err)
+	}
 }
 
 func TestChiSquareTestOfIndependence_ZeroColTotal(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic due to zero column total, but no panic occurred")
-		}
-	}()
-
 	table := [][]float64{
 		{0, 1},
 		{0, 1},
 	}
 
-	_ = ChiSquareTestOfIndependence(table)
+	res := ChiSquareTestOfIndependence(table)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error due to zero column total: err = %v", res.Err)
+	}
+}
+
+func TestChiSquarePValues(t *testing.T) {
+	// df = 5: Q(x) = 2(1 - Φ(√x)) + 2φ(√x)·√x·(1 + x/3)
+	gof := ChiSquareGoodnessOfFit([]float64{16, 18, 16, 14, 12, 12}, []float64{16, 16, 16, 16, 16, 8})
+	r := math.Sqrt(gof.Statistic)
+	want := math.Erfc(r/math.Sqrt2) + 2*math.Exp(-r*r/2)/math.Sqrt(2*math.Pi)*r*(1+r*r/3)
+	if math.Abs(gof.PValue-want) > 1e-12 {
+		t.Errorf("ChiSquareGoodnessOfFit().PValue = %.12f; want %.12f", gof.PValue, want)
+	}
+
+	// df = 6: Q(x) = e^(-x/2)·(1 + x/2 + (x/2)²/2)
+	ind := ChiSquareTestOfIndependence([][]float64{
+		{90, 60, 104, 95},
+		{30, 50, 51, 20},
+		{30, 40, 45, 35},
+	})
+	h := ind.Statistic / 2
+	want = math.Exp(-h) * (1 + h + h*h/2)
+	if math.Abs(ind.PValue-want) > 1e-12*want {
+		t.Errorf("ChiSquareTestOfIndependence().PValue = %g; want %g", ind.PValue, want)
+	}
+}
+
+func TestChiSquareGoodnessOfFit_ExtremeStatistic(t *testing.T) {
+	// A huge statistic must give a tiny but non-zero p-value rather than
+	// 1 - 1 = 0. df = 1 here, so p = erfc(√(χ²/2)).
+	res := ChiSquareGoodnessOfFit([]float64{1300, 700}, []float64{1000, 1000})
+	want := math.Erfc(math.Sqrt(res.Statistic / 2))
+	if res.PValue <= 0 || math.Abs(res.PValue-want) > 1e-10*want {
+		t.Errorf("PValue = %g; want %g", res.PValue, want)
+	}
 }
 
-func TestGammaReflectionBranch(t *testing.T) {
-	// z < 0.5 triggers the reflection formula path
-	val := gamma(0.4)
-	if math.IsNaN(val) || val <= 0 {
-		t.Errorf("Expected valid gamma value for z < 0.5, got %v", val)
+func TestChiSquareEffectSizes(t *testing.T) {
+	gof := ChiSquareGoodnessOfFit([]float64{30, 10, 20}, []float64{20, 20, 20})
+	if gof.DF != 2 || gof.N[0] != 60 || math.Abs(gof.EffectSize-math.Sqrt(10.0/60)) > 1e-15 {
+		t.Errorf("goodness of fit: df %v, N %v, w %v", gof.DF, gof.N, gof.EffectSize)
+	}
+
+	// a 2 × 3 table with χ² = 100/9 on 90 observations
+	ind := ChiSquareTestOfIndependence([][]float64{{20, 15, 10}, {10, 15, 20}})
+	want := math.Sqrt(ind.Statistic / 90)
+	if ind.DF != 2 || ind.N[0] != 90 || math.Abs(ind.EffectSize-want) > 1e-15 || ind.EffectSizeName != "Cramér's V" {
+		t.Errorf("independence: df %v, N %v, V %v; want V %v", ind.DF, ind.N, ind.EffectSize, want)
+	}
+	if ind.ConfLevel != 0 || ind.Alternative != 0 {
+		t.Errorf("independence reports an interval or alternative: %+v", ind)
 	}
 }
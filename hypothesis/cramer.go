@@ -0,0 +1,168 @@
+package hypothesis
+
+import "math"
+
+// CramerVonMisesTest tests whether the sample x comes from the continuous
+// distribution with the given cdf by the integrated squared distance
+// between the empirical CDF and cdf. With uᵢ = cdf(x₍ᵢ₎) sorted, the
+// statistic is
+//
+//	W² = 1/(12n) + Σ (uᵢ - (2i-1)/(2n))².
+//
+// The p-value comes from the limiting distribution, applied by default to
+// Stephens' modification (W² - 0.4/n + 0.6/n²)(1 + 1/n), which matches it
+// closely for any n, and with PValueAsymptotic to W² itself; there is no
+// exact p-value. With TestOptions.Lilliefors set, cdf is a normal fitted
+// to x and the p-value follows Stephens' correction as in R's
+// nortest::cvm.test, which needs at least 8 observations. Alternative and
+// ConfLevel do not apply.
+func CramerVonMisesTest(x []float64, cdf func(float64) float64, opts ...TestOptions) TestResult {
+	u, o, res := edfSetup("CramerVonMisesTest", x, cdf, opts)
+	if res.Err != nil {
+		return res
+	}
+	if o.PValueMethod == PValueExact {
+		return failed(ErrInvalidParameter, "CramerVonMisesTest: no exact p-value is available")
+	}
+	n := len(u)
+	nf := float64(n)
+	w2 := 1 / (12 * nf)
+	for i, v := range u {
+		d := v - float64(2*i+1)/(2*nf)
+		w2 += d * d
+	}
+
+	method := "Cramér-von Mises test"
+	var p float64
+	switch {
+	case o.Lilliefors:
+		if o.PValueMethod != PValueAuto {
+			return failed(ErrInvalidParameter, "CramerVonMisesTest: the Lilliefors correction has its own p-values")
+		}
+		if n < 8 {
+			return failed(ErrEmptyInput, "CramerVonMisesTest: the Lilliefors correction needs at least 8 observations")
+		}
+		method = "Cramér-von Mises normality test"
+		p = cvmNormalPValue(w2 * (1 + 0.5/nf))
+	case o.PValueMethod == PValueAsymptotic:
+		p = cvmSurvival(w2)
+	default:
+		p = cvmSurvival((w2 - 0.4/nf + 0.6/(nf*nf)) * (1 + 1/nf))
+	}
+	return TestResult{
+		Statistic:     w2,
+		PValue:        p,
+		Method:        method,
+		StatisticName: "W",
+		N:             []int{n},
+	}
+}
+
+// TwoSampleCramerVonMisesTest tests whether the samples x and y come from
+// the same continuous distribution with Anderson's (1962) statistic
+//
+//	T = n₁n₂ / N² Σ (Fₓ(zₖ) - Fᵧ(zₖ))²
+//
+// summed over the N values zₖ of the pooled sample. The p-value is exact,
+// from the permutation distribution given the ties present, when there are
+// at most a million ways to split the pooled sample, and otherwise comes
+// from the limiting distribution of the one-sample W², which T shares;
+// TestOptions.PValueMethod overrides the choice. Alternative and ConfLevel
+// do not apply.
+func TwoSampleCramerVonMisesTest(x, y []float64, opts ...TestOptions) TestResult {
+	s, o, res := pooledSetup("TwoSampleCramerVonMisesTest", x, y, opts)
+	if res.Err != nil {
+		return res
+	}
+	n1, n2 := float64(s.n1), float64(s.n2)
+	n := n1 + n2
+	var sum float64
+	s.walk(func(_, i, j, _, l int) {
+		d := float64(i)*n2 - float64(j)*n1
+		sum += float64(l) * d * d
+	})
+	t := sum / (n1 * n2 * n * n)
+
+	exact, tooLarge := useExact(o.PValueMethod, false, s.labellings())
+	if tooLarge {
+		return failed(ErrInvalidParameter, "TwoSampleCramerVonMisesTest: samples too large for an exact p-value")
+	}
+	method := "Asymptotic two-sample Cramér-von Mises test"
+	var p float64
+	if exact {
+		// the exact path compares the terms as integers scaled by (n₁n₂)²,
+		// which stay well inside int64 for samples small enough to get here
+		term := func(_, i, j, _, l int) int64 {
+			d := int64(i*s.n2 - j*s.n1)
+			return int64(l) * d * d
+		}
+		var k int64
+		s.walk(func(g, i, j, f, l int) { k += term(g, i, j, f, l) })
+		method = "Exact two-sample Cramér-von Mises test"
+		p = s.sumTail(term, k)
+	} else {
+		p = cvmSurvival(t)
+	}
+	return TestResult{
+		Statistic:     t,
+		PValue:        p,
+		Method:        method,
+		StatisticName: "T",
+		N:             []int{s.n1, s.n2},
+	}
+}
+
+// cvmSurvival returns P(W² > x) under the limiting distribution of the
+// Cramér-von Mises statistic, from Anderson and Darling's (1952) series
+//
+//	P(W² ≤ x) = 1/(π√x) Σ Γ(k+½)/(Γ(½) k!) √(4k+1) e^(-q) K_¼(q),  q = (4k+1)²/(16x).
+func cvmSurvival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	var cdf float64
+	for k := 0; k < 100; k++ {
+		kf := float64(k)
+		q := (4*kf + 1) * (4*kf + 1) / (16 * x)
+		term := math.Exp(lgamma(kf+0.5)-lgamma(0.5)-lgamma(kf+1)) * math.Sqrt(4*kf+1) * scaledBesselK(0.25, q)
+		cdf += term
+		if term <= 1e-17*cdf {
+			break
+		}
+	}
+	return math.Max(0, math.Min(1, 1-cdf/(math.Pi*math.Sqrt(x))))
+}
+
+// scaledBesselK returns e^(-z) K_ν(z), the modified Bessel function of the
+// second kind, from K_ν(z) = ∫₀^∞ e^(-z cosh t) cosh(νt) dt. The trapezoid
+// rule converges geometrically on this smooth, doubly exponentially
+// decaying integrand.
+func scaledBesselK(nu, z float64) float64 {
+	const h = 0.05
+	sum := 0.5 * math.Exp(-2*z)
+	for t := h; ; t += h {
+		f := math.Exp(-z*(1+math.Cosh(t))) * math.Cosh(nu*t)
+		sum += f
+		if f <= 1e-18*sum {
+			break
+		}
+	}
+	return h * sum
+}
+
+// cvmNormalPValue returns the p-value of the modified Cramér-von Mises
+// statistic w = W²(1 + 0.5/n) against a fitted normal distribution
+// (D'Agostino and Stephens, 1986, table 4.9).
+func cvmNormalPValue(w float64) float64 {
+	switch {
+	case w < 0.0275:
+		return -math.Expm1(-13.953 + 775.5*w - 12542.61*w*w)
+	case w < 0.051:
+		return -math.Expm1(-5.903 + 179.546*w - 1515.29*w*w)
+	case w < 0.092:
+		return math.Exp(0.886 - 31.62*w + 10.897*w*w)
+	case w < 1.1:
+		return math.Exp(1.111 - 34.242*w + 12.832*w*w)
+	}
+	return 7.37e-10
+}
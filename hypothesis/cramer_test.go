@@ -0,0 +1,183 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+func TestCramerVonMisesDistribution(t *testing.T) {
+	// K_½(z) = √(π/(2z)) e^(-z)
+	for _, z := range []float64{0.01, 0.3, 2, 15} {
+		want := math.Sqrt(math.Pi/(2*z)) * math.Exp(-2*z)
+		if got := scaledBesselK(0.5, z); math.Abs(got-want) > 1e-12*want {
+			t.Errorf("e^(-z) K_½(%v) = %v; want %v", z, got, want)
+		}
+	}
+	// the tabled critical values of W² in the limit
+	for _, c := range []struct{ x, p float64 }{{0.347, 0.10}, {0.461, 0.05}, {0.743, 0.01}, {1.168, 0.001}} {
+		if got := cvmSurvival(c.x); math.Abs(got-c.p) > 3e-4*(1+100*c.p) {
+			t.Errorf("P(W² > %v) = %v; want %v", c.x, got, c.p)
+		}
+	}
+	// the 5 % point of the modified statistic against a fitted normal
+	if p := cvmNormalPValue(0.126); math.Abs(p-0.05) > 0.001 {
+		t.Errorf("fitted normal: p at 0.126 = %v; want 0.05", p)
+	}
+}
+
+func TestCramerVonMisesTest(t *testing.T) {
+	x := []float64{0.62, 0.05, 0.33, 0.91, 0.48}
+	res := CramerVonMisesTest(x, uniformCDF)
+	u := sorted(x)
+	want := 1.0 / 60
+	for i, v := range u {
+		want += (v - float64(2*i+1)/10) * (v - float64(2*i+1)/10)
+	}
+	if math.Abs(res.Statistic-want) > 1e-15 || res.Method != "Cramér-von Mises test" || res.StatisticName != "W" {
+		t.Errorf("W² = %v (%s); want %v", res.Statistic, res.Method, want)
+	}
+	modified := (want - 0.4/5 + 0.6/25) * 1.2
+	if math.Abs(res.PValue-cvmSurvival(modified)) > 1e-12 {
+		t.Errorf("p = %v; want %v", res.PValue, cvmSurvival(modified))
+	}
+	asym := CramerVonMisesTest(x, uniformCDF, TestOptions{PValueMethod: PValueAsymptotic})
+	if math.Abs(asym.PValue-cvmSurvival(want)) > 1e-12 {
+		t.Errorf("asymptotic p = %v; want %v", asym.PValue, cvmSurvival(want))
+	}
+}
+
+// Stephens' modification gives the nominal level for exponential samples
+// of 10 and, with the Lilliefors correction, for normal samples of 20.
+func TestCramerVonMisesLevel(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	const reps = 5000
+	exponential := probability.Exponential{Lambda: 2}.CDF
+	x := make([]float64, 10)
+	y := make([]float64, 20)
+	var plain, fitted float64
+	for r := 0; r < reps; r++ {
+		for i := range x {
+			x[i] = rng.ExpFloat64() / 2
+		}
+		if CramerVonMisesTest(x, exponential).Reject(0.05) {
+			plain++
+		}
+		for i := range y {
+			y[i] = rng.NormFloat64()
+		}
+		cdf := probability.Normal{Mu: mean(y), Sigma: sampleStdDev(y)}.CDF
+		if CramerVonMisesTest(y, cdf, TestOptions{Lilliefors: true}).Reject(0.05) {
+			fitted++
+		}
+	}
+	if rate := plain / reps; math.Abs(rate-0.05) > 0.01 {
+		t.Errorf("rejection rate %v; want about 0.05", rate)
+	}
+	if rate := fitted / reps; math.Abs(rate-0.05) > 0.01 {
+		t.Errorf("Lilliefors rejection rate %v; want about 0.05", rate)
+	}
+}
+
+func TestTwoSampleCramerVonMisesTest(t *testing.T) {
+	// without ties T = U / (n₁n₂N) - (4n₁n₂ - 1) / (6N), with U from the
+	// ranks rᵢ of x and sⱼ of y in the pooled sample
+	x := []float64{1.2, 3.4, 2.5, 5.0}
+	y := []float64{0.3, 2.2, 1.1, 0.9, 1.4, 4.0}
+	ranks, _ := midranks(append(append([]float64(nil), x...), y...))
+	rx, ry := sorted(ranks[:4]), sorted(ranks[4:])
+	var u float64
+	for i, r := range rx {
+		u += 4 * (r - float64(i+1)) * (r - float64(i+1))
+	}
+	for j, s := range ry {
+		u += 6 * (s - float64(j+1)) * (s - float64(j+1))
+	}
+	want := u/(24*10) - float64(4*24-1)/60
+	res := TwoSampleCramerVonMisesTest(x, y)
+	if math.Abs(res.Statistic-want) > 1e-12 || res.StatisticName != "T" {
+		t.Errorf("T = %v; want %v", res.Statistic, want)
+	}
+
+	x = []float64{1.2, 3.4, 2.2, 5.0, 2.2}
+	y = []float64{0.3, 2.2, 1.1, 0.9, 1.2, 4.0}
+	res = TwoSampleCramerVonMisesTest(x, y)
+	p := labellingTail(x, y, func(a, b []float64) float64 {
+		return TwoSampleCramerVonMisesTest(a, b, TestOptions{PValueMethod: PValueAsymptotic}).Statistic
+	})
+	if res.Method != "Exact two-sample Cramér-von Mises test" || math.Abs(res.PValue-p) > 1e-12 {
+		t.Errorf("%s p = %v; want %v", res.Method, res.PValue, p)
+	}
+
+	rng := rand.New(rand.NewSource(8))
+	x, y = make([]float64, 11), make([]float64, 10)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+	}
+	for i := range y {
+		y[i] = rng.NormFloat64()
+	}
+	exact := TwoSampleCramerVonMisesTest(x, y)
+	asym := TwoSampleCramerVonMisesTest(x, y, TestOptions{PValueMethod: PValueAsymptotic})
+	if exact.Method != "Exact two-sample Cramér-von Mises test" || math.Abs(asym.PValue-exact.PValue) > 0.02 {
+		t.Errorf("%s p = %v; asymptotic p = %v", exact.Method, exact.PValue, asym.PValue)
+	}
+}
+
+func TestTwoSampleCramerVonMisesLargeSample(t *testing.T) {
+	// Σ l(i·n₂ - j·n₁)² is far beyond int64 for samples this size
+	rng := rand.New(rand.NewSource(9))
+	x, y := make([]float64, 20000), make([]float64, 20000)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+		y[i] = rng.NormFloat64() + 0.5
+	}
+	res := TwoSampleCramerVonMisesTest(x, y)
+	if !(res.Statistic > 0) || res.PValue > 1e-10 || res.Err != nil {
+		t.Errorf("T = %v, p = %v, err = %v; want T > 0 and p ≈ 0", res.Statistic, res.PValue, res.Err)
+	}
+
+	// the rank form of T from TestTwoSampleCramerVonMisesTest
+	ranks, _ := midranks(append(append([]float64(nil), x...), y...))
+	var u float64
+	for i, r := range sorted(ranks[:len(x)]) {
+		u += float64(len(x)) * (r - float64(i+1)) * (r - float64(i+1))
+	}
+	for j, r := range sorted(ranks[len(x):]) {
+		u += float64(len(y)) * (r - float64(j+1)) * (r - float64(j+1))
+	}
+	n1, n2 := float64(len(x)), float64(len(y))
+	want := u/(n1*n2*(n1+n2)) - (4*n1*n2-1)/(6*(n1+n2))
+	if math.Abs(res.Statistic-want) > 1e-9*want {
+		t.Errorf("T = %v; want %v", res.Statistic, want)
+	}
+}
+
+func TestCramerVonMisesErrors(t *testing.T) {
+	big := make([]float64, 40)
+	for i := range big {
+		big[i] = float64(i)
+	}
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"empty", CramerVonMisesTest(nil, uniformCDF), ErrEmptyInput},
+		{"nil cdf", CramerVonMisesTest(sleep1, nil), ErrInvalidParameter},
+		{"exact", CramerVonMisesTest(sleep1, uniformCDF, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+		{"Lilliefors small", CramerVonMisesTest(sleep1[:7], uniformCDF, TestOptions{Lilliefors: true}), ErrEmptyInput},
+		{"options", CramerVonMisesTest(sleep1, uniformCDF, TestOptions{}, TestOptions{}), ErrInvalidParameter},
+		{"two-sample empty", TwoSampleCramerVonMisesTest(sleep1, nil), ErrEmptyInput},
+		{"two-sample NaN", TwoSampleCramerVonMisesTest(sleep1, []float64{math.NaN()}), ErrInvalidParameter},
+		{"two-sample too large", TwoSampleCramerVonMisesTest(big, big, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package hypothesis
+
+import (
+	"math"
+	"sort"
+)
+
+// The helpers in this file are shared by the tests based on the empirical
+// distribution function (EDF): Kolmogorov-Smirnov, Anderson-Darling and
+// Cramér-von Mises. The exact two-sample p-values walk the pooled sample
+// one run of tied values at a time; under the null hypothesis every
+// labelling of the pooled values as x or y is equally likely, so the number
+// of x in each run is hypergeometric given the runs before it.
+
+// edfSetup validates the input of a one-sample EDF test and returns the
+// sorted probability-integral transforms cdf(x₍ᵢ₎) and the options, or a
+// failed result.
+func edfSetup(name string, x []float64, cdf func(float64) float64, opts []TestOptions) ([]float64, TestOptions, TestResult) {
+	if len(x) == 0 {
+		return nil, TestOptions{}, failed(ErrEmptyInput, name+": data must not be empty")
+	}
+	if cdf == nil {
+		return nil, TestOptions{}, failed(ErrInvalidParameter, name+": cdf must not be nil")
+	}
+	if !finite(x) {
+		return nil, TestOptions{}, failed(ErrInvalidParameter, name+": data must be finite")
+	}
+	o, err := testOptions(name, opts)
+	if err != nil {
+		return nil, o, failedWith(err)
+	}
+	u := make([]float64, len(x))
+	for i, v := range x {
+		u[i] = cdf(v)
+		if !(u[i] >= 0 && u[i] <= 1) {
+			return nil, o, failed(ErrInvalidParameter, name+": cdf must return values in [0, 1]")
+		}
+	}
+	sort.Float64s(u)
+	return u, o, TestResult{}
+}
+
+// hasTies reports whether the sorted slice u holds a repeated value.
+func hasTies(u []float64) bool {
+	for i := 1; i < len(u); i++ {
+		if u[i] == u[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// pooled is the pooled sample of a two-sample EDF test, reduced to its runs
+// of tied values: sizes[g] values in run g, fromX[g] of them from x.
+type pooled struct {
+	n1, n2 int
+	sizes  []int
+	fromX  []int
+}
+
+// pooledSetup validates the input of a two-sample EDF test and returns the
+// pooled sample and the options, or a failed result.
+func pooledSetup(name string, x, y []float64, opts []TestOptions) (pooled, TestOptions, TestResult) {
+	if len(x) == 0 || len(y) == 0 {
+		return pooled{}, TestOptions{}, failed(ErrEmptyInput, name+": samples must not be empty")
+	}
+	if !finite(x) || !finite(y) {
+		return pooled{}, TestOptions{}, failed(ErrInvalidParameter, name+": data must be finite")
+	}
+	o, err := testOptions(name, opts)
+	if err != nil {
+		return pooled{}, o, failedWith(err)
+	}
+
+	s := pooled{n1: len(x), n2: len(y)}
+	xs, ys := sorted(x), sorted(y)
+	for i, j := 0, 0; i < len(xs) || j < len(ys); {
+		v := math.Inf(1)
+		if i < len(xs) {
+			v = xs[i]
+		}
+		if j < len(ys) && ys[j] < v {
+			v = ys[j]
+		}
+		f := 0
+		for ; i < len(xs) && xs[i] == v; i++ {
+			f++
+		}
+		l := f
+		for ; j < len(ys) && ys[j] == v; j++ {
+			l++
+		}
+		s.sizes = append(s.sizes, l)
+		s.fromX = append(s.fromX, f)
+	}
+	return s, o, TestResult{}
+}
+
+// walk calls visit at the end of each run g of l values, f of them from x,
+// with i and j the numbers of values from x and y up to that point.
+func (s pooled) walk(visit func(g, i, j, f, l int)) {
+	i, b := 0, 0
+	for g, l := range s.sizes {
+		i += s.fromX[g]
+		b += l
+		visit(g, i, b-i, s.fromX[g], l)
+	}
+}
+
+// spread returns the probabilities that f = 0, 1, … of l positions go to
+// x when r of the rest remaining positions do.
+func spread(l, r, rest int) []float64 {
+	if l == 1 && r > 0 {
+		return []float64{1 - float64(r)/float64(rest), float64(r) / float64(rest)}
+	}
+	p := make([]float64, min(l, r)+1)
+	for f := range p {
+		if l-f <= rest-r {
+			p[f] = math.Exp(lchoose(float64(r), float64(f)) +
+				lchoose(float64(rest-r), float64(l-f)) - lchoose(float64(rest), float64(l)))
+		}
+	}
+	return p
+}
+
+// smirnovExact returns the exact P(D ≥ d) of the two-sample
+// Kolmogorov-Smirnov statistic scaled by n₁n₂, for the alternative alt. It
+// follows the probability of the labellings whose EDFs have not yet been d
+// apart and adds up the mass that first reaches d at each run, so the
+// p-value is not lost to cancellation.
+func (s pooled) smirnovExact(d int, alt Alternative) float64 {
+	if d <= 0 {
+		return 1
+	}
+	n := s.n1 + s.n2
+	u := make([]float64, s.n1+1)
+	u[0] = 1
+	var p float64
+	b := 0
+	for _, l := range s.sizes {
+		next := make([]float64, s.n1+1)
+		for i, mass := range u {
+			if mass == 0 {
+				continue
+			}
+			for f, pf := range spread(l, s.n1-i, n-b) {
+				next[i+f] += mass * pf
+			}
+		}
+		b += l
+		for i, mass := range next {
+			dev := i*s.n2 - (b-i)*s.n1
+			switch alt {
+			case Less:
+				dev = -dev
+			case TwoSided:
+				dev = max(dev, -dev)
+			}
+			if dev >= d {
+				p += mass
+				next[i] = 0
+			}
+		}
+		u = next
+	}
+	return math.Min(1, p)
+}
+
+// sumTail returns the exact P(S ≥ observed) of a statistic S = Σ term(g, i,
+// j, f, l) over the runs of the pooled sample, with the arguments of walk.
+// The partial sums are integers, so that equal values merge exactly.
+func (s pooled) sumTail(term func(g, i, j, f, l int) int64, observed int64) float64 {
+	n := s.n1 + s.n2
+	dist := make([]map[int64]float64, s.n1+1)
+	dist[0] = map[int64]float64{0: 1}
+	b := 0
+	for g, l := range s.sizes {
+		next := make([]map[int64]float64, s.n1+1)
+		for i, sums := range dist {
+			if len(sums) == 0 {
+				continue
+			}
+			for f, pf := range spread(l, s.n1-i, n-b) {
+				if pf == 0 {
+					continue
+				}
+				t := term(g, i+f, b+l-i-f, f, l)
+				if next[i+f] == nil {
+					next[i+f] = make(map[int64]float64)
+				}
+				for v, mass := range sums {
+					next[i+f][v+t] += mass * pf
+				}
+			}
+		}
+		b += l
+		dist = next
+	}
+	var p float64
+	for v, mass := range dist[s.n1] {
+		if v >= observed {
+			p += mass
+		}
+	}
+	return math.Min(1, p)
+}
+
+// labellings returns C(n₁+n₂, n₁), the number of ways to label the pooled
+// sample, which bounds the work of sumTail.
+func (s pooled) labellings() float64 {
+	return math.Exp(lchoose(float64(s.n1+s.n2), float64(s.n1)))
+}
@@ -0,0 +1,68 @@
+package hypothesis
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+// labellingTail returns the share of the splits of the pooled sample into
+// groups of len(x) and len(y) whose statistic is at least the observed one:
+// the exact permutation p-value, by enumeration.
+func labellingTail(x, y []float64, statistic func(x, y []float64) float64) float64 {
+	all := append(append([]float64(nil), x...), y...)
+	observed := statistic(x, y)
+	var hits, total float64
+	for mask := uint(0); mask < 1<<len(all); mask++ {
+		if bits.OnesCount(mask) != len(x) {
+			continue
+		}
+		var a, b []float64
+		for i, v := range all {
+			if mask&(1<<i) != 0 {
+				a = append(a, v)
+			} else {
+				b = append(b, v)
+			}
+		}
+		total++
+		if statistic(a, b) >= observed-1e-9 {
+			hits++
+		}
+	}
+	return hits / total
+}
+
+func TestPooledRuns(t *testing.T) {
+	s, _, res := pooledSetup("test", []float64{3, 1, 2, 2}, []float64{2, 5, 1}, nil)
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	wantSizes, wantX := []int{2, 3, 1, 1}, []int{1, 2, 1, 0}
+	for g := range wantSizes {
+		if s.sizes[g] != wantSizes[g] || s.fromX[g] != wantX[g] {
+			t.Fatalf("runs %v from x %v; want %v, %v", s.sizes, s.fromX, wantSizes, wantX)
+		}
+	}
+	var ends [][2]int
+	s.walk(func(_, i, j, _, _ int) { ends = append(ends, [2]int{i, j}) })
+	if ends[1] != [2]int{3, 2} || ends[3] != [2]int{4, 3} {
+		t.Errorf("walk visited %v", ends)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, c := range [][3]int{{1, 3, 7}, {3, 2, 9}, {4, 6, 8}, {5, 0, 5}} {
+		var total float64
+		for _, p := range spread(c[0], c[1], c[2]) {
+			total += p
+		}
+		if math.Abs(total-1) > 1e-12 {
+			t.Errorf("spread%v sums to %v", c, total)
+		}
+	}
+	// two of four positions to x out of three of nine: C(3,2)C(6,2)/C(9,4)
+	if p := spread(4, 3, 9)[2]; math.Abs(p-45.0/126) > 1e-12 {
+		t.Errorf("spread(4, 3, 9)[2] = %v; want %v", p, 45.0/126)
+	}
+}
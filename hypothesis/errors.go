@@ -0,0 +1,26 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// Sentinel errors, the same values in every package; see internal/errs.
+var (
+	ErrEmptyInput       = errs.ErrEmptyInput
+	ErrLengthMismatch   = errs.ErrLengthMismatch
+	ErrInvalidParameter = errs.ErrInvalidParameter
+	ErrSingularMatrix   = errs.ErrSingularMatrix
+)
+
+// failed is the result of a test that cannot run on its inputs: a NaN
+// statistic and p-value and an error matching kind.
+func failed(kind error, msg string) TestResult {
+	return failedWith(errs.New(kind, msg))
+}
+
+// failedWith is failed for an error already built.
+func failedWith(err error) TestResult {
+	return TestResult{Statistic: math.NaN(), PValue: math.NaN(), Err: err}
+}
@@ -0,0 +1,315 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+// KruskalWallisTest tests whether k independent groups come from the same
+// distribution, the rank-based counterpart of OneWayANOVA. The statistic
+//
+//	H = (12 / (N(N+1)) Σ Rᵢ² / nᵢ - 3(N+1)) / (1 - Σ (t³ - t) / (N³ - N))
+//
+// uses the rank sums Rᵢ of the groups in the pooled sample of N, corrected
+// for groups of t tied values. The p-value is exact, by enumerating the
+// assignments of the ranks to the groups, for small samples without ties,
+// and otherwise comes from the chi-square distribution on k-1 degrees of
+// freedom; TestOptions.PValueMethod overrides the choice, and its other
+// fields do not apply. The effect size is ε² = H / (N-1).
+func KruskalWallisTest(groups [][]float64, opts ...TestOptions) TestResult {
+	if err := checkGroups("KruskalWallisTest", groups); err != nil {
+		return failedWith(err)
+	}
+	var all []float64
+	sizes := make([]int, len(groups))
+	for i, group := range groups {
+		if !finite(group) {
+			return failed(ErrInvalidParameter, "KruskalWallisTest: data must be finite")
+		}
+		all = append(all, group...)
+		sizes[i] = len(group)
+	}
+	o, err := testOptions("KruskalWallisTest", opts)
+	if err != nil {
+		return failedWith(err)
+	}
+
+	n := float64(len(all))
+	ranks, ties := midranks(all)
+	correction := 1 - ties/(n*n*n-n)
+	if correction == 0 {
+		return failed(ErrInvalidParameter, "KruskalWallisTest: all observations are tied")
+	}
+	h := (12/(n*(n+1))*rankSumScore(ranks, sizes) - 3*(n+1)) / correction
+	df := float64(len(groups) - 1)
+
+	assignments := math.Exp(lgamma(n + 1))
+	for _, size := range sizes {
+		assignments /= math.Exp(lgamma(float64(size) + 1))
+	}
+	exact, tooLarge := useExact(o.PValueMethod, ties > 0, assignments*float64(len(all)))
+	if tooLarge {
+		return failed(ErrInvalidParameter, "KruskalWallisTest: samples too large for an exact p-value")
+	}
+	method := "Kruskal-Wallis rank sum test"
+	var p float64
+	if exact {
+		method = "Kruskal-Wallis rank sum exact test"
+		p = kruskalExact(ranks, sizes)
+	} else {
+		p = probability.ChiSquared{K: df}.Survival(h)
+	}
+
+	return TestResult{
+		Statistic:      h,
+		PValue:         p,
+		Method:         method,
+		StatisticName:  "Kruskal-Wallis chi-squared",
+		DF:             df,
+		N:              sizes,
+		EffectSize:     h / (n - 1),
+		EffectSizeName: "epsilon squared",
+	}
+}
+
+// rankSumScore returns Σ Rᵢ² / nᵢ for the groups of the given sizes, which
+// hold consecutive runs of ranks.
+func rankSumScore(ranks []float64, sizes []int) float64 {
+	var score float64
+	start := 0
+	for _, size := range sizes {
+		var r float64
+		for _, v := range ranks[start : start+size] {
+			r += v
+		}
+		score += r * r / float64(size)
+		start += size
+	}
+	return score
+}
+
+// kruskalExact returns P(H ≥ H_obs) under the permutation distribution, by
+// enumerating every assignment of the ranks to groups of the given sizes.
+// H increases with Σ Rᵢ² / nᵢ, which it compares directly.
+func kruskalExact(ranks []float64, sizes []int) float64 {
+	observed := rankSumScore(ranks, sizes)
+	threshold := observed * (1 - 1e-12)
+	remaining := append([]int(nil), sizes...)
+	sums := make([]float64, len(sizes))
+	var hits, total float64
+
+	var assign func(i int)
+	assign = func(i int) {
+		if i == len(ranks) {
+			var score float64
+			for g, s := range sums {
+				score += s * s / float64(sizes[g])
+			}
+			total++
+			if score >= threshold {
+				hits++
+			}
+			return
+		}
+		for g := range remaining {
+			if remaining[g] == 0 {
+				continue
+			}
+			remaining[g]--
+			sums[g] += ranks[i]
+			assign(i + 1)
+			sums[g] -= ranks[i]
+			remaining[g]++
+		}
+	}
+	assign(0)
+	return hits / total
+}
+
+// FriedmanTest tests whether k treatments differ in a randomized complete
+// block design, the rank-based counterpart of a two-way ANOVA without
+// replication. blocks[i][j] is the observation of treatment j in block i;
+// ranking within each block removes the block effects. The statistic
+//
+//	Q = 12 Σ (Rⱼ - n(k+1)/2)² / (nk(k+1) - Σ (t³ - t) / (k-1))
+//
+// uses the treatment rank sums Rⱼ over n blocks, corrected for groups of t
+// tied values within a block. The p-value is exact, from the distribution
+// of the rank sums over all within-block permutations, for small designs
+// without ties, and otherwise comes from the chi-square distribution on k-1
+// degrees of freedom; TestOptions.PValueMethod overrides the choice, and
+// its other fields do not apply. The effect size is Kendall's coefficient
+// of concordance W = Q / (n(k-1)).
+func FriedmanTest(blocks [][]float64, opts ...TestOptions) TestResult {
+	if len(blocks) < 2 {
+		return failed(ErrEmptyInput, "FriedmanTest: need at least 2 blocks")
+	}
+	k := len(blocks[0])
+	if k < 2 {
+		return failed(ErrEmptyInput, "FriedmanTest: need at least 2 treatments")
+	}
+	for _, block := range blocks {
+		if len(block) != k {
+			return failed(ErrLengthMismatch, "FriedmanTest: all blocks must have one observation per treatment")
+		}
+		if !finite(block) {
+			return failed(ErrInvalidParameter, "FriedmanTest: data must be finite")
+		}
+	}
+	o, err := testOptions("FriedmanTest", opts)
+	if err != nil {
+		return failedWith(err)
+	}
+
+	n := len(blocks)
+	blockRanks := make([][]float64, n)
+	rankSums := make([]float64, k)
+	var ties float64
+	for i, block := range blocks {
+		r, t := midranks(block)
+		blockRanks[i] = r
+		ties += t
+		for j, v := range r {
+			rankSums[j] += v
+		}
+	}
+	nf, kf := float64(n), float64(k)
+	var ss float64
+	for _, r := range rankSums {
+		d := r - nf*(kf+1)/2
+		ss += d * d
+	}
+	den := nf*kf*(kf+1) - ties/(kf-1)
+	if den == 0 {
+		return failed(ErrInvalidParameter, "FriedmanTest: all observations within each block are tied")
+	}
+	q := 12 * ss / den
+	df := kf - 1
+
+	// the reachable rank-sum vectors, times the permutations per block
+	// and the number of blocks
+	perBlock := math.Exp(lgamma(kf + 1))
+	states := math.Min(math.Pow(perBlock, nf-1), math.Pow(nf*(kf-1)+1, kf-1))
+	exact, tooLarge := useExact(o.PValueMethod, ties > 0, states*perBlock*nf)
+	if tooLarge || (exact && kf*math.Log2(2*nf*kf+1) > 62) {
+		return failed(ErrInvalidParameter, "FriedmanTest: design too large for an exact p-value")
+	}
+	method := "Friedman rank sum test"
+	var p float64
+	if exact {
+		method = "Friedman rank sum exact test"
+		p = friedmanExact(blockRanks)
+	} else {
+		p = probability.ChiSquared{K: df}.Survival(q)
+	}
+
+	sizes := make([]int, k)
+	for j := range sizes {
+		sizes[j] = n
+	}
+	return TestResult{
+		Statistic:      q,
+		PValue:         p,
+		Method:         method,
+		StatisticName:  "Friedman chi-squared",
+		DF:             df,
+		N:              sizes,
+		EffectSize:     q / (nf * (kf - 1)),
+		EffectSizeName: "Kendall's W",
+	}
+}
+
+// friedmanExact returns P(Q ≥ Q_obs) under the distribution of the
+// treatment rank sums when each block's ranks are permuted independently.
+// Q increases with Σ Rⱼ², compared exactly on doubled ranks. The rank-sum
+// vectors are packed into one integer, digit j in base 2nk+1 holding the
+// doubled sum of treatment j, so that adding a block's permutation is one
+// addition. The first block stays fixed, since Σ Rⱼ² does not depend on
+// the labels of the treatments.
+func friedmanExact(blockRanks [][]float64) float64 {
+	n, k := len(blockRanks), len(blockRanks[0])
+	base := 2*n*k + 1
+	place := make([]int, k)
+	place[0] = 1
+	for j := 1; j < k; j++ {
+		place[j] = place[j-1] * base
+	}
+	pack := func(r []int) int {
+		key := 0
+		for j, v := range r {
+			key += v * place[j]
+		}
+		return key
+	}
+	perms := permutations(k)
+
+	observed := 0
+	sums := make([]int, k)
+	for _, r := range blockRanks {
+		for j, v := range doubled(r) {
+			sums[j] += v
+		}
+	}
+	for _, s := range sums {
+		observed += s * s
+	}
+
+	dist := map[int]float64{pack(doubled(blockRanks[0])): 1}
+	for _, r := range blockRanks[1:] {
+		r2 := doubled(r)
+		steps := make([]int, len(perms))
+		permuted := make([]int, k)
+		for i, perm := range perms {
+			for j, from := range perm {
+				permuted[j] = r2[from]
+			}
+			steps[i] = pack(permuted)
+		}
+		next := make(map[int]float64, len(dist)*2)
+		w := 1 / float64(len(perms))
+		for key, prob := range dist {
+			for _, step := range steps {
+				next[key+step] += prob * w
+			}
+		}
+		dist = next
+	}
+
+	var p float64
+	for key, prob := range dist {
+		score := 0
+		for j := 0; j < k; j++ {
+			s := key / place[j] % base
+			score += s * s
+		}
+		if score >= observed {
+			p += prob
+		}
+	}
+	return math.Min(1, p)
+}
+
+// permutations returns every permutation of 0, …, k-1.
+func permutations(k int) [][]int {
+	if k == 0 {
+		return [][]int{{}}
+	}
+	var out [][]int
+	for _, perm := range permutations(k - 1) {
+		for pos := 0; pos <= len(perm); pos++ {
+			p := make([]int, 0, k)
+			p = append(p, perm[:pos]...)
+			p = append(p, k-1)
+			p = append(p, perm[pos:]...)
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// lgamma is math.Lgamma without the sign.
+func lgamma(x float64) float64 {
+	v, _ := math.Lgamma(x)
+	return v
+}
@@ -0,0 +1,168 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// RoundingTimes of Hollander & Wolfe: times to first base for 22 players
+// (blocks) under three methods of rounding first base (treatments).
+var roundingTimes = [][]float64{
+	{5.40, 5.50, 5.55}, {5.85, 5.70, 5.75}, {5.20, 5.60, 5.50}, {5.55, 5.50, 5.40},
+	{5.90, 5.85, 5.70}, {5.45, 5.55, 5.60}, {5.40, 5.40, 5.35}, {5.45, 5.50, 5.35},
+	{5.25, 5.15, 5.00}, {5.85, 5.80, 5.70}, {5.25, 5.20, 5.10}, {5.65, 5.55, 5.45},
+	{5.60, 5.35, 5.45}, {5.05, 5.00, 4.95}, {5.50, 5.50, 5.40}, {5.45, 5.55, 5.50},
+	{5.55, 5.55, 5.35}, {5.45, 5.50, 5.55}, {5.50, 5.45, 5.25}, {5.65, 5.60, 5.40},
+	{5.70, 5.65, 5.55}, {6.30, 6.30, 6.25},
+}
+
+func TestKruskalWallisMatchesR(t *testing.T) {
+	// kruskal.test(list(x, y, z)) from R's documentation
+	x := []float64{2.9, 3.0, 2.5, 2.6, 3.2}
+	y := []float64{3.8, 2.7, 4.0, 2.4}
+	z := []float64{2.8, 3.4, 3.7, 2.2, 2.0}
+	res := KruskalWallisTest([][]float64{x, y, z})
+	if res.Err != nil || math.Abs(res.Statistic-0.77143) > 1e-5 || math.Abs(res.PValue-0.68) > 5e-5 {
+		t.Errorf("H = %v, p = %v, err %v; want 0.77143, 0.68", res.Statistic, res.PValue, res.Err)
+	}
+	if res.DF != 2 || res.Method != "Kruskal-Wallis rank sum test" || res.EffectSize != res.Statistic/13 {
+		t.Errorf("DF %v, Method %q, EffectSize %v", res.DF, res.Method, res.EffectSize)
+	}
+
+	// kruskal.test(count ~ spray, data = InsectSprays), with ties
+	res = KruskalWallisTest(insectSprays)
+	if math.Abs(res.Statistic-54.691) > 5e-4 || math.Abs(res.PValue-1.511e-10)/1.511e-10 > 1e-3 {
+		t.Errorf("InsectSprays: H = %v, p = %v; want 54.691, 1.511e-10", res.Statistic, res.PValue)
+	}
+}
+
+// For two groups H is the square of the standardized rank sum, so the exact
+// Kruskal-Wallis p-value is the two-sided exact rank sum p-value.
+func TestKruskalWallisExactTwoGroups(t *testing.T) {
+	kw := KruskalWallisTest([][]float64{permeabX, permeabY})
+	mw := MannWhitneyUTest(permeabX, permeabY)
+	if kw.Method != "Kruskal-Wallis rank sum exact test" || math.Abs(kw.PValue-mw.PValue) > 1e-12 {
+		t.Errorf("%s p = %v; rank sum p = %v", kw.Method, kw.PValue, mw.PValue)
+	}
+	asym := KruskalWallisTest([][]float64{permeabX, permeabY}, TestOptions{PValueMethod: PValueAsymptotic})
+	if asym.Statistic != kw.Statistic || asym.Method != "Kruskal-Wallis rank sum test" {
+		t.Errorf("asymptotic: %+v", asym)
+	}
+}
+
+func TestFriedmanMatchesR(t *testing.T) {
+	// friedman.test(RoundingTimes)
+	res := FriedmanTest(roundingTimes)
+	if res.Err != nil || math.Abs(res.Statistic-11.143) > 5e-4 || math.Abs(res.PValue-0.003805) > 5e-7 {
+		t.Errorf("Q = %v, p = %v, err %v; want 11.143, 0.003805", res.Statistic, res.PValue, res.Err)
+	}
+	if res.DF != 2 || res.Method != "Friedman rank sum test" || len(res.N) != 3 || res.N[0] != 22 {
+		t.Errorf("DF %v, Method %q, N %v", res.DF, res.Method, res.N)
+	}
+	if w := res.Statistic / 44; math.Abs(res.EffectSize-w) > 1e-15 || res.EffectSizeName != "Kendall's W" {
+		t.Errorf("EffectSize %v %q; want Kendall's W %v", res.EffectSize, res.EffectSizeName, w)
+	}
+}
+
+// The exact Friedman p-value, ties included, against an enumeration of
+// every within-block permutation of a small design.
+func TestFriedmanExactEnumeration(t *testing.T) {
+	blocks := [][]float64{{1, 3, 2, 5}, {2, 2, 4, 3}, {6, 1, 5, 4}}
+	ranks := make([][]float64, len(blocks))
+	for i, b := range blocks {
+		ranks[i], _ = midranks(b)
+	}
+	score := func(r [][]float64) float64 {
+		var s float64
+		for j := range r[0] {
+			var sum float64
+			for _, block := range r {
+				sum += block[j]
+			}
+			s += sum * sum
+		}
+		return s
+	}
+	observed := score(ranks)
+	perms := permutations(4)
+	var hits, total float64
+	permuted := make([][]float64, len(ranks))
+	for i := range permuted {
+		permuted[i] = make([]float64, 4)
+	}
+	var walk func(i int)
+	walk = func(i int) {
+		if i == len(ranks) {
+			total++
+			if score(permuted) >= observed-1e-9 {
+				hits++
+			}
+			return
+		}
+		for _, perm := range perms {
+			for j, from := range perm {
+				permuted[i][j] = ranks[i][from]
+			}
+			walk(i + 1)
+		}
+	}
+	walk(0)
+
+	res := FriedmanTest(blocks, TestOptions{PValueMethod: PValueExact})
+	if res.Method != "Friedman rank sum exact test" || math.Abs(res.PValue-hits/total) > 1e-12 {
+		t.Errorf("%s p = %v; want %v", res.Method, res.PValue, hits/total)
+	}
+	if auto := FriedmanTest(blocks); auto.Method != "Friedman rank sum test" {
+		t.Errorf("ties should select the chi-square approximation, got %q", auto.Method)
+	}
+}
+
+// With two treatments the Friedman test is the two-sided sign test.
+func TestFriedmanExactSignTest(t *testing.T) {
+	blocks := make([][]float64, len(sleep1))
+	wins := 0
+	for i := range blocks {
+		blocks[i] = []float64{sleep1[i], sleep2[i] + 0.05*float64(i+1)}
+		if blocks[i][0] > blocks[i][1] {
+			wins++
+		}
+	}
+	n := len(blocks)
+	extreme := min(wins, n-wins)
+	var p float64
+	for k := 0; k <= n; k++ {
+		if k <= extreme || k >= n-extreme {
+			p += math.Exp(lgamma(float64(n+1))-lgamma(float64(k+1))-lgamma(float64(n-k+1))) / math.Pow(2, float64(n))
+		}
+	}
+	res := FriedmanTest(blocks)
+	if res.Method != "Friedman rank sum exact test" || math.Abs(res.PValue-p) > 1e-12 {
+		t.Errorf("%s p = %v; sign test p = %v", res.Method, res.PValue, p)
+	}
+}
+
+func TestRankANOVAErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"kruskal one group", KruskalWallisTest([][]float64{{1, 2}}), ErrEmptyInput},
+		{"kruskal empty group", KruskalWallisTest([][]float64{{1, 2}, {}}), ErrEmptyInput},
+		{"kruskal NaN", KruskalWallisTest([][]float64{{1, math.NaN()}, {2, 3}}), ErrInvalidParameter},
+		{"kruskal tied", KruskalWallisTest([][]float64{{1, 1}, {1, 1}}), ErrInvalidParameter},
+		{"kruskal options", KruskalWallisTest(insectSprays, TestOptions{}, TestOptions{}), ErrInvalidParameter},
+		{"kruskal too large", KruskalWallisTest(insectSprays, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+		{"friedman one block", FriedmanTest([][]float64{{1, 2, 3}}), ErrEmptyInput},
+		{"friedman one treatment", FriedmanTest([][]float64{{1}, {2}}), ErrEmptyInput},
+		{"friedman ragged", FriedmanTest([][]float64{{1, 2}, {1, 2, 3}}), ErrLengthMismatch},
+		{"friedman Inf", FriedmanTest([][]float64{{1, 2}, {math.Inf(1), 3}}), ErrInvalidParameter},
+		{"friedman tied", FriedmanTest([][]float64{{1, 1}, {2, 2}}), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/stat"
+)
+
+// KolmogorovSmirnovTest tests whether the sample x comes from the
+// continuous distribution with the given cdf, which can be any function
+// such as probability.Normal{Mu: 0, Sigma: 1}.CDF or a closure over
+// probability.ExponentialCDF. The statistic is the largest distance
+// between the empirical CDF of x and cdf: D for the two-sided test, D^+ =
+// sup (Fₙ - F) for Greater and D^- = sup (F - Fₙ) for Less, the empirical
+// CDF lying above or below cdf respectively.
+//
+// The p-value is exact, by Marsaglia, Tsang and Wang's method for D and the
+// Birnbaum-Tingey formula for D^±, for samples under 100 without ties, and
+// otherwise comes from Kolmogorov's limiting distribution;
+// TestOptions.PValueMethod overrides the choice. When the parameters of a
+// normal cdf are estimated from x, set TestOptions.Lilliefors or use
+// LillieforsTest: the usual p-values are then far too large.
+func KolmogorovSmirnovTest(x []float64, cdf func(float64) float64, opts ...TestOptions) TestResult {
+	u, o, res := edfSetup("KolmogorovSmirnovTest", x, cdf, opts)
+	if res.Err != nil {
+		return res
+	}
+	n := len(u)
+	nf := float64(n)
+	var dPlus, dMinus float64
+	for i, v := range u {
+		dPlus = math.Max(dPlus, float64(i+1)/nf-v)
+		dMinus = math.Max(dMinus, v-float64(i)/nf)
+	}
+	d, name := math.Max(dPlus, dMinus), "D"
+	switch o.Alternative {
+	case Greater:
+		d, name = dPlus, "D^+"
+	case Less:
+		d, name = dMinus, "D^-"
+	}
+
+	if o.Lilliefors {
+		if o.Alternative != TwoSided || o.PValueMethod != PValueAuto {
+			return failed(ErrInvalidParameter, "KolmogorovSmirnovTest: the Lilliefors correction is two-sided with its own p-values")
+		}
+		if n < 5 {
+			return failed(ErrEmptyInput, "KolmogorovSmirnovTest: the Lilliefors correction needs at least 5 observations")
+		}
+		return TestResult{
+			Statistic:     d,
+			PValue:        lillieforsPValue(d, n),
+			Method:        "Lilliefors (Kolmogorov-Smirnov) normality test",
+			StatisticName: name,
+			Alternative:   o.Alternative,
+			N:             []int{n},
+		}
+	}
+
+	// the exact two-sided distribution costs about (2nd)³ log n steps
+	m := 2*math.Floor(nf*d) + 1
+	work := m * m * m * math.Log2(nf+1)
+	if o.Alternative != TwoSided {
+		work = nf
+	}
+	ties := hasTies(u)
+	exact, tooLarge := useExact(o.PValueMethod, ties || n >= 100, work)
+	if tooLarge {
+		return failed(ErrInvalidParameter, "KolmogorovSmirnovTest: sample too large for an exact p-value")
+	}
+	if o.PValueMethod == PValueAuto {
+		// R's rule, which stays under about 1e8 steps
+		exact = !ties && n < 100
+	}
+	method := "Asymptotic one-sample Kolmogorov-Smirnov test"
+	var p float64
+	switch {
+	case exact && o.Alternative == TwoSided:
+		method = "Exact one-sample Kolmogorov-Smirnov test"
+		p = kolmogorovExact(n, d)
+	case exact:
+		method = "Exact one-sample Kolmogorov-Smirnov test"
+		p = smirnovOneSided(n, d)
+	case o.Alternative == TwoSided:
+		p = kolmogorovSurvival(math.Sqrt(nf) * d)
+	default:
+		p = math.Exp(-2 * nf * d * d)
+	}
+	return TestResult{
+		Statistic:     d,
+		PValue:        p,
+		Method:        method,
+		StatisticName: name,
+		Alternative:   o.Alternative,
+		N:             []int{n},
+	}
+}
+
+// LillieforsTest tests x for normality with the Kolmogorov-Smirnov
+// statistic against the normal distribution whose mean and standard
+// deviation are estimated from x. The p-value uses Dallal and Wilkinson's
+// approximation to Lilliefors' distribution, as R's nortest::lillie.test
+// does. Err is set for fewer than 5 observations or a constant sample.
+func LillieforsTest(x []float64) TestResult {
+	if len(x) < 5 {
+		return failed(ErrEmptyInput, "LillieforsTest: need at least 5 observations")
+	}
+	if !finite(x) {
+		return failed(ErrInvalidParameter, "LillieforsTest: data must be finite")
+	}
+	mu, sigma := stat.Mean(x), stat.StdDev(x)
+	if !(sigma > 0) {
+		return failed(ErrInvalidParameter, "LillieforsTest: data must not be constant")
+	}
+	cdf := func(v float64) float64 { return standardNormal.CDF((v - mu) / sigma) }
+	return KolmogorovSmirnovTest(x, cdf, TestOptions{Lilliefors: true})
+}
+
+// TwoSampleKolmogorovSmirnovTest tests whether the samples x and y come
+// from the same continuous distribution. The statistic is the largest
+// distance between their empirical CDFs: D for the two-sided test, D^+ =
+// sup (Fₓ - Fᵧ) for Greater and D^- = sup (Fᵧ - Fₓ) for Less.
+//
+// The p-value is exact, from the permutation distribution given the ties
+// present, when n₁n₂ < 10000, and otherwise comes from Kolmogorov's
+// limiting distribution; TestOptions.PValueMethod overrides the choice.
+func TwoSampleKolmogorovSmirnovTest(x, y []float64, opts ...TestOptions) TestResult {
+	s, o, res := pooledSetup("TwoSampleKolmogorovSmirnovTest", x, y, opts)
+	if res.Err != nil {
+		return res
+	}
+	// distances are kept as integers, scaled by n₁n₂
+	var dPlus, dMinus int
+	s.walk(func(_, i, j, _, _ int) {
+		dev := i*s.n2 - j*s.n1
+		dPlus, dMinus = max(dPlus, dev), max(dMinus, -dev)
+	})
+	d, name := max(dPlus, dMinus), "D"
+	switch o.Alternative {
+	case Greater:
+		d, name = dPlus, "D^+"
+	case Less:
+		d, name = dMinus, "D^-"
+	}
+	m := float64(s.n1) * float64(s.n2)
+	statistic := float64(d) / m
+
+	// the exact distribution costs about N·n₁ steps
+	exact, tooLarge := useExact(o.PValueMethod, m >= 10000, float64(s.n1+s.n2)*float64(s.n1+1))
+	if tooLarge {
+		return failed(ErrInvalidParameter, "TwoSampleKolmogorovSmirnovTest: samples too large for an exact p-value")
+	}
+	method := "Asymptotic two-sample Kolmogorov-Smirnov test"
+	var p float64
+	switch {
+	case exact:
+		method = "Exact two-sample Kolmogorov-Smirnov test"
+		p = s.smirnovExact(d, o.Alternative)
+	case o.Alternative == TwoSided:
+		p = kolmogorovSurvival(math.Sqrt(m/float64(s.n1+s.n2)) * statistic)
+	default:
+		p = math.Exp(-2 * m / float64(s.n1+s.n2) * statistic * statistic)
+	}
+	return TestResult{
+		Statistic:     statistic,
+		PValue:        p,
+		Method:        method,
+		StatisticName: name,
+		Alternative:   o.Alternative,
+		N:             []int{s.n1, s.n2},
+	}
+}
+
+// kolmogorovSurvival returns P(K > x) for Kolmogorov's limiting
+// distribution of √n D, from the series in exp(-(2k-1)²π²/(8x²)) for small
+// x and the alternating series in exp(-2k²x²) otherwise.
+func kolmogorovSurvival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if x < 1 {
+		var cdf float64
+		for k := 1; k <= 10; k++ {
+			m := float64(2*k - 1)
+			cdf += math.Exp(-m * m * math.Pi * math.Pi / (8 * x * x))
+		}
+		return 1 - math.Sqrt(2*math.Pi)/x*cdf
+	}
+	var p float64
+	sign := 1.0
+	for k := 1; k <= 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * x * x)
+		p += sign * term
+		if term < 1e-17 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Min(1, 2*p)
+}
+
+// smirnovOneSided returns the exact P(D^+ ≥ d) for a sample of n, which is
+// also that of D^-, by the Birnbaum-Tingey formula
+//
+//	d Σ_{j=0}^{⌊n(1-d)⌋} C(n, j) (1 - d - j/n)^(n-j) (d + j/n)^(j-1).
+func smirnovOneSided(n int, d float64) float64 {
+	if d <= 0 {
+		return 1
+	}
+	if d >= 1 {
+		return 0
+	}
+	nf := float64(n)
+	var p float64
+	for j := 0; j <= int(math.Floor(nf*(1-d))); j++ {
+		jf := float64(j)
+		rest := 1 - d - jf/nf
+		if rest <= 0 {
+			continue
+		}
+		p += math.Exp(lchoose(nf, jf) + (nf-jf)*math.Log(rest) + (jf-1)*math.Log(d+jf/nf))
+	}
+	return math.Min(1, d*p)
+}
+
+// kolmogorovExact returns the exact P(D ≥ d) for a sample of n by the
+// matrix method of Marsaglia, Tsang and Wang (2003), as in R's ks.test.
+// For d > 1/2 the one-sided events are disjoint, and the p-value is twice
+// the one-sided one, which keeps its precision far in the tail.
+func kolmogorovExact(n int, d float64) float64 {
+	if d <= 0 {
+		return 1
+	}
+	if d >= 1 {
+		return 0
+	}
+	if d > 0.5 {
+		return 2 * smirnovOneSided(n, d)
+	}
+	nf := float64(n)
+	k := int(nf*d) + 1
+	m := 2*k - 1
+	h := float64(k) - nf*d
+
+	H := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 >= 0 {
+				H[i*m+j] = 1
+			}
+		}
+	}
+	for i := 0; i < m; i++ {
+		H[i*m] -= math.Pow(h, float64(i+1))
+		H[(m-1)*m+i] -= math.Pow(h, float64(m-i))
+	}
+	if 2*h-1 > 0 {
+		H[(m-1)*m] += math.Pow(2*h-1, float64(m))
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			for g := 2; g <= i-j+1; g++ {
+				H[i*m+j] /= float64(g)
+			}
+		}
+	}
+
+	Q, e := matrixPower(H, m, n)
+	s := Q[(k-1)*m+k-1]
+	for i := 1; i <= n; i++ {
+		s = s * float64(i) / nf
+		if s < 1e-140 {
+			s *= 1e140
+			e -= 140
+		}
+	}
+	cdf := s * math.Pow(10, float64(e))
+	return math.Max(0, math.Min(1, 1-cdf))
+}
+
+// matrixPower returns A^n for the m×m matrix A as V·10^e, rescaling to keep
+// the entries in range.
+func matrixPower(A []float64, m, n int) (V []float64, e int) {
+	if n == 1 {
+		return append([]float64(nil), A...), 0
+	}
+	V, e = matrixPower(A, m, n/2)
+	B := matrixMul(V, V, m)
+	e *= 2
+	if n%2 == 1 {
+		B = matrixMul(A, B, m)
+	}
+	if B[(m/2)*m+m/2] > 1e140 {
+		for i := range B {
+			B[i] *= 1e-140
+		}
+		e += 140
+	}
+	return B, e
+}
+
+func matrixMul(A, B []float64, m int) []float64 {
+	C := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for k := 0; k < m; k++ {
+			a := A[i*m+k]
+			if a == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				C[i*m+j] += a * B[k*m+j]
+			}
+		}
+	}
+	return C
+}
+
+// lillieforsPValue returns Dallal and Wilkinson's (1986) approximation to
+// the p-value of the Kolmogorov-Smirnov statistic d against a normal
+// distribution fitted to n observations, with the refinement for large
+// p-values used by R's nortest::lillie.test.
+func lillieforsPValue(d float64, n int) float64 {
+	nf := float64(n)
+	kd, nd := d, nf
+	if n > 100 {
+		kd, nd = d*math.Pow(nf/100, 0.49), 100
+	}
+	p := math.Exp(-7.01256*kd*kd*(nd+2.78019) + 2.99587*kd*math.Sqrt(nd+2.78019) -
+		0.122119 + 0.974598/math.Sqrt(nd) + 1.67997/nd)
+	if p <= 0.1 {
+		return p
+	}
+	kk := (math.Sqrt(nf) - 0.01 + 0.85/math.Sqrt(nf)) * d
+	switch {
+	case kk <= 0.302:
+		return 1
+	case kk <= 0.5:
+		return 2.76773 - 19.828315*kk + 80.709644*kk*kk - 138.55152*kk*kk*kk + 81.218052*kk*kk*kk*kk
+	case kk <= 0.9:
+		return -4.901232 + 40.662806*kk - 97.490286*kk*kk + 94.029866*kk*kk*kk - 32.355711*kk*kk*kk*kk
+	case kk <= 1.31:
+		return 6.198765 - 19.558097*kk + 23.186922*kk*kk - 12.234627*kk*kk*kk + 2.423045*kk*kk*kk*kk
+	}
+	return 0
+}
+
+// lchoose returns log C(n, k).
+func lchoose(n, k float64) float64 {
+	return lgamma(n+1) - lgamma(k+1) - lgamma(n-k+1)
+}
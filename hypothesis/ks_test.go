@@ -0,0 +1,232 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+var uniformCDF = probability.Uniform{A: 0, B: 1}.CDF
+
+func TestKolmogorovDistributions(t *testing.T) {
+	// Marsaglia, Tsang and Wang (2003): P(D₁₀ < 0.274)
+	if got := 1 - kolmogorovExact(10, 0.274); math.Abs(got-0.6284796154565043) > 1e-13 {
+		t.Errorf("K(10, 0.274) = %.16f; want 0.6284796154565043", got)
+	}
+	// the tabled critical values of √n D in the limit
+	for _, c := range []struct{ x, p float64 }{{1.2238, 0.10}, {1.3581, 0.05}, {1.6276, 0.01}} {
+		if got := kolmogorovSurvival(c.x); math.Abs(got-c.p) > 1e-4 {
+			t.Errorf("P(K > %v) = %v; want %v", c.x, got, c.p)
+		}
+	}
+	// past 1/2 the two one-sided deviations cannot both reach d
+	for _, n := range []int{5, 20, 60} {
+		below := kolmogorovExact(n, 0.5-1e-9)
+		if math.Abs(below-2*smirnovOneSided(n, 0.5)) > 1e-6*below+1e-13 {
+			t.Errorf("n = %d: P(D ≥ ½) = %v; twice the one-sided p = %v", n, below, 2*smirnovOneSided(n, 0.5))
+		}
+	}
+}
+
+// The exact p-values against the rejection rates of simulated uniform
+// samples of 8.
+func TestKolmogorovSmirnovSimulated(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const reps, n = 20000, 8
+	x := make([]float64, n)
+	var two, greater float64
+	for r := 0; r < reps; r++ {
+		for i := range x {
+			x[i] = rng.Float64()
+		}
+		if KolmogorovSmirnovTest(x, uniformCDF).Statistic >= 0.4 {
+			two++
+		}
+		if KolmogorovSmirnovTest(x, uniformCDF, TestOptions{Alternative: Greater}).Statistic >= 0.3 {
+			greater++
+		}
+	}
+	if want := kolmogorovExact(n, 0.4); math.Abs(two/reps-want) > 0.01 {
+		t.Errorf("P(D ≥ 0.4) = %v; simulated %v", want, two/reps)
+	}
+	if want := smirnovOneSided(n, 0.3); math.Abs(greater/reps-want) > 0.01 {
+		t.Errorf("P(D^+ ≥ 0.3) = %v; simulated %v", want, greater/reps)
+	}
+}
+
+func TestKolmogorovSmirnovTest(t *testing.T) {
+	x := []float64{0.7, 0.1, 0.4}
+	res := KolmogorovSmirnovTest(x, uniformCDF)
+	if math.Abs(res.Statistic-0.3) > 1e-15 || res.StatisticName != "D" || res.Method != "Exact one-sample Kolmogorov-Smirnov test" {
+		t.Errorf("two-sided: %v", res)
+	}
+	if p := kolmogorovExact(3, res.Statistic); res.PValue != p {
+		t.Errorf("PValue = %v; want %v", res.PValue, p)
+	}
+	less := KolmogorovSmirnovTest(x, uniformCDF, TestOptions{Alternative: Less})
+	if math.Abs(less.Statistic-0.1) > 1e-15 || less.StatisticName != "D^-" || less.PValue != smirnovOneSided(3, less.Statistic) {
+		t.Errorf("less: %v", less)
+	}
+
+	asym := KolmogorovSmirnovTest(x, uniformCDF, TestOptions{PValueMethod: PValueAsymptotic})
+	if asym.Method != "Asymptotic one-sample Kolmogorov-Smirnov test" || math.Abs(asym.PValue-kolmogorovSurvival(math.Sqrt(3)*0.3)) > 1e-12 {
+		t.Errorf("asymptotic: %v", asym)
+	}
+	greater := KolmogorovSmirnovTest(x, uniformCDF, TestOptions{Alternative: Greater, PValueMethod: PValueAsymptotic})
+	if math.Abs(greater.PValue-math.Exp(-2*3*0.09)) > 1e-15 {
+		t.Errorf("asymptotic greater p = %v", greater.PValue)
+	}
+	// ties, as in rounded data, select the asymptotic p-value
+	if tied := KolmogorovSmirnovTest([]float64{0.2, 0.2, 0.5}, uniformCDF); tied.Method != asym.Method {
+		t.Errorf("ties: Method = %q", tied.Method)
+	}
+}
+
+func TestKolmogorovSmirnovExactFarTail(t *testing.T) {
+	// uᵢ = 0.31 + 0.69(i-1)/99 gives D = D^- = 0.31; the p-value is
+	// 1 - P(D < 31/100) computed in rational arithmetic
+	x := make([]float64, 99)
+	for i := range x {
+		x[i] = float64(i) / 99
+	}
+	res := KolmogorovSmirnovTest(x, func(v float64) float64 { return 0.31 + 0.69*v })
+	if res.Method != "Exact one-sample Kolmogorov-Smirnov test" {
+		t.Errorf("Method = %q", res.Method)
+	}
+	if want := 6.004004148542034e-09; math.Abs(res.PValue-want) > 1e-6*want {
+		t.Errorf("PValue = %v; want %v", res.PValue, want)
+	}
+}
+
+// A normal fitted to the sample makes the plain test far too conservative;
+// the Lilliefors correction restores its level.
+func TestLillieforsLevel(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	const reps, n = 2000, 30
+	x := make([]float64, n)
+	var lilliefors, naive float64
+	for r := 0; r < reps; r++ {
+		for i := range x {
+			x[i] = 10 + 3*rng.NormFloat64()
+		}
+		res := LillieforsTest(x)
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		if res.Reject(0.05) {
+			lilliefors++
+		}
+		mu, sigma := mean(x), sampleStdDev(x)
+		if KolmogorovSmirnovTest(x, probability.Normal{Mu: mu, Sigma: sigma}.CDF).Reject(0.05) {
+			naive++
+		}
+	}
+	if rate := lilliefors / reps; math.Abs(rate-0.05) > 0.015 {
+		t.Errorf("Lilliefors rejection rate %v; want about 0.05", rate)
+	}
+	if rate := naive / reps; rate > 0.01 {
+		t.Errorf("uncorrected rejection rate %v; want far below 0.05", rate)
+	}
+	// the asymptotic 5 % point of Lilliefors' distribution is 0.886/√n
+	if p := lillieforsPValue(0.0886, 100); math.Abs(p-0.05) > 0.002 {
+		t.Errorf("Lilliefors p at the 5 %% point = %v", p)
+	}
+}
+
+func TestLillieforsTest(t *testing.T) {
+	res := LillieforsTest(sleep1)
+	cdf := probability.Normal{Mu: mean(sleep1), Sigma: sampleStdDev(sleep1)}.CDF
+	opt := KolmogorovSmirnovTest(sleep1, cdf, TestOptions{Lilliefors: true})
+	if math.Abs(res.Statistic-opt.Statistic) > 1e-12 || math.Abs(res.PValue-opt.PValue) > 1e-12 {
+		t.Errorf("LillieforsTest %v; with the option %v", res, opt)
+	}
+	if res.Method != "Lilliefors (Kolmogorov-Smirnov) normality test" {
+		t.Errorf("Method = %q", res.Method)
+	}
+}
+
+func TestTwoSampleKolmogorovSmirnovExact(t *testing.T) {
+	x := []float64{1.2, 3.4, 2.2, 5.0, 2.2}
+	y := []float64{0.3, 2.2, 1.1, 0.9, 1.2, 4.0}
+	for _, alt := range []Alternative{TwoSided, Less, Greater} {
+		o := TestOptions{Alternative: alt}
+		res := TwoSampleKolmogorovSmirnovTest(x, y, o)
+		want := labellingTail(x, y, func(a, b []float64) float64 {
+			return TwoSampleKolmogorovSmirnovTest(a, b, TestOptions{Alternative: alt, PValueMethod: PValueAsymptotic}).Statistic
+		})
+		if res.Method != "Exact two-sample Kolmogorov-Smirnov test" || math.Abs(res.PValue-want) > 1e-12 {
+			t.Errorf("%v: %s p = %v; want %v", alt, res.Method, res.PValue, want)
+		}
+	}
+	res := TwoSampleKolmogorovSmirnovTest(x, y)
+	// the EDFs are furthest apart at 1.1, where Fₓ = 0 and Fᵧ = 3/6
+	if math.Abs(res.Statistic-0.5) > 1e-15 || res.N[0] != 5 || res.N[1] != 6 {
+		t.Errorf("D = %v, N = %v", res.Statistic, res.N)
+	}
+}
+
+func TestTwoSampleKolmogorovSmirnovLarge(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	x := make([]float64, 120)
+	y := make([]float64, 90)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+	}
+	for i := range y {
+		y[i] = rng.NormFloat64()
+	}
+	asym := TwoSampleKolmogorovSmirnovTest(x, y)
+	exact := TwoSampleKolmogorovSmirnovTest(x, y, TestOptions{PValueMethod: PValueExact})
+	if asym.Method != "Asymptotic two-sample Kolmogorov-Smirnov test" || exact.Statistic != asym.Statistic {
+		t.Fatalf("%s, D = %v; exact D = %v", asym.Method, asym.Statistic, exact.Statistic)
+	}
+	// the limiting distribution is slightly conservative at these sizes
+	if asym.PValue < exact.PValue || asym.PValue > 1.15*exact.PValue {
+		t.Errorf("asymptotic p = %v; exact p = %v", asym.PValue, exact.PValue)
+	}
+
+	// the statistic is the largest gap between the two EDFs
+	xs, ys := sorted(x), sorted(y)
+	var d float64
+	for _, v := range append(xs, ys...) {
+		fx := float64(sort.SearchFloat64s(xs, math.Nextafter(v, math.Inf(1)))) / 120
+		fy := float64(sort.SearchFloat64s(ys, math.Nextafter(v, math.Inf(1)))) / 90
+		d = math.Max(d, math.Abs(fx-fy))
+	}
+	if math.Abs(asym.Statistic-d) > 1e-15 {
+		t.Errorf("D = %v; want %v", asym.Statistic, d)
+	}
+}
+
+func TestKolmogorovSmirnovErrors(t *testing.T) {
+	big := make([]float64, 20000)
+	for i := range big {
+		big[i] = float64(i) / 20000
+	}
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"empty", KolmogorovSmirnovTest(nil, uniformCDF), ErrEmptyInput},
+		{"nil cdf", KolmogorovSmirnovTest(sleep1, nil), ErrInvalidParameter},
+		{"NaN", KolmogorovSmirnovTest([]float64{math.NaN()}, uniformCDF), ErrInvalidParameter},
+		{"bad cdf", KolmogorovSmirnovTest(sleep1, func(float64) float64 { return 2 }), ErrInvalidParameter},
+		{"too large", KolmogorovSmirnovTest(big, func(v float64) float64 { return v * v }, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+		{"Lilliefors one-sided", KolmogorovSmirnovTest(sleep1, uniformCDF, TestOptions{Lilliefors: true, Alternative: Less}), ErrInvalidParameter},
+		{"Lilliefors small", LillieforsTest([]float64{1, 2, 3, 4}), ErrEmptyInput},
+		{"Lilliefors constant", LillieforsTest([]float64{1, 1, 1, 1, 1}), ErrInvalidParameter},
+		{"two-sample empty", TwoSampleKolmogorovSmirnovTest(sleep1, nil), ErrEmptyInput},
+		{"two-sample Inf", TwoSampleKolmogorovSmirnovTest(sleep1, []float64{math.Inf(-1)}), ErrInvalidParameter},
+		{"two-sample too large", TwoSampleKolmogorovSmirnovTest(big, big, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+}
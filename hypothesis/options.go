@@ -0,0 +1,113 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+// TestOptions configures the tests that take it as an optional trailing
+// argument. The zero value, like omitting it, gives a two-sided test with a
+// 95 % confidence interval and, for rank tests, the default choice between
+// exact and asymptotic p-values.
+type TestOptions struct {
+	// Alternative is TwoSided (the default), Less or Greater. A one-sided
+	// test reports the matching one-sided confidence bound: (-Inf, u] for
+	// Less and [l, +Inf) for Greater.
+	Alternative Alternative
+	// ConfLevel is the coverage of the confidence interval; default 0.95.
+	ConfLevel float64
+	// EqualVariance selects the pooled-variance Student's t-test instead
+	// of Welch's in TwoSampleTTestData. The other tests ignore it.
+	EqualVariance bool
+	// PValueMethod selects exact or asymptotic p-values for the rank and
+	// EDF tests. The other tests ignore it.
+	PValueMethod PValueMethod
+	// Lilliefors declares that the cdf given to a one-sample EDF test
+	// (KolmogorovSmirnovTest, AndersonDarlingTest, CramerVonMisesTest) is a
+	// normal CDF whose mean and standard deviation were estimated from the
+	// same sample, and selects p-values corrected for the estimation. The
+	// other tests ignore it.
+	Lilliefors bool
+}
+
+// PValueMethod selects how a rank or EDF test computes its p-value.
+type PValueMethod int
+
+const (
+	// PValueAuto uses the exact permutation distribution for small samples
+	// without ties and the asymptotic distribution otherwise.
+	PValueAuto PValueMethod = iota
+	// PValueExact always uses the exact permutation distribution,
+	// conditional on the ties present. Its cost grows quickly with the
+	// sample sizes.
+	PValueExact
+	// PValueAsymptotic always uses the large-sample approximation.
+	PValueAsymptotic
+)
+
+func (o TestOptions) withDefaults() TestOptions {
+	if o.Alternative == 0 {
+		o.Alternative = TwoSided
+	}
+	if o.ConfLevel == 0 {
+		o.ConfLevel = 0.95
+	}
+	return o
+}
+
+// testOptions returns the options passed to the test name, with defaults
+// filled in, or an error matching ErrInvalidParameter.
+func testOptions(name string, opts []TestOptions) (TestOptions, error) {
+	var o TestOptions
+	switch len(opts) {
+	case 0:
+	case 1:
+		o = opts[0]
+	default:
+		return o, errs.New(ErrInvalidParameter, name+": at most one TestOptions may be given")
+	}
+	o = o.withDefaults()
+	if o.Alternative < TwoSided || o.Alternative > Greater {
+		return o, errs.New(ErrInvalidParameter, name+": alternative must be TwoSided, Less or Greater")
+	}
+	if !(o.ConfLevel > 0 && o.ConfLevel < 1) {
+		return o, errs.New(ErrInvalidParameter, name+": confidence level must be in (0, 1)")
+	}
+	if o.PValueMethod < PValueAuto || o.PValueMethod > PValueAsymptotic {
+		return o, errs.New(ErrInvalidParameter, name+": p-value method must be PValueAuto, PValueExact or PValueAsymptotic")
+	}
+	return o, nil
+}
+
+// pValue returns the p-value of the statistic s, whose null distribution is
+// the symmetric null, against the alternative alt. Each tail is taken
+// directly from CDF or Survival so that tiny p-values are not lost to
+// cancellation.
+func pValue(null probability.Continuous, s float64, alt Alternative) float64 {
+	switch alt {
+	case Less:
+		return null.CDF(s)
+	case Greater:
+		return null.Survival(s)
+	}
+	return 2 * null.Survival(math.Abs(s))
+}
+
+// confInt returns the confidence interval est ± q·se for a pivot with the
+// symmetric distribution null: two-sided with q at (1 + level) / 2, or a
+// one-sided bound with q at level.
+func confInt(null probability.Continuous, est, se float64, o TestOptions) [2]float64 {
+	switch o.Alternative {
+	case Less:
+		return [2]float64{math.Inf(-1), est + null.Quantile(o.ConfLevel)*se}
+	case Greater:
+		return [2]float64{est - null.Quantile(o.ConfLevel)*se, math.Inf(1)}
+	}
+	q := null.Quantile((1 + o.ConfLevel) / 2)
+	return [2]float64{est - q*se, est + q*se}
+}
+
+// standardNormal is the null distribution of the z statistics.
+var standardNormal = probability.Normal{Mu: 0, Sigma: 1}
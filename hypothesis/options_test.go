@@ -0,0 +1,94 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestOneSidedPValues(t *testing.T) {
+	two := PairedTTest(sleep1, sleep2)
+	less := PairedTTest(sleep1, sleep2, TestOptions{Alternative: Less})
+	greater := PairedTTest(sleep1, sleep2, TestOptions{Alternative: Greater})
+	// R: t.test(sleep1, sleep2, paired = TRUE, alternative = "less") gives
+	// p-value = 0.001416
+	if math.Abs(less.PValue-0.001416) > 1e-6 || math.Abs(less.PValue-two.PValue/2) > 1e-15 {
+		t.Errorf("less: p = %v; want 0.001416 = %v / 2", less.PValue, two.PValue)
+	}
+	if math.Abs(less.PValue+greater.PValue-1) > 1e-15 {
+		t.Errorf("less and greater p-values %v and %v do not sum to 1", less.PValue, greater.PValue)
+	}
+	if less.Alternative != Less || greater.Alternative != Greater || two.Alternative != TwoSided {
+		t.Errorf("alternatives %v, %v, %v", less.Alternative, greater.Alternative, two.Alternative)
+	}
+
+	z := OneSampleZTest(105, 100, 15, 30, TestOptions{Alternative: Greater})
+	if want := normalTwoTailed(z.Statistic) / 2; math.Abs(z.PValue-want) > 1e-15 {
+		t.Errorf("z greater: p = %v; want %v", z.PValue, want)
+	}
+}
+
+// normalTwoTailed is the two-sided p-value of a standard normal statistic.
+func normalTwoTailed(z float64) float64 { return 2 * standardNormal.Survival(math.Abs(z)) }
+
+// A one-sided bound is the null value at which the one-sided test has
+// p-value exactly 1 - ConfLevel.
+func TestOneSidedConfidenceBounds(t *testing.T) {
+	s1, s2 := sampleStdDev(sleep1), sampleStdDev(sleep2)
+	m1, m2 := mean(sleep1), mean(sleep2)
+	for _, level := range []float64{0.9, 0.95, 0.99} {
+		less := OneSampleTTest(m1, 0, s1, 10, TestOptions{Alternative: Less, ConfLevel: level})
+		if !math.IsInf(less.ConfInt[0], -1) || less.ConfLevel != level {
+			t.Errorf("less: interval %v at level %v", less.ConfInt, less.ConfLevel)
+		}
+		at := OneSampleTTest(m1, less.ConfInt[1], s1, 10, TestOptions{Alternative: Less})
+		if math.Abs(at.PValue-(1-level)) > 1e-12 {
+			t.Errorf("less: p-value at the upper bound = %v; want %v", at.PValue, 1-level)
+		}
+
+		greater := TwoSampleTTestWelch(m1, m2, s1, s2, 10, 10, TestOptions{Alternative: Greater, ConfLevel: level})
+		if !math.IsInf(greater.ConfInt[1], 1) {
+			t.Errorf("greater: interval %v", greater.ConfInt)
+		}
+		shifted := TwoSampleTTestWelch(m1-greater.ConfInt[0], m2, s1, s2, 10, 10, TestOptions{Alternative: Greater})
+		if math.Abs(shifted.PValue-(1-level)) > 1e-12 {
+			t.Errorf("greater: p-value at the lower bound = %v; want %v", shifted.PValue, 1-level)
+		}
+
+		z := TwoSampleZTest(5, 4.5, 1.2, 1.1, 50, 40, TestOptions{Alternative: Less, ConfLevel: level})
+		se := math.Sqrt(1.2*1.2/50 + 1.1*1.1/40)
+		if want := 0.5 + standardNormal.Quantile(level)*se; math.Abs(z.ConfInt[1]-want) > 1e-14 {
+			t.Errorf("z less: upper bound %v; want %v", z.ConfInt[1], want)
+		}
+	}
+
+	// qnorm(0.95) = 1.6448536269514722
+	z := OneSampleZTest(105, 100, 15, 30, TestOptions{Alternative: Greater})
+	if want := 105 - 1.6448536269514722*15/math.Sqrt(30); math.Abs(z.ConfInt[0]-want) > 1e-12 {
+		t.Errorf("z greater: lower bound %v; want %v", z.ConfInt[0], want)
+	}
+}
+
+func TestTestOptionsDefaults(t *testing.T) {
+	plain := OneSampleTTest(105, 100, 15, 30)
+	zero := OneSampleTTest(105, 100, 15, 30, TestOptions{})
+	if plain.PValue != zero.PValue || plain.ConfInt != zero.ConfInt || zero.ConfLevel != 0.95 || zero.Alternative != TwoSided {
+		t.Errorf("zero TestOptions differ from the default: %+v vs %+v", zero, plain)
+	}
+}
+
+func TestTestOptionsInvalid(t *testing.T) {
+	for _, opts := range [][]TestOptions{
+		{{Alternative: 4}},
+		{{Alternative: -1}},
+		{{ConfLevel: 1}},
+		{{ConfLevel: -0.5}},
+		{{ConfLevel: math.NaN()}},
+		{{}, {}},
+	} {
+		res := OneSampleZTest(1, 0, 1, 10, opts...)
+		if !errors.Is(res.Err, ErrInvalidParameter) || !math.IsNaN(res.PValue) {
+			t.Errorf("OneSampleZTest with %+v: err = %v", opts, res.Err)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package hypothesis
+
+import (
+	"math"
+	"sort"
+)
+
+// The helpers in this file are shared by the rank tests: mid-ranks, exact
+// permutation distributions of rank sums, and order statistics of the
+// pairwise differences and Walsh averages behind Hodges-Lehmann estimates.
+// Exact distributions are built on doubled ranks, which are integers even
+// when ties give half-integer mid-ranks.
+
+// Limits on the work, in elementary steps, of an exact p-value: autoExactWork
+// when the test chooses for itself, maxExactWork when the caller asks for it.
+const (
+	autoExactWork = 1e6
+	maxExactWork  = 1e8
+)
+
+// midranks returns the ranks of x, from 1, with tied values sharing the mean
+// of their ranks, and the tie term Σ (t³ - t) over the groups of t tied
+// values.
+func midranks(x []float64) (ranks []float64, ties float64) {
+	n := len(x)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return x[idx[a]] < x[idx[b]] })
+
+	ranks = make([]float64, n)
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		r := float64(i+j+1) / 2
+		for _, k := range idx[i:j] {
+			ranks[k] = r
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	return ranks, ties
+}
+
+// doubled returns 2·r for each mid-rank r.
+func doubled(ranks []float64) []int {
+	r2 := make([]int, len(ranks))
+	for i, r := range ranks {
+		r2[i] = int(2 * r)
+	}
+	return r2
+}
+
+// sum returns the sum of the integers in r.
+func sum(r []int) int {
+	s := 0
+	for _, v := range r {
+		s += v
+	}
+	return s
+}
+
+// subsetSumCounts returns c with c[s] the number of ways to choose m of the
+// scores r with sum s: the null distribution of a rank-sum statistic.
+func subsetSumCounts(r []int, m int) []float64 {
+	top := sum(r)
+	dp := make([][]float64, m+1)
+	for j := range dp {
+		dp[j] = make([]float64, top+1)
+	}
+	dp[0][0] = 1
+	for i, v := range r {
+		for j := min(i+1, m); j >= 1; j-- {
+			prev, cur := dp[j-1], dp[j]
+			for s := top; s >= v; s-- {
+				cur[s] += prev[s-v]
+			}
+		}
+	}
+	return dp[m]
+}
+
+// rankSumCounts returns c with c[u] the number of ways to choose n₁ of the
+// ranks 1, …, n₁+n₂ with rank sum n₁(n₁+1)/2 + u: the null distribution of
+// the Mann-Whitney U without ties. It adds one rank at a time, keeping for
+// each count j chosen so far only the j(i-j)+1 reachable values of U and
+// only the counts that can still reach n₁, so it takes about
+// (n₁+n₂)·n₁n₂·min(n₁, n₂)/8 steps.
+func rankSumCounts(n1, n2 int) []float64 {
+	n := n1 + n2
+	dp := make([][]float64, n1+1)
+	dp[0] = []float64{1}
+	for i := 0; i < n; i++ {
+		// adding rank i+1 to j-1 chosen ranks raises U by i-(j-1)
+		for j := min(i+1, n1); j >= max(1, n1-(n-i-1)); j-- {
+			prev := dp[j-1]
+			shift := i - (j - 1)
+			if need := len(prev) + shift; len(dp[j]) < need {
+				dp[j] = append(dp[j], make([]float64, need-len(dp[j]))...)
+			}
+			cur := dp[j]
+			for u, v := range prev {
+				cur[u+shift] += v
+			}
+		}
+	}
+	return dp[n1]
+}
+
+// signedSumCounts returns c with c[s] the number of subsets of the scores r,
+// of any size, with sum s: the null distribution of a signed-rank statistic.
+func signedSumCounts(r []int) []float64 {
+	top := sum(r)
+	c := make([]float64, top+1)
+	c[0] = 1
+	for _, v := range r {
+		for s := top; s >= v; s-- {
+			c[s] += c[s-v]
+		}
+	}
+	return c
+}
+
+// exactPValue returns the p-value of the observed statistic s under the
+// exact null distribution with counts c, centred on center. The two-sided
+// p-value doubles the tail on the side of s, as R's wilcox.test does.
+func exactPValue(c []float64, s int, center float64, alt Alternative) float64 {
+	var total, below, above float64
+	for v, n := range c {
+		total += n
+		if v <= s {
+			below += n
+		}
+		if v >= s {
+			above += n
+		}
+	}
+	switch alt {
+	case Less:
+		return below / total
+	case Greater:
+		return above / total
+	}
+	p := below
+	if float64(s) > center {
+		p = above
+	}
+	return math.Min(1, 2*p/total)
+}
+
+// exactQuantile returns the smallest q with P(X ≤ q) ≥ p for X with counts
+// c over 0, 1, 2, ….
+func exactQuantile(c []float64, p float64) int {
+	var total float64
+	for _, n := range c {
+		total += n
+	}
+	p -= 10 * 2.220446049250313e-16
+	var acc float64
+	for q, n := range c {
+		if acc += n / total; acc >= p {
+			return q
+		}
+	}
+	return len(c) - 1
+}
+
+// continuity returns the continuity correction of a normal approximation
+// to a discrete statistic at distance d from its mean.
+func continuity(d float64, alt Alternative) float64 {
+	switch {
+	case alt == Greater:
+		return 0.5
+	case alt == Less:
+		return -0.5
+	case d == 0:
+		return 0
+	}
+	return math.Copysign(0.5, d)
+}
+
+// useExact reports whether a test computes its p-value exactly: when asked
+// to, provided the work stays under maxExactWork, or by default when
+// preferAsymptotic (ties, or a sample beyond the test's usual exact range)
+// is false and the work stays under autoExactWork. tooLarge is set when an
+// exact p-value was asked for but is out of reach.
+func useExact(method PValueMethod, preferAsymptotic bool, work float64) (exact, tooLarge bool) {
+	switch method {
+	case PValueExact:
+		return work <= maxExactWork, work > maxExactWork
+	case PValueAsymptotic:
+		return false, false
+	}
+	return !preferAsymptotic && work <= autoExactWork, false
+}
+
+// finite reports whether every value of x is finite.
+func finite(x []float64) bool {
+	for _, v := range x {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// kthPairValue returns the k-th smallest (from 1) of a set of pair values
+// lying in [lo, hi], where count(v) is how many of them are ≤ v. It bisects
+// on v until lo and hi are adjacent floats, so it needs O(log) calls of
+// count rather than the sorted list of all pairs.
+func kthPairValue(k int, lo, hi float64, count func(v float64) int) float64 {
+	if count(lo) >= k {
+		return lo
+	}
+	// invariant: count(lo) < k ≤ count(hi)
+	for {
+		mid := lo/2 + hi/2
+		if mid <= lo || mid >= hi {
+			return hi
+		}
+		if count(mid) >= k {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+}
+
+// differences returns the k-th smallest of the n₁n₂ differences xᵢ - yⱼ of
+// the sorted samples x and y, as a function of k.
+func differences(x, y []float64) func(k int) float64 {
+	count := func(v float64) int {
+		n, j := 0, 0
+		for _, xi := range x {
+			for j < len(y) && xi-y[j] > v {
+				j++
+			}
+			n += len(y) - j
+		}
+		return n
+	}
+	return func(k int) float64 {
+		return kthPairValue(k, x[0]-y[len(y)-1], x[len(x)-1]-y[0], count)
+	}
+}
+
+// walshAverages returns the k-th smallest of the n(n+1)/2 Walsh averages
+// (xᵢ + xⱼ) / 2, i ≤ j, of the sorted sample x, as a function of k.
+func walshAverages(x []float64) func(k int) float64 {
+	count := func(v float64) int {
+		n, j := 0, len(x)-1
+		for i := range x {
+			for j >= i && (x[i]+x[j])/2 > v {
+				j--
+			}
+			if j < i {
+				break
+			}
+			n += j - i + 1
+		}
+		return n
+	}
+	return func(k int) float64 {
+		return kthPairValue(k, x[0], x[len(x)-1], count)
+	}
+}
+
+// hodgesLehmann returns the median of m pair values given by kth, and the
+// confidence interval between the order statistics that invert the rank
+// test: kth(q) and kth(m+1-q), with q from the exact null counts c of the
+// untied statistic when c is not nil, and from its normal approximation
+// with mean m/2 and standard deviation sigma otherwise.
+func hodgesLehmann(kth func(k int) float64, m int, c []float64, sigma float64, o TestOptions) (float64, [2]float64) {
+	est := kth((m + 1) / 2)
+	if m%2 == 0 {
+		est = (est + kth(m/2+1)) / 2
+	}
+
+	alpha := 1 - o.ConfLevel
+	if o.Alternative == TwoSided {
+		alpha /= 2
+	}
+	var q int
+	if c != nil {
+		q = exactQuantile(c, alpha)
+	} else {
+		q = int(math.Round(float64(m)/2 - standardNormal.Quantile(1-alpha)*sigma))
+	}
+	q = max(1, min(q, m))
+
+	ci := [2]float64{kth(q), kth(m + 1 - q)}
+	switch o.Alternative {
+	case Less:
+		ci[0] = math.Inf(-1)
+	case Greater:
+		ci[1] = math.Inf(1)
+	}
+	return est, ci
+}
@@ -0,0 +1,121 @@
+package hypothesis
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMidranks(t *testing.T) {
+	ranks, ties := midranks([]float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})
+	want := []float64{4.5, 1.5, 6, 1.5, 8, 11, 3, 10, 8, 4.5, 8}
+	for i := range want {
+		if ranks[i] != want[i] {
+			t.Fatalf("midranks = %v; want %v", ranks, want)
+		}
+	}
+	// two pairs and a triple: 6 + 6 + 24
+	if ties != 36 {
+		t.Errorf("tie term = %v; want 36", ties)
+	}
+}
+
+// brute-force rank-sum and signed-rank distributions over all subsets
+func TestExactCountsMatchEnumeration(t *testing.T) {
+	scores := []int{2, 3, 3, 6, 8, 8, 8, 14}
+	n := len(scores)
+	want := make([]float64, sum(scores)+1)
+	wantSigned := make([]float64, sum(scores)+1)
+	for mask := 0; mask < 1<<n; mask++ {
+		s, size := 0, 0
+		for i := range scores {
+			if mask&(1<<i) != 0 {
+				s += scores[i]
+				size++
+			}
+		}
+		wantSigned[s]++
+		if size == 3 {
+			want[s]++
+		}
+	}
+	got, gotSigned := subsetSumCounts(scores, 3), signedSumCounts(scores)
+	for s := range want {
+		if got[s] != want[s] || gotSigned[s] != wantSigned[s] {
+			t.Fatalf("counts at %d: %v, %v; want %v, %v", s, got[s], gotSigned[s], want[s], wantSigned[s])
+		}
+	}
+}
+
+// The untied counts of U are the rank-sum counts of the ranks 1, …, N,
+// shifted to start at 0.
+func TestRankSumCounts(t *testing.T) {
+	for _, size := range [][2]int{{1, 1}, {1, 6}, {6, 1}, {4, 7}, {9, 9}, {12, 5}} {
+		n1, n2 := size[0], size[1]
+		scores := make([]int, n1+n2)
+		for i := range scores {
+			scores[i] = i + 1
+		}
+		want := subsetSumCounts(scores, n1)[n1*(n1+1)/2:]
+		got := rankSumCounts(n1, n2)
+		if len(got) != n1*n2+1 {
+			t.Fatalf("%d×%d: %d counts; want %d", n1, n2, len(got), n1*n2+1)
+		}
+		for u := range got {
+			if got[u] != want[u] {
+				t.Fatalf("%d×%d: count of U = %d is %v; want %v", n1, n2, u, got[u], want[u])
+			}
+		}
+	}
+}
+
+func TestExactQuantile(t *testing.T) {
+	// counts of the untied signed-rank statistic for n = 4
+	c := signedSumCounts([]int{1, 2, 3, 4})
+	// P(V ≤ 0) = 1/16, P(V ≤ 1) = 2/16, P(V ≤ 2) = 3/16
+	for _, tt := range []struct {
+		p    float64
+		want int
+	}{{0.01, 0}, {1.0 / 16, 0}, {0.1, 1}, {0.15, 2}, {0.5, 5}, {1, 10}} {
+		if got := exactQuantile(c, tt.p); got != tt.want {
+			t.Errorf("exactQuantile(%v) = %d; want %d", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestPairOrderStatistics(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([]float64, 23)
+	y := make([]float64, 17)
+	for i := range x {
+		x[i] = math.Round(rng.NormFloat64()*100) / 10 // with ties
+	}
+	for i := range y {
+		y[i] = rng.ExpFloat64()
+	}
+	var diffs, walsh []float64
+	for _, a := range x {
+		for _, b := range y {
+			diffs = append(diffs, a-b)
+		}
+	}
+	for i := range x {
+		for j := i; j < len(x); j++ {
+			walsh = append(walsh, (x[i]+x[j])/2)
+		}
+	}
+	sort.Float64s(diffs)
+	sort.Float64s(walsh)
+	kd, kw := differences(sorted(x), sorted(y)), walshAverages(sorted(x))
+	for k := 1; k <= len(diffs); k++ {
+		if got := kd(k); got != diffs[k-1] {
+			t.Fatalf("differences(%d) = %v; want %v", k, got, diffs[k-1])
+		}
+	}
+	for k := 1; k <= len(walsh); k++ {
+		if got := kw(k); got != walsh[k-1] {
+			t.Fatalf("walshAverages(%d) = %v; want %v", k, got, walsh[k-1])
+		}
+	}
+}
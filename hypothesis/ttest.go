@@ -2,24 +2,57 @@ package hypothesis
 
 import (
 	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+	"github.com/cyber-mountain-man/statistical-go/stat"
 )
 
-// OneSampleTTest returns a t-test statistic and p-value for a one-sample t-test.
-func OneSampleTTest(sampleMean, populationMean, sampleStdDev float64, n int) TestResult {
-	if sampleStdDev <= 0 || n <= 1 {
-		panic("OneSampleTTest: sample standard deviation must be > 0 and sample size > 1")
+// OneSampleTTest returns a t-test statistic and p-value for a one-sample
+// t-test, with a confidence interval for the mean and Cohen's d,
+// (x̄ - μ₀) / s, as effect size. The test is two-sided unless opts sets
+// another Alternative.
+func OneSampleTTest(sampleMean, populationMean, sampleStdDev float64, n int, opts ...TestOptions) TestResult {
+	if !(sampleStdDev > 0) || n <= 1 {
+		return failed(ErrInvalidParameter, "OneSampleTTest: sample standard deviation must be > 0 and sample size > 1")
+	}
+	o, err := testOptions("OneSampleTTest", opts)
+	if err != nil {
+		return failedWith(err)
 	}
 	standardError := sampleStdDev / math.Sqrt(float64(n))
 	t := (sampleMean - populationMean) / standardError
 	df := float64(n - 1)
-	p := 2 * (1 - studentTCDF(math.Abs(t), df))
-	return TestResult{Statistic: t, PValue: p}
+	null := probability.StudentT{Nu: df}
+	p := pValue(null, t, o.Alternative)
+	return TestResult{
+		Statistic:      t,
+		PValue:         p,
+		Method:         "One Sample t-test",
+		StatisticName:  "t",
+		Alternative:    o.Alternative,
+		DF:             df,
+		N:              []int{n},
+		Parameter:      "mean",
+		NullValue:      populationMean,
+		Estimate:       sampleMean,
+		ConfInt:        confInt(null, sampleMean, standardError, o),
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     (sampleMean - populationMean) / sampleStdDev,
+		EffectSizeName: "Cohen's d",
+	}
 }
 
-// TwoSampleTTestWelch returns a t-test statistic and p-value for Welch's t-test.
-func TwoSampleTTestWelch(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int) TestResult {
-	if stdDev1 <= 0 || stdDev2 <= 0 || n1 <= 1 || n2 <= 1 {
-		panic("TwoSampleTTestWelch: standard deviations must be > 0 and sample sizes > 1")
+// TwoSampleTTestWelch returns a t-test statistic and p-value for Welch's
+// t-test, with a confidence interval for the difference in means and
+// Cohen's d, standardised by the pooled standard deviation, as effect size.
+// The test is two-sided unless opts sets another Alternative.
+func TwoSampleTTestWelch(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int, opts ...TestOptions) TestResult {
+	if !(stdDev1 > 0 && stdDev2 > 0) || n1 <= 1 || n2 <= 1 {
+		return failed(ErrInvalidParameter, "TwoSampleTTestWelch: standard deviations must be > 0 and sample sizes > 1")
+	}
+	o, err := testOptions("TwoSampleTTestWelch", opts)
+	if err != nil {
+		return failedWith(err)
 	}
 	se1 := (stdDev1 * stdDev1) / float64(n1)
 	se2 := (stdDev2 * stdDev2) / float64(n2)
@@ -27,52 +60,174 @@ func TwoSampleTTestWelch(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int) Tes
 
 	// Welch-Satterthwaite approximation
 	df := math.Pow(se1+se2, 2) / ((math.Pow(se1, 2) / float64(n1-1)) + (math.Pow(se2, 2) / float64(n2-1)))
-	p := 2 * (1 - studentTCDF(math.Abs(t), df))
+	null := probability.StudentT{Nu: df}
+	p := pValue(null, t, o.Alternative)
 
-	return TestResult{Statistic: t, PValue: p}
+	diff := mean1 - mean2
+	return TestResult{
+		Statistic:      t,
+		PValue:         p,
+		Method:         "Welch Two Sample t-test",
+		StatisticName:  "t",
+		Alternative:    o.Alternative,
+		DF:             df,
+		N:              []int{n1, n2},
+		Parameter:      "difference in means",
+		Estimate:       diff,
+		ConfInt:        confInt(null, diff, math.Sqrt(se1+se2), o),
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     diff / pooledStdDev(stdDev1, stdDev2, n1, n2),
+		EffectSizeName: "Cohen's d",
+	}
 }
 
-// PairedTTest returns a t-test statistic and p-value for a paired sample t-test.
-func PairedTTest(x, y []float64) TestResult {
+// PairedTTest returns a t-test statistic and p-value for a paired sample
+// t-test, with a confidence interval for the mean difference x - y and
+// Cohen's d_z, the mean difference over the standard deviation of the
+// differences, as effect size. The test is two-sided unless opts sets
+// another Alternative. Err is set when the differences are constant, which
+// leaves t undefined.
+func PairedTTest(x, y []float64, opts ...TestOptions) TestResult {
 	if len(x) != len(y) {
-		panic("PairedTTest: slices must have the same length")
+		return failed(ErrLengthMismatch, "PairedTTest: slices must have the same length")
 	}
 	n := len(x)
 	if n < 2 {
-		panic("PairedTTest: need at least 2 paired observations")
+		return failed(ErrEmptyInput, "PairedTTest: need at least 2 paired observations")
+	}
+	o, err := testOptions("PairedTTest", opts)
+	if err != nil {
+		return failedWith(err)
 	}
 
-	var sumDiff, sumDiffSq float64
+	diffs := make([]float64, n)
+	var sumDiff float64
 	for i := 0; i < n; i++ {
-		d := x[i] - y[i]
-		sumDiff += d
-		sumDiffSq += d * d
+		diffs[i] = x[i] - y[i]
+		sumDiff += diffs[i]
 	}
-
 	meanDiff := sumDiff / float64(n)
-	variance := (sumDiffSq - float64(n)*meanDiff*meanDiff) / float64(n-1)
-	stdDev := math.Sqrt(variance)
+	var ss float64
+	for _, d := range diffs {
+		ss += (d - meanDiff) * (d - meanDiff)
+	}
+	stdDev := math.Sqrt(ss / float64(n-1))
+	if !(stdDev > 0) || math.IsInf(stdDev, 0) {
+		return failed(ErrInvalidParameter, "PairedTTest: standard deviation of the differences must be positive and finite")
+	}
 	standardError := stdDev / math.Sqrt(float64(n))
 	t := meanDiff / standardError
 	df := float64(n - 1)
-	p := 2 * (1 - studentTCDF(math.Abs(t), df))
+	null := probability.StudentT{Nu: df}
+	p := pValue(null, t, o.Alternative)
+
+	return TestResult{
+		Statistic:      t,
+		PValue:         p,
+		Method:         "Paired t-test",
+		StatisticName:  "t",
+		Alternative:    o.Alternative,
+		DF:             df,
+		N:              []int{n},
+		Parameter:      "mean difference",
+		Estimate:       meanDiff,
+		ConfInt:        confInt(null, meanDiff, standardError, o),
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     meanDiff / stdDev,
+		EffectSizeName: "Cohen's d_z",
+	}
+}
+
+// OneSampleTTestData runs OneSampleTTest on the sample x, with the mean
+// and standard deviation computed by the stat package.
+func OneSampleTTestData(x []float64, populationMean float64, opts ...TestOptions) TestResult {
+	if len(x) < 2 {
+		return failed(ErrEmptyInput, "OneSampleTTestData: need at least 2 observations")
+	}
+	sd := stat.StdDev(x)
+	if !(sd > 0) {
+		return failed(ErrInvalidParameter, "OneSampleTTestData: sample standard deviation must be > 0")
+	}
+	return OneSampleTTest(stat.Mean(x), populationMean, sd, len(x), opts...)
+}
 
-	return TestResult{Statistic: t, PValue: p}
+// TwoSampleTTestData compares the means of the samples x and y with
+// Welch's t-test, or with the pooled-variance Student's t-test when opts
+// sets EqualVariance. Means and standard deviations are computed by the stat
+// package.
+func TwoSampleTTestData(x, y []float64, opts ...TestOptions) TestResult {
+	if len(x) < 2 || len(y) < 2 {
+		return failed(ErrEmptyInput, "TwoSampleTTestData: need at least 2 observations in each sample")
+	}
+	o, err := testOptions("TwoSampleTTestData", opts)
+	if err != nil {
+		return failedWith(err)
+	}
+	sd1, sd2 := stat.StdDev(x), stat.StdDev(y)
+	if o.EqualVariance {
+		if !(pooledStdDev(sd1, sd2, len(x), len(y)) > 0) {
+			return failed(ErrInvalidParameter, "TwoSampleTTestData: pooled standard deviation must be > 0")
+		}
+		return TwoSampleTTestPooled(stat.Mean(x), stat.Mean(y), sd1, sd2, len(x), len(y), o)
+	}
+	if !(sd1 > 0 && sd2 > 0) {
+		return failed(ErrInvalidParameter, "TwoSampleTTestData: standard deviations must be > 0")
+	}
+	return TwoSampleTTestWelch(stat.Mean(x), stat.Mean(y), sd1, sd2, len(x), len(y), o)
 }
 
-// studentTCDF approximates the CDF for the Student’s t-distribution (for two-tailed tests).
-// Currently uses a normal distribution approximation as a placeholder.
-// In the future, this can be replaced with a more accurate approximation
-// (e.g., using a series expansion or continued fractions) without needing external dependencies like gonum.
-func studentTCDF(t, df float64) float64 {
-	_ = df // Retain df for future implementation; suppress unused parameter warning.
+// TwoSampleTTestPooled returns a t-test statistic and p-value for the
+// two-sample Student's t-test, which assumes the two populations share a
+// variance and estimates it by pooling, on n₁+n₂-2 degrees of freedom.
+// The result carries a confidence interval for the difference in means and
+// Cohen's d, standardised by the pooled standard deviation, as effect size.
+// The test is two-sided unless opts sets another Alternative. Use Welch's
+// test when the variances may differ; LeveneTest, BartlettTest and
+// VarianceFTest test whether they do.
+func TwoSampleTTestPooled(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int, opts ...TestOptions) TestResult {
+	if !(stdDev1 >= 0 && stdDev2 >= 0) || n1 <= 1 || n2 <= 1 {
+		return failed(ErrInvalidParameter, "TwoSampleTTestPooled: standard deviations must be ≥ 0 and sample sizes > 1")
+	}
+	if !(pooledStdDev(stdDev1, stdDev2, n1, n2) > 0) {
+		return failed(ErrInvalidParameter, "TwoSampleTTestPooled: pooled standard deviation must be > 0")
+	}
+	o, err := testOptions("TwoSampleTTestPooled", opts)
+	if err != nil {
+		return failedWith(err)
+	}
+	return pooledTTest(mean1, mean2, stdDev1, stdDev2, n1, n2, o)
+}
+
+// pooledTTest is TwoSampleTTestPooled on validated arguments.
+func pooledTTest(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int, o TestOptions) TestResult {
+	sp := pooledStdDev(stdDev1, stdDev2, n1, n2)
+	se := sp * math.Sqrt(1/float64(n1)+1/float64(n2))
+	diff := mean1 - mean2
+	t := diff / se
+	df := float64(n1 + n2 - 2)
+	null := probability.StudentT{Nu: df}
+	p := pValue(null, t, o.Alternative)
 
-	// TODO: Replace with a proper Student's t-distribution CDF approximation.
-	// For now, use the standard normal CDF as an approximation.
-	return standardNormalCDF(t)
+	return TestResult{
+		Statistic:      t,
+		PValue:         p,
+		Method:         "Two Sample t-test",
+		StatisticName:  "t",
+		Alternative:    o.Alternative,
+		DF:             df,
+		N:              []int{n1, n2},
+		Parameter:      "difference in means",
+		Estimate:       diff,
+		ConfInt:        confInt(null, diff, se, o),
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     diff / sp,
+		EffectSizeName: "Cohen's d",
+	}
 }
 
-// standardNormalCDF computes the cumulative distribution function for the standard normal distribution.
-func standardNormalCDF(x float64) float64 {
-	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+// pooledStdDev returns the pooled standard deviation
+// √(((n₁-1)s₁² + (n₂-1)s₂²) / (n₁+n₂-2)) of two samples.
+func pooledStdDev(s1, s2 float64, n1, n2 int) float64 {
+	v := (float64(n1-1)*s1*s1 + float64(n2-1)*s2*s2) / float64(n1+n2-2)
+	return math.Sqrt(v)
 }
@@ -1,6 +1,7 @@
 package hypothesis
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -23,21 +24,17 @@ func TestOneSampleTTest_Valid(t *testing.T) {
 }
 
 func TestOneSampleTTest_SampleSizeTooSmall(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for n <= 1")
-		}
-	}()
-	_ = OneSampleTTest(100, 100, 10, 1)
+	res := OneSampleTTest(100, 100, 10, 1)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error for n <= 1: err = %v", res.Err)
+	}
 }
 
 func TestOneSampleTTest_NonPositiveStdDev(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for stdDev <= 0")
-		}
-	}()
-	_ = OneSampleTTest(100, 95, 0, 10)
+	res := OneSampleTTest(100, 95, 0, 10)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error for stdDev <= 0: err = %v", res.Err)
+	}
 }
 
 func TestTwoSampleTTestWelch(t *testing.T) {
@@ -58,21 +55,17 @@ func TestTwoSampleTTestWelch(t *testing.T) {
 }
 
 func TestTwoSampleTTestWelch_InvalidSampleSize(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for n1 or n2 <= 1")
-		}
-	}()
-	_ = TwoSampleTTestWelch(100, 95, 10, 12, 1, 25)
+	res := TwoSampleTTestWelch(100, 95, 10, 12, 1, 25)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error for n1 or n2 <= 1: err = %v", res.Err)
+	}
 }
 
 func TestTwoSampleTTestWelch_InvalidStdDev(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for stdDev1 or stdDev2 <= 0")
-		}
-	}()
-	_ = TwoSampleTTestWelch(100, 95, 0, 12, 30, 25)
+	res := TwoSampleTTestWelch(100, 95, 0, 12, 30, 25)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error for stdDev1 or stdDev2 <= 0: err = %v", res.Err)
+	}
 }
 
 func TestPairedTTest(t *testing.T) {
@@ -106,19 +99,191 @@ func TestPairedTTest(t *testing.T) {
 }
 
 func TestPairedTTest_LengthMismatch(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for unequal lengths")
-		}
-	}()
-	_ = PairedTTest([]float64{1, 2}, []float64{1})
+	res := PairedTTest([]float64{1, 2}, []float64{1})
+	if !errors.Is(res.Err, ErrLengthMismatch) {
+		t.Errorf("Expected error for unequal lengths: err = %v", res.Err)
+	}
 }
 
 func TestPairedTTest_TooShort(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("Expected panic for length < 2")
+	res := PairedTTest([]float64{1}, []float64{1})
+	if !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("Expected error for length < 2: err = %v", res.Err)
+	}
+}
+
+func TestPairedTTest_ConstantDifference(t *testing.T) {
+	x := []float64{0.1, 0.7, 1e8, 3.3, -2.9}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = x[i] + 1
+	}
+	res := PairedTTest(x, y)
+	if !errors.Is(res.Err, ErrInvalidParameter) || !math.IsNaN(res.Statistic) {
+		t.Errorf("constant shift: t = %v, err = %v; want ErrInvalidParameter", res.Statistic, res.Err)
+	}
+	// overflowing differences give a non-finite standard deviation
+	if res := PairedTTest([]float64{1e308, -1e308}, []float64{-1e308, 1e308}); !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("overflow: err = %v; want ErrInvalidParameter", res.Err)
+	}
+}
+
+// Reference p-values match R's t.test for the same summary statistics.
+func TestTTestPValues(t *testing.T) {
+	tests := []struct {
+		name string
+		got  TestResult
+		want float64
+	}{
+		{"one-sample df=29", OneSampleTTest(105, 100, 15, 30), 0.078203},
+		{"one-sample df=24", OneSampleTTest(98, 100, 10, 25), 0.327287},
+		{"Welch", TwoSampleTTestWelch(100, 95, 10, 12, 30, 25), 0.103983},
+		{"paired df=4", PairedTTest([]float64{85, 90, 78, 92, 88}, []float64{80, 88, 75, 89, 85}), 0.002838},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got.PValue-tt.want) > 1e-5 {
+			t.Errorf("%s: PValue = %.6f; want %.6f", tt.name, tt.got.PValue, tt.want)
 		}
-	}()
-	_ = PairedTTest([]float64{1}, []float64{1})
+	}
+}
+
+// The sleep data of Cushny and Peebles, as used in the examples of R's
+// t.test.
+var sleep1 = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+var sleep2 = []float64{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+
+// sampleStdDev returns the n-1 standard deviation of data.
+func sampleStdDev(data []float64) float64 {
+	m := mean(data)
+	var ss float64
+	for _, x := range data {
+		ss += (x - m) * (x - m)
+	}
+	return math.Sqrt(ss / float64(len(data)-1))
+}
+
+// Reference values from R's t.test(sleep1), t.test(sleep1, sleep2) and
+// t.test(sleep1, sleep2, paired = TRUE); qt(0.975, 9) = 2.262157.
+func TestTTestResultDetails(t *testing.T) {
+	s1, s2 := sampleStdDev(sleep1), sampleStdDev(sleep2)
+	tests := []struct {
+		name     string
+		got      TestResult
+		stat, df float64
+		ci       [2]float64
+		estimate float64
+		effect   float64
+		n        []int
+	}{
+		{
+			"one-sample", OneSampleTTest(mean(sleep1), 0, s1, 10),
+			1.3257, 9, [2]float64{0.75 - 2.262157*s1/math.Sqrt(10), 0.75 + 2.262157*s1/math.Sqrt(10)},
+			0.75, 0.75 / s1, []int{10},
+		},
+		{
+			"Welch", TwoSampleTTestWelch(mean(sleep1), mean(sleep2), s1, s2, 10, 10),
+			-1.8608, 17.776, [2]float64{-3.3654832, 0.2054832},
+			-1.58, -1.58 / math.Sqrt((s1*s1+s2*s2)/2), []int{10, 10},
+		},
+		{
+			"paired", PairedTTest(sleep1, sleep2),
+			-4.0621, 9, [2]float64{-2.4598858, -0.7001142},
+			-1.58, -4.0621 / math.Sqrt(10), []int{10},
+		},
+	}
+	for _, tt := range tests {
+		r := tt.got
+		if r.Err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, r.Err)
+		}
+		if math.Abs(r.Statistic-tt.stat) > 1e-4 || math.Abs(r.DF-tt.df) > 1e-3 {
+			t.Errorf("%s: t = %v, df = %v; want %v, %v", tt.name, r.Statistic, r.DF, tt.stat, tt.df)
+		}
+		if math.Abs(r.ConfInt[0]-tt.ci[0]) > 1e-6 || math.Abs(r.ConfInt[1]-tt.ci[1]) > 1e-6 || r.ConfLevel != 0.95 {
+			t.Errorf("%s: %v interval %v; want %v", tt.name, r.ConfLevel, r.ConfInt, tt.ci)
+		}
+		if math.Abs(r.Estimate-tt.estimate) > 1e-12 || math.Abs(r.EffectSize-tt.effect) > 1e-4 {
+			t.Errorf("%s: estimate %v, effect size %v; want %v, %v", tt.name, r.Estimate, r.EffectSize, tt.estimate, tt.effect)
+		}
+		if len(r.N) != len(tt.n) || r.N[0] != tt.n[0] || r.Alternative != TwoSided || r.StatisticName != "t" {
+			t.Errorf("%s: N = %v, alternative %v, statistic %q", tt.name, r.N, r.Alternative, r.StatisticName)
+		}
+	}
+}
+
+func TestTTestData(t *testing.T) {
+	s1 := sampleStdDev(sleep1)
+	one := OneSampleTTestData(sleep1, 0.5, TestOptions{Alternative: Greater})
+	want := OneSampleTTest(0.75, 0.5, s1, 10, TestOptions{Alternative: Greater})
+	if math.Abs(one.Statistic-want.Statistic) > 1e-12 || math.Abs(one.PValue-want.PValue) > 1e-12 || one.ConfInt[1] != want.ConfInt[1] {
+		t.Errorf("OneSampleTTestData = %+v; want %+v", one, want)
+	}
+
+	welch := TwoSampleTTestData(sleep1, sleep2)
+	if welch.Method != "Welch Two Sample t-test" || math.Abs(welch.PValue-0.07939) > 1e-5 ||
+		math.Abs(welch.ConfInt[0]+3.3654832) > 1e-6 || math.Abs(welch.ConfInt[1]-0.2054832) > 1e-6 {
+		t.Errorf("Welch TwoSampleTTestData = %+v", welch)
+	}
+
+	// R: t.test(sleep1, sleep2, var.equal = TRUE)
+	pooled := TwoSampleTTestData(sleep1, sleep2, TestOptions{EqualVariance: true})
+	if pooled.Method != "Two Sample t-test" || pooled.DF != 18 || math.Abs(pooled.Statistic+1.8608) > 1e-4 ||
+		math.Abs(pooled.PValue-0.07919) > 1e-5 ||
+		math.Abs(pooled.ConfInt[0]+3.363874) > 1e-6 || math.Abs(pooled.ConfInt[1]-0.203874) > 1e-6 {
+		t.Errorf("pooled TwoSampleTTestData = %+v", pooled)
+	}
+
+	// unequal sizes: Student's t uses (n₁-1)s₁² + (n₂-1)s₂² over n₁+n₂-2
+	x, y := []float64{1, 2, 3, 4}, []float64{2, 4, 6}
+	sp := math.Sqrt((3*sampleStdDev(x)*sampleStdDev(x) + 2*4.0) / 5)
+	wantT := (2.5 - 4) / (sp * math.Sqrt(1.0/4+1.0/3))
+	if got := TwoSampleTTestData(x, y, TestOptions{EqualVariance: true}); math.Abs(got.Statistic-wantT) > 1e-14 || got.DF != 5 {
+		t.Errorf("pooled t = %v on %v df; want %v on 5", got.Statistic, got.DF, wantT)
+	}
+}
+
+func TestTTestDataErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"one-sample short", OneSampleTTestData([]float64{1}, 0), ErrEmptyInput},
+		{"one-sample constant", OneSampleTTestData([]float64{2, 2, 2}, 0), ErrInvalidParameter},
+		{"two-sample short", TwoSampleTTestData([]float64{1, 2}, []float64{3}), ErrEmptyInput},
+		{"Welch constant", TwoSampleTTestData([]float64{1, 2}, []float64{3, 3}), ErrInvalidParameter},
+		{"pooled constant", TwoSampleTTestData([]float64{1, 1}, []float64{3, 3}, TestOptions{EqualVariance: true}), ErrInvalidParameter},
+		{"bad options", TwoSampleTTestData([]float64{1, 2}, []float64{3, 5}, TestOptions{ConfLevel: 2}), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+	// one constant sample is fine for the pooled test
+	if res := TwoSampleTTestData([]float64{1, 2}, []float64{3, 3}, TestOptions{EqualVariance: true}); res.Err != nil {
+		t.Errorf("pooled test with one constant sample: %v", res.Err)
+	}
+}
+
+func TestTwoSampleTTestPooled(t *testing.T) {
+	s1, s2 := sampleStdDev(sleep1), sampleStdDev(sleep2)
+	r := TwoSampleTTestPooled(0.75, 2.33, s1, s2, 10, 10)
+	data := TwoSampleTTestData(sleep1, sleep2, TestOptions{EqualVariance: true})
+	if math.Abs(r.PValue-0.07919) > 1e-5 || math.Abs(r.Statistic-data.Statistic) > 1e-12 || r.DF != 18 {
+		t.Errorf("TwoSampleTTestPooled = %+v", r)
+	}
+	if math.Abs(r.EffectSize+1.58/pooledStdDev(s1, s2, 10, 10)) > 1e-12 {
+		t.Errorf("Cohen's d = %v", r.EffectSize)
+	}
+	for _, bad := range []TestResult{
+		TwoSampleTTestPooled(1, 2, -1, 1, 10, 10),
+		TwoSampleTTestPooled(1, 2, 0, 0, 10, 10),
+		TwoSampleTTestPooled(1, 2, 1, 1, 1, 10),
+		TwoSampleTTestPooled(1, 2, 1, 1, 10, 10, TestOptions{ConfLevel: 1.5}),
+	} {
+		if !errors.Is(bad.Err, ErrInvalidParameter) {
+			t.Errorf("TwoSampleTTestPooled: err = %v; want ErrInvalidParameter", bad.Err)
+		}
+	}
 }
@@ -1,8 +1,135 @@
 package hypothesis
 
-// TestResult holds the outcome of a hypothesis test
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// TestResult holds the outcome of a hypothesis test. When the inputs are
+// invalid the test does not run: Err is set, matching one of the sentinel
+// errors under errors.Is, and Statistic and PValue are NaN.
+//
+// The remaining fields describe the test for reporting. A field that does
+// not apply to a test keeps its zero value: DF is 0 for z-tests, ConfLevel
+// is 0 when there is no confidence interval, Parameter is empty when the
+// test estimates no parameter, and Alternative is 0 for omnibus tests such
+// as chi-square and ANOVA, whose statistic only rejects in one direction.
 type TestResult struct {
 	Statistic float64
 	PValue    float64
 	Err       error
+
+	Method        string      // name of the test, e.g. "Welch Two Sample t-test"
+	StatisticName string      // symbol of the statistic: "t", "z", "X-squared", "F"
+	Alternative   Alternative // alternative hypothesis of a test about Parameter
+	DF            float64     // degrees of freedom of the null distribution
+	DF2           float64     // denominator degrees of freedom of an F statistic
+	N             []int       // sample sizes, one per sample or group
+
+	Parameter string     // parameter under test, e.g. "mean" or "difference in means"
+	NullValue float64    // value of Parameter under the null hypothesis
+	Estimate  float64    // sample estimate of Parameter
+	ConfInt   [2]float64 // confidence interval for Parameter
+	ConfLevel float64    // coverage of ConfInt, e.g. 0.95
+
+	EffectSize     float64 // standardised effect size
+	EffectSizeName string  // e.g. "Cohen's d", "Cramér's V", "eta squared"
+}
+
+// Alternative is the alternative hypothesis of a test about a parameter θ
+// with null value θ₀.
+type Alternative int
+
+const (
+	TwoSided Alternative = iota + 1 // θ ≠ θ₀
+	Less                            // θ < θ₀
+	Greater                         // θ > θ₀
+)
+
+// String returns the relation the alternative asserts, as in "true mean is
+// not equal to 0".
+func (a Alternative) String() string {
+	switch a {
+	case TwoSided:
+		return "not equal to"
+	case Less:
+		return "less than"
+	case Greater:
+		return "greater than"
+	}
+	return fmt.Sprintf("Alternative(%d)", int(a))
+}
+
+// Reject reports whether the test rejects the null hypothesis at
+// significance level alpha, that is whether it ran and p ≤ alpha.
+func (r TestResult) Reject(alpha float64) bool {
+	return r.Err == nil && r.PValue <= alpha
+}
+
+// String formats the result as a summary in the layout of R's htest print
+// method: the test name, the statistic with its degrees of freedom and
+// p-value, the alternative, the confidence interval, the estimate and the
+// effect size.
+func (r TestResult) String() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\t%s\n\n", r.Method)
+	if len(r.N) > 0 {
+		sizes := make([]string, len(r.N))
+		for i, n := range r.N {
+			sizes[i] = fmt.Sprint(n)
+		}
+		fmt.Fprintf(&b, "n = %s\n", strings.Join(sizes, ", "))
+	}
+
+	name := r.StatisticName
+	if name == "" {
+		name = "statistic"
+	}
+	fmt.Fprintf(&b, "%s = %s", name, formatNum(r.Statistic, 5))
+	switch {
+	case r.DF2 > 0:
+		fmt.Fprintf(&b, ", num df = %s, denom df = %s", formatNum(r.DF, 5), formatNum(r.DF2, 5))
+	case r.DF > 0:
+		fmt.Fprintf(&b, ", df = %s", formatNum(r.DF, 5))
+	}
+	fmt.Fprintf(&b, ", p-value %s\n", formatPValue(r.PValue))
+
+	if r.Parameter != "" && r.Alternative != 0 {
+		fmt.Fprintf(&b, "alternative hypothesis: true %s is %v %s\n", r.Parameter, r.Alternative, formatNum(r.NullValue, 7))
+	}
+	if r.ConfLevel > 0 {
+		fmt.Fprintf(&b, "%s percent confidence interval:\n %s %s\n",
+			formatNum(100*r.ConfLevel, 7), formatNum(r.ConfInt[0], 7), formatNum(r.ConfInt[1], 7))
+	}
+	if r.Parameter != "" {
+		fmt.Fprintf(&b, "sample estimates:\n%s\n%s\n", r.Parameter, formatNum(r.Estimate, 7))
+	}
+	if r.EffectSizeName != "" {
+		fmt.Fprintf(&b, "effect size:\n%s = %s\n", r.EffectSizeName, formatNum(r.EffectSize, 4))
+	}
+	return b.String()
+}
+
+// formatNum formats x with the given number of significant digits.
+func formatNum(x float64, digits int) string {
+	switch {
+	case math.IsInf(x, 1):
+		return "Inf"
+	case math.IsInf(x, -1):
+		return "-Inf"
+	}
+	return fmt.Sprintf("%.*g", digits, x)
+}
+
+// formatPValue formats a p-value as R does, with "< 2.2e-16" below the
+// resolution of double precision.
+func formatPValue(p float64) string {
+	if p < 2.2e-16 {
+		return "< 2.2e-16"
+	}
+	return "= " + formatNum(p, 4)
 }
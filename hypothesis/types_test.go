@@ -0,0 +1,41 @@
+package hypothesis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTestResultReject(t *testing.T) {
+	res := TestResult{PValue: 0.03}
+	if !res.Reject(0.05) || res.Reject(0.01) || !res.Reject(0.03) {
+		t.Errorf("Reject at p = 0.03 gave %v, %v, %v", res.Reject(0.05), res.Reject(0.01), res.Reject(0.03))
+	}
+	if failed(ErrInvalidParameter, "x").Reject(1) {
+		t.Error("a failed test must not reject")
+	}
+}
+
+func TestTestResultString(t *testing.T) {
+	got := PairedTTest(sleep1, sleep2).String()
+	for _, want := range []string{
+		"\tPaired t-test\n",
+		"n = 10\n",
+		"t = -4.0621, df = 9, p-value = 0.002833\n",
+		"alternative hypothesis: true mean difference is not equal to 0\n",
+		"95 percent confidence interval:\n -2.459886 -0.7001142\n",
+		"sample estimates:\nmean difference\n-1.58\n",
+		"effect size:\nCohen's d_z = -1.285\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q; missing %q", got, want)
+		}
+	}
+
+	got = ChiSquareTestOfIndependence([][]float64{{1e4, 1}, {1, 1e4}}).String()
+	if !strings.Contains(got, "X-squared = 19994, df = 1, p-value < 2.2e-16\n") || strings.Contains(got, "alternative") {
+		t.Errorf("String() = %q", got)
+	}
+	if got := OneWayANOVA([][]float64{{1}}).String(); got != "OneWayANOVA: requires at least two groups" {
+		t.Errorf("String() of a failed test = %q", got)
+	}
+}
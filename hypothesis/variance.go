@@ -0,0 +1,160 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+	"github.com/cyber-mountain-man/statistical-go/stat"
+)
+
+// Center is the location from which LeveneTest measures the absolute
+// deviations of each group.
+type Center int
+
+const (
+	// CenterMedian gives the Brown-Forsythe test, which keeps its level
+	// for skewed and heavy-tailed data. It is the default.
+	CenterMedian Center = iota
+	// CenterMean gives Levene's original test.
+	CenterMean
+)
+
+// LeveneTest tests whether the groups have equal variances by a one-way
+// ANOVA on the absolute deviations of each observation from its group's
+// center: the median (the Brown-Forsythe test) or the mean. The statistic
+// follows an F distribution on k-1 and N-k degrees of freedom for k groups
+// of N observations. Err is set for fewer than two groups, an empty group,
+// no more observations than groups, or an unknown center. When every group
+// is constant the statistic and p-value are NaN.
+func LeveneTest(groups [][]float64, center Center) TestResult {
+	if err := checkGroups("LeveneTest", groups); err != nil {
+		return failedWith(err)
+	}
+	method := "Brown-Forsythe test of homogeneity of variances"
+	switch center {
+	case CenterMedian:
+	case CenterMean:
+		method = "Levene's test of homogeneity of variances"
+	default:
+		return failed(ErrInvalidParameter, "LeveneTest: center must be CenterMedian or CenterMean")
+	}
+
+	deviations := make([][]float64, len(groups))
+	for i, group := range groups {
+		c := stat.Median(group)
+		if center == CenterMean {
+			c = stat.Mean(group)
+		}
+		deviations[i] = make([]float64, len(group))
+		for j, x := range group {
+			deviations[i][j] = math.Abs(x - c)
+		}
+	}
+	res := OneWayANOVA(deviations)
+	res.Method = method
+	res.EffectSize, res.EffectSizeName = 0, ""
+	return res
+}
+
+// BartlettTest tests whether normally distributed groups have equal
+// variances. Bartlett's statistic
+//
+//	K² = ((N-k) ln s²ₚ - Σ (nᵢ-1) ln sᵢ²) / (1 + (Σ 1/(nᵢ-1) - 1/(N-k)) / (3(k-1)))
+//
+// with s²ₚ the pooled variance follows a chi-square distribution on k-1
+// degrees of freedom. The test is sensitive to non-normality; prefer
+// LeveneTest for heavy-tailed data. Err is set for fewer than two groups
+// or a group with fewer than two observations or zero variance.
+func BartlettTest(groups [][]float64) TestResult {
+	if len(groups) < 2 {
+		return failed(ErrEmptyInput, "BartlettTest: requires at least two groups")
+	}
+	k := float64(len(groups))
+	sizes := make([]int, len(groups))
+	var dfTotal, pooled, sumLog, sumInv float64
+	for i, group := range groups {
+		if len(group) < 2 {
+			return failed(ErrEmptyInput, "BartlettTest: every group needs at least 2 observations")
+		}
+		v := stat.Variance(group)
+		if !(v > 0) {
+			return failed(ErrInvalidParameter, "BartlettTest: every group needs a positive variance")
+		}
+		df := float64(len(group) - 1)
+		sizes[i] = len(group)
+		dfTotal += df
+		pooled += df * v
+		sumLog += df * math.Log(v)
+		sumInv += 1 / df
+	}
+	pooled /= dfTotal
+
+	correction := 1 + (sumInv-1/dfTotal)/(3*(k-1))
+	k2 := (dfTotal*math.Log(pooled) - sumLog) / correction
+	return TestResult{
+		Statistic:     k2,
+		PValue:        probability.ChiSquared{K: k - 1}.Survival(k2),
+		Method:        "Bartlett test of homogeneity of variances",
+		StatisticName: "Bartlett's K-squared",
+		DF:            k - 1,
+		N:             sizes,
+	}
+}
+
+// VarianceFTest tests whether two normal populations have equal variances
+// from the sample standard deviations. The statistic F = s₁² / s₂² follows
+// an F distribution on n₁-1 and n₂-1 degrees of freedom, and the result
+// carries a confidence interval for the ratio of variances σ₁² / σ₂². The
+// test is two-sided, with p-value twice the smaller tail, unless opts sets
+// another Alternative.
+func VarianceFTest(stdDev1, stdDev2 float64, n1, n2 int, opts ...TestOptions) TestResult {
+	if !(stdDev1 > 0 && stdDev2 > 0) || math.IsInf(stdDev1, 0) || math.IsInf(stdDev2, 0) || n1 <= 1 || n2 <= 1 {
+		return failed(ErrInvalidParameter, "VarianceFTest: standard deviations must be positive and finite and sample sizes > 1")
+	}
+	o, err := testOptions("VarianceFTest", opts)
+	if err != nil {
+		return failedWith(err)
+	}
+	df1, df2 := float64(n1-1), float64(n2-1)
+	null := probability.FDist{D1: df1, D2: df2}
+	f := (stdDev1 * stdDev1) / (stdDev2 * stdDev2)
+
+	var p float64
+	var ci [2]float64
+	switch o.Alternative {
+	case Less:
+		p = null.CDF(f)
+		ci = [2]float64{0, f / null.Quantile(1-o.ConfLevel)}
+	case Greater:
+		p = null.Survival(f)
+		ci = [2]float64{f / null.Quantile(o.ConfLevel), math.Inf(1)}
+	default:
+		p = math.Min(1, 2*math.Min(null.CDF(f), null.Survival(f)))
+		beta := (1 - o.ConfLevel) / 2
+		ci = [2]float64{f / null.Quantile(1-beta), f / null.Quantile(beta)}
+	}
+	return TestResult{
+		Statistic:     f,
+		PValue:        p,
+		Method:        "F test to compare two variances",
+		StatisticName: "F",
+		Alternative:   o.Alternative,
+		DF:            df1,
+		DF2:           df2,
+		N:             []int{n1, n2},
+		Parameter:     "ratio of variances",
+		NullValue:     1,
+		Estimate:      f,
+		ConfInt:       ci,
+		ConfLevel:     o.ConfLevel,
+	}
+}
+
+// VarianceFTestData runs VarianceFTest on the samples x and y, with the
+// standard deviations computed by the stat package.
+func VarianceFTestData(x, y []float64, opts ...TestOptions) TestResult {
+	if len(x) < 2 || len(y) < 2 {
+		return failed(ErrEmptyInput, "VarianceFTestData: need at least 2 observations in each sample")
+	}
+	return VarianceFTest(stat.StdDev(x), stat.StdDev(y), len(x), len(y), opts...)
+}
@@ -0,0 +1,113 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// InsectSprays from R's datasets package: insect counts for six sprays.
+var insectSprays = [][]float64{
+	{10, 7, 20, 14, 14, 12, 10, 23, 17, 20, 14, 13},
+	{11, 17, 21, 11, 16, 14, 17, 17, 19, 21, 7, 13},
+	{0, 1, 7, 2, 3, 1, 2, 1, 3, 0, 1, 4},
+	{3, 5, 12, 6, 4, 3, 5, 5, 5, 5, 2, 4},
+	{3, 5, 3, 5, 3, 6, 1, 1, 3, 2, 6, 4},
+	{11, 9, 15, 22, 15, 16, 13, 10, 26, 26, 24, 13},
+}
+
+// Reference values from car::leveneTest(count ~ spray, InsectSprays) with
+// center = median and center = mean, and bartlett.test(count ~ spray).
+func TestVarianceHomogeneityTests(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     TestResult
+		stat, p float64
+		df, df2 float64
+	}{
+		{"Brown-Forsythe", LeveneTest(insectSprays, CenterMedian), 3.8214, 0.004223, 5, 66},
+		{"Levene", LeveneTest(insectSprays, CenterMean), 6.4554, 6.104e-05, 5, 66},
+		{"Bartlett", BartlettTest(insectSprays), 25.96, 9.085e-05, 5, 0},
+	}
+	for _, tt := range tests {
+		r := tt.got
+		if r.Err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, r.Err)
+		}
+		if math.Abs(r.Statistic-tt.stat) > 1e-4*tt.stat || math.Abs(r.PValue-tt.p) > 1e-3*tt.p {
+			t.Errorf("%s: statistic %v, p = %v; want %v, %v", tt.name, r.Statistic, r.PValue, tt.stat, tt.p)
+		}
+		if r.DF != tt.df || r.DF2 != tt.df2 || len(r.N) != 6 {
+			t.Errorf("%s: df %v, %v and N %v", tt.name, r.DF, r.DF2, r.N)
+		}
+	}
+	if got := LeveneTest(insectSprays, CenterMedian).Method; got != "Brown-Forsythe test of homogeneity of variances" {
+		t.Errorf("Method = %q", got)
+	}
+}
+
+func TestVarianceHomogeneityErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"Levene one group", LeveneTest(insectSprays[:1], CenterMean), ErrEmptyInput},
+		{"Levene empty group", LeveneTest([][]float64{{1, 2}, {}}, CenterMedian), ErrEmptyInput},
+		{"Levene center", LeveneTest(insectSprays, Center(5)), ErrInvalidParameter},
+		{"Bartlett one group", BartlettTest(insectSprays[:1]), ErrEmptyInput},
+		{"Bartlett singleton", BartlettTest([][]float64{{1, 2}, {3}}), ErrEmptyInput},
+		{"Bartlett constant", BartlettTest([][]float64{{1, 2}, {3, 3}}), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) || !math.IsNaN(tt.got.Statistic) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+	if r := LeveneTest([][]float64{{1, 1}, {2, 2}}, CenterMean); r.Err != nil || !math.IsNaN(r.Statistic) {
+		t.Errorf("constant groups: statistic %v, err %v; want NaN, nil", r.Statistic, r.Err)
+	}
+}
+
+// R: var.test(sleep1, sleep2)
+func TestVarianceFTest(t *testing.T) {
+	r := VarianceFTestData(sleep1, sleep2)
+	if math.Abs(r.Statistic-0.79834) > 1e-5 || math.Abs(r.PValue-0.7427) > 1e-4 ||
+		math.Abs(r.ConfInt[0]-0.198297) > 1e-6 || math.Abs(r.ConfInt[1]-3.214123) > 1e-6 {
+		t.Errorf("VarianceFTestData = %+v", r)
+	}
+	if r.DF != 9 || r.DF2 != 9 || r.NullValue != 1 || r.Estimate != r.Statistic {
+		t.Errorf("df %v, %v, null %v, estimate %v", r.DF, r.DF2, r.NullValue, r.Estimate)
+	}
+
+	// a one-sided bound b is the ratio at which the one-sided test of the
+	// rescaled sample has p-value 1 - ConfLevel
+	s1, s2 := sampleStdDev(sleep1), sampleStdDev(sleep2)
+	less := VarianceFTest(s1, s2, 10, 15, TestOptions{Alternative: Less, ConfLevel: 0.9})
+	at := VarianceFTest(s1/math.Sqrt(less.ConfInt[1]), s2, 10, 15, TestOptions{Alternative: Less})
+	if less.ConfInt[0] != 0 || math.Abs(at.PValue-0.1) > 1e-10 {
+		t.Errorf("less: interval %v, p-value at the bound %v", less.ConfInt, at.PValue)
+	}
+	greater := VarianceFTest(s1, s2, 10, 15, TestOptions{Alternative: Greater})
+	at = VarianceFTest(s1/math.Sqrt(greater.ConfInt[0]), s2, 10, 15, TestOptions{Alternative: Greater})
+	if !math.IsInf(greater.ConfInt[1], 1) || math.Abs(at.PValue-0.05) > 1e-10 {
+		t.Errorf("greater: interval %v, p-value at the bound %v", greater.ConfInt, at.PValue)
+	}
+	if math.Abs(less.PValue+greater.PValue-1) > 1e-14 {
+		t.Errorf("one-sided p-values %v and %v do not sum to 1", less.PValue, greater.PValue)
+	}
+
+	for _, bad := range []TestResult{
+		VarianceFTest(0, 1, 10, 10),
+		VarianceFTest(1, math.Inf(1), 10, 10),
+		VarianceFTest(1, 1, 1, 10),
+		VarianceFTest(1, 1, 10, 10, TestOptions{Alternative: 7}),
+	} {
+		if !errors.Is(bad.Err, ErrInvalidParameter) {
+			t.Errorf("VarianceFTest: err = %v; want ErrInvalidParameter", bad.Err)
+		}
+	}
+	if r := VarianceFTestData([]float64{1}, sleep2); !errors.Is(r.Err, ErrEmptyInput) {
+		t.Errorf("VarianceFTestData with one observation: err = %v", r.Err)
+	}
+}
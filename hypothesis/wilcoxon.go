@@ -0,0 +1,220 @@
+package hypothesis
+
+import (
+	"math"
+	"sort"
+)
+
+// MannWhitneyUTest compares the locations of two independent samples with
+// the Wilcoxon-Mann-Whitney rank sum test, which needs no normality. The
+// statistic W is the Mann-Whitney U of x: the number of pairs (xᵢ, yⱼ)
+// with xᵢ > yⱼ, ties counting one half.
+//
+// The p-value is exact for samples under 50 without ties, and otherwise
+// comes from the normal approximation with tie and continuity corrections;
+// TestOptions.PValueMethod overrides the choice. The result carries the
+// Hodges-Lehmann estimate of the location shift, the median of the
+// differences xᵢ - yⱼ, with its distribution-free confidence interval, and
+// the rank-biserial correlation 2W / (n₁n₂) - 1 as effect size. The test is
+// two-sided unless opts sets another Alternative.
+func MannWhitneyUTest(x, y []float64, opts ...TestOptions) TestResult {
+	if len(x) == 0 || len(y) == 0 {
+		return failed(ErrEmptyInput, "MannWhitneyUTest: samples must not be empty")
+	}
+	if !finite(x) || !finite(y) {
+		return failed(ErrInvalidParameter, "MannWhitneyUTest: data must be finite")
+	}
+	o, err := testOptions("MannWhitneyUTest", opts)
+	if err != nil {
+		return failedWith(err)
+	}
+
+	n1, n2 := len(x), len(y)
+	n := n1 + n2
+	ranks, ties := midranks(append(append(make([]float64, 0, n), x...), y...))
+	var r1 float64
+	for _, r := range ranks[:n1] {
+		r1 += r
+	}
+	m := float64(n1) * float64(n2)
+	w := r1 - float64(n1*(n1+1))/2
+
+	// without ties the counts of U cost about N·n₁n₂·min(n₁, n₂)/8 steps,
+	// and with them the counts of the doubled rank sums N·n₁·N(N+1)
+	work := float64(n) * m * float64(min(n1, n2)) / 8
+	if ties > 0 {
+		work = float64(n) * float64(n1) * float64(n*(n+1))
+	}
+	exact, tooLarge := useExact(o.PValueMethod, ties > 0, work)
+	if tooLarge {
+		return failed(ErrInvalidParameter, "MannWhitneyUTest: samples too large for an exact p-value")
+	}
+	if o.PValueMethod == PValueAuto {
+		// R's rule, which stays under about 1.5e6 steps
+		exact = ties == 0 && n1 < 50 && n2 < 50
+	}
+	var p float64
+	method := "Wilcoxon rank sum exact test"
+	switch {
+	case exact && ties == 0:
+		p = exactPValue(rankSumCounts(n1, n2), int(w), m/2, o.Alternative)
+	case exact:
+		c := subsetSumCounts(doubled(ranks), n1)
+		p = exactPValue(c, int(2*r1), float64(n1*(n+1)), o.Alternative)
+	default:
+		method = "Wilcoxon rank sum test with continuity correction"
+		sigma := math.Sqrt(m / 12 * (float64(n+1) - ties/float64(n*(n-1))))
+		if sigma == 0 {
+			return failed(ErrInvalidParameter, "MannWhitneyUTest: all observations are tied")
+		}
+		d := w - m/2
+		p = pValue(standardNormal, (d-continuity(d, o.Alternative))/sigma, o.Alternative)
+	}
+
+	xs, ys := sorted(x), sorted(y)
+	var c []float64
+	if n1 < 50 && n2 < 50 {
+		c = rankSumCounts(n1, n2)
+	}
+	est, ci := hodgesLehmann(differences(xs, ys), n1*n2, c, math.Sqrt(m*float64(n+1)/12), o)
+
+	return TestResult{
+		Statistic:      w,
+		PValue:         p,
+		Method:         method,
+		StatisticName:  "W",
+		Alternative:    o.Alternative,
+		N:              []int{n1, n2},
+		Parameter:      "location shift",
+		Estimate:       est,
+		ConfInt:        ci,
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     2*w/m - 1,
+		EffectSizeName: "rank-biserial r",
+	}
+}
+
+// WilcoxonSignedRankTest tests whether the sample x is symmetric about mu
+// with the Wilcoxon signed-rank test. The statistic V is the sum of the
+// ranks of |xᵢ - mu| over the xᵢ above mu; observations equal to mu are
+// dropped.
+//
+// The p-value is exact for samples under 50 without ties or zeros, and
+// otherwise comes from the normal approximation with tie and continuity
+// corrections; TestOptions.PValueMethod overrides the choice. The result
+// carries the Hodges-Lehmann estimate of the center, the median of the
+// Walsh averages (xᵢ + xⱼ) / 2, with its distribution-free confidence
+// interval, and the matched-pairs rank-biserial correlation as effect
+// size. The test is two-sided unless opts sets another Alternative.
+func WilcoxonSignedRankTest(x []float64, mu float64, opts ...TestOptions) TestResult {
+	return signedRankTest("WilcoxonSignedRankTest", x, mu, "location", opts)
+}
+
+// WilcoxonSignedRankPairedTest runs the Wilcoxon signed-rank test on the
+// differences xᵢ - yᵢ of paired samples, testing for a location shift of
+// 0. See WilcoxonSignedRankTest.
+func WilcoxonSignedRankPairedTest(x, y []float64, opts ...TestOptions) TestResult {
+	if len(x) != len(y) {
+		return failed(ErrLengthMismatch, "WilcoxonSignedRankPairedTest: slices must have the same length")
+	}
+	d := make([]float64, len(x))
+	for i := range x {
+		d[i] = x[i] - y[i]
+	}
+	return signedRankTest("WilcoxonSignedRankPairedTest", d, 0, "location shift", opts)
+}
+
+func signedRankTest(name string, x []float64, mu float64, parameter string, opts []TestOptions) TestResult {
+	if len(x) == 0 {
+		return failed(ErrEmptyInput, name+": data must not be empty")
+	}
+	if !finite(x) || math.IsNaN(mu) || math.IsInf(mu, 0) {
+		return failed(ErrInvalidParameter, name+": data and mu must be finite")
+	}
+	o, err := testOptions(name, opts)
+	if err != nil {
+		return failedWith(err)
+	}
+
+	var abs []float64
+	var positive []bool
+	for _, v := range x {
+		if d := v - mu; d != 0 {
+			abs = append(abs, math.Abs(d))
+			positive = append(positive, d > 0)
+		}
+	}
+	n := len(abs)
+	if n == 0 {
+		return failed(ErrInvalidParameter, name+": every observation equals mu")
+	}
+	ranks, ties := midranks(abs)
+	var v float64
+	for i, r := range ranks {
+		if positive[i] {
+			v += r
+		}
+	}
+	total := float64(n) * float64(n+1) / 2
+
+	exact, tooLarge := useExact(o.PValueMethod, ties > 0 || n < len(x), float64(n)*float64(n*(n+1)))
+	if tooLarge {
+		return failed(ErrInvalidParameter, name+": sample too large for an exact p-value")
+	}
+	if o.PValueMethod == PValueAuto {
+		// R's rule, as in MannWhitneyUTest
+		exact = ties == 0 && n == len(x) && n < 50
+	}
+	var p float64
+	method := "Wilcoxon signed rank exact test"
+	if exact {
+		c := signedSumCounts(doubled(ranks))
+		p = exactPValue(c, int(2*v), total, o.Alternative)
+	} else {
+		method = "Wilcoxon signed rank test with continuity correction"
+		sigma := math.Sqrt(total*float64(2*n+1)/12 - ties/48)
+		if sigma == 0 {
+			return failed(ErrInvalidParameter, name+": all observations are tied")
+		}
+		d := v - total/2
+		p = pValue(standardNormal, (d-continuity(d, o.Alternative))/sigma, o.Alternative)
+	}
+
+	// the estimate and interval use every observation: for a continuous
+	// sample, the rank test at a generic center sees no zeros
+	nx := len(x)
+	var c []float64
+	if nx < 50 {
+		scores := make([]int, nx)
+		for i := range scores {
+			scores[i] = i + 1
+		}
+		c = signedSumCounts(scores)
+	}
+	walsh := nx * (nx + 1) / 2
+	sigma := math.Sqrt(float64(walsh) * float64(2*nx+1) / 12)
+	est, ci := hodgesLehmann(walshAverages(sorted(x)), walsh, c, sigma, o)
+
+	return TestResult{
+		Statistic:      v,
+		PValue:         p,
+		Method:         method,
+		StatisticName:  "V",
+		Alternative:    o.Alternative,
+		N:              []int{nx},
+		Parameter:      parameter,
+		NullValue:      mu,
+		Estimate:       est,
+		ConfInt:        ci,
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     2*v/total - 1,
+		EffectSizeName: "rank-biserial r",
+	}
+}
+
+// sorted returns a sorted copy of x.
+func sorted(x []float64) []float64 {
+	s := append([]float64(nil), x...)
+	sort.Float64s(s)
+	return s
+}
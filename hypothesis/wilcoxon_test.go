@@ -0,0 +1,200 @@
+package hypothesis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Examples of R's wilcox.test: the depression scores of Hollander & Wolfe
+// (paired) and the permeability constants of their table 4.1.
+var (
+	depressionX = []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	depressionY = []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+	permeabX    = []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	permeabY    = []float64{1.15, 0.88, 0.90, 0.74, 1.21}
+)
+
+func TestRankTestsMatchR(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      TestResult
+		stat, p  float64
+		method   string
+		estimate float64
+		ci       [2]float64
+	}{
+		// wilcox.test(x, y, paired = TRUE, alternative = "greater", conf.int = TRUE)
+		{"signed rank greater", WilcoxonSignedRankPairedTest(depressionX, depressionY, TestOptions{Alternative: Greater}),
+			40, 0.01953, "Wilcoxon signed rank exact test", 0.46, [2]float64{0.175, math.Inf(1)}},
+		// wilcox.test(x, y, alternative = "greater", conf.int = TRUE)
+		{"rank sum greater", MannWhitneyUTest(permeabX, permeabY, TestOptions{Alternative: Greater}),
+			35, 0.1272, "Wilcoxon rank sum exact test", 0.305, [2]float64{-0.08, math.Inf(1)}},
+		{"rank sum", MannWhitneyUTest(permeabX, permeabY),
+			35, 0.2544, "Wilcoxon rank sum exact test", 0.305, [2]float64{-0.15, 0.76}},
+		// the sleep data have ties, and zeros among the paired differences
+		{"rank sum with ties", MannWhitneyUTest(sleep1, sleep2),
+			25.5, 0.06933, "Wilcoxon rank sum test with continuity correction", -1.35, [2]float64{-3.6, 0.1}},
+		{"signed rank with ties", WilcoxonSignedRankPairedTest(sleep1, sleep2),
+			0, 0.009091, "Wilcoxon signed rank test with continuity correction", -1.3, [2]float64{-2.7, -0.9}},
+	}
+	for _, tt := range tests {
+		r := tt.got
+		if r.Err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, r.Err)
+		}
+		if r.Statistic != tt.stat || math.Abs(r.PValue-tt.p) > 5e-4*tt.p || r.Method != tt.method {
+			t.Errorf("%s: %s, statistic %v, p = %v; want %s, %v, %v", tt.name, r.Method, r.Statistic, r.PValue, tt.method, tt.stat, tt.p)
+		}
+		if math.Abs(r.Estimate-tt.estimate) > 1e-12 ||
+			math.Abs(r.ConfInt[0]-tt.ci[0]) > 1e-12 || (r.ConfInt[1] != tt.ci[1] && math.Abs(r.ConfInt[1]-tt.ci[1]) > 1e-12) {
+			t.Errorf("%s: estimate %v, interval %v; want %v, %v", tt.name, r.Estimate, r.ConfInt, tt.estimate, tt.ci)
+		}
+	}
+}
+
+// The exact p-value with ties is the share of the relabellings of the
+// pooled sample whose rank sum is at least as extreme.
+func TestMannWhitneyExactWithTies(t *testing.T) {
+	x := []float64{1, 2, 2, 3, 5}
+	y := []float64{2, 3, 4, 4, 6, 7}
+	pooled := append(append([]float64(nil), x...), y...)
+	ranks, _ := midranks(pooled)
+	var observed float64
+	for _, r := range ranks[:len(x)] {
+		observed += r
+	}
+	var below, above, total float64
+	n := len(pooled)
+	for mask := 0; mask < 1<<n; mask++ {
+		var s float64
+		size := 0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				s += ranks[i]
+				size++
+			}
+		}
+		if size != len(x) {
+			continue
+		}
+		total++
+		if s <= observed {
+			below++
+		}
+		if s >= observed {
+			above++
+		}
+	}
+	less := MannWhitneyUTest(x, y, TestOptions{Alternative: Less, PValueMethod: PValueExact})
+	greater := MannWhitneyUTest(x, y, TestOptions{Alternative: Greater, PValueMethod: PValueExact})
+	if math.Abs(less.PValue-below/total) > 1e-14 || math.Abs(greater.PValue-above/total) > 1e-14 {
+		t.Errorf("exact p-values %v, %v; want %v, %v", less.PValue, greater.PValue, below/total, above/total)
+	}
+	if less.Method != "Wilcoxon rank sum exact test" {
+		t.Errorf("Method = %q", less.Method)
+	}
+	if auto := MannWhitneyUTest(x, y); auto.Method != "Wilcoxon rank sum test with continuity correction" {
+		t.Errorf("ties should select the normal approximation, got %q", auto.Method)
+	}
+}
+
+func TestSignedRankOneSample(t *testing.T) {
+	// against mu the one-sample test is the paired test of x - mu
+	d := make([]float64, len(depressionX))
+	for i := range d {
+		d[i] = depressionX[i] - 1.5
+	}
+	one := WilcoxonSignedRankTest(depressionX, 1.5, TestOptions{Alternative: Less})
+	paired := WilcoxonSignedRankPairedTest(d, make([]float64, len(d)), TestOptions{Alternative: Less})
+	if one.Statistic != paired.Statistic || one.PValue != paired.PValue || one.NullValue != 1.5 {
+		t.Errorf("one-sample %+v; paired %+v", one, paired)
+	}
+	if math.Abs(one.Estimate-1.5-paired.Estimate) > 1e-12 {
+		t.Errorf("estimates %v and %v + 1.5", one.Estimate, paired.Estimate)
+	}
+	if one.EffectSize != 2*one.Statistic/45-1 || one.Parameter != "location" {
+		t.Errorf("effect size %v, parameter %q", one.EffectSize, one.Parameter)
+	}
+}
+
+// Samples under 50 without ties get R's exact p-value. With complete
+// separation of 30 and 30 it is 2 / C(60, 30), which R's
+// wilcox.test(31:60, 1:30) also gives, where the normal approximation is
+// off by orders of magnitude.
+func TestMannWhitneyExactUnder50(t *testing.T) {
+	x := make([]float64, 30)
+	y := make([]float64, 30)
+	for i := range x {
+		x[i] = float64(31 + i)
+		y[i] = float64(1 + i)
+	}
+	res := MannWhitneyUTest(x, y)
+	want := 2 / math.Exp(lchoose(60, 30))
+	if res.Method != "Wilcoxon rank sum exact test" || res.Statistic != 900 || math.Abs(res.PValue-want) > 1e-9*want {
+		t.Errorf("%s W = %v, p = %v; want exact 900, %v", res.Method, res.Statistic, res.PValue, want)
+	}
+	for _, n := range []int{20, 40, 49} {
+		x, y := make([]float64, n), make([]float64, n)
+		for i := range x {
+			x[i], y[i] = float64(2*i), float64(2*i+1)
+		}
+		if res := MannWhitneyUTest(x, y); res.Method != "Wilcoxon rank sum exact test" {
+			t.Errorf("%d×%d: Method = %q", n, n, res.Method)
+		}
+	}
+}
+
+// With 60 observations the tests use the normal approximation; its p-value
+// is close to the exact one.
+func TestRankTestsLargeSample(t *testing.T) {
+	x := make([]float64, 60)
+	y := make([]float64, 55)
+	for i := range x {
+		x[i] = math.Sin(float64(i)*1.3) + 0.3
+	}
+	for i := range y {
+		y[i] = math.Cos(float64(i) * 0.7)
+	}
+	approx := MannWhitneyUTest(x, y)
+	exact := MannWhitneyUTest(x, y, TestOptions{PValueMethod: PValueExact})
+	if approx.Method == exact.Method || math.Abs(approx.PValue-exact.PValue) > 0.01 {
+		t.Errorf("rank sum: approximate p = %v, exact p = %v", approx.PValue, exact.PValue)
+	}
+	if !(approx.ConfInt[0] < approx.Estimate && approx.Estimate < approx.ConfInt[1]) {
+		t.Errorf("rank sum: estimate %v outside %v", approx.Estimate, approx.ConfInt)
+	}
+
+	approx = WilcoxonSignedRankTest(x, 0)
+	exact = WilcoxonSignedRankTest(x, 0, TestOptions{PValueMethod: PValueExact})
+	if approx.Method == exact.Method || math.Abs(approx.PValue-exact.PValue) > 0.01 {
+		t.Errorf("signed rank: approximate p = %v, exact p = %v", approx.PValue, exact.PValue)
+	}
+}
+
+func TestRankTestsErrors(t *testing.T) {
+	big := make([]float64, 2000)
+	for i := range big {
+		big[i] = float64(i)
+	}
+	tests := []struct {
+		name string
+		got  TestResult
+		want error
+	}{
+		{"rank sum empty", MannWhitneyUTest(nil, permeabY), ErrEmptyInput},
+		{"rank sum NaN", MannWhitneyUTest([]float64{math.NaN()}, permeabY), ErrInvalidParameter},
+		{"rank sum tied", MannWhitneyUTest([]float64{1, 1}, []float64{1}), ErrInvalidParameter},
+		{"rank sum too large", MannWhitneyUTest(big, big, TestOptions{PValueMethod: PValueExact}), ErrInvalidParameter},
+		{"rank sum options", MannWhitneyUTest(permeabX, permeabY, TestOptions{PValueMethod: 3}), ErrInvalidParameter},
+		{"signed rank empty", WilcoxonSignedRankTest(nil, 0), ErrEmptyInput},
+		{"signed rank mu", WilcoxonSignedRankTest(permeabX, math.Inf(1)), ErrInvalidParameter},
+		{"signed rank all zero", WilcoxonSignedRankTest([]float64{2, 2}, 2), ErrInvalidParameter},
+		{"paired mismatch", WilcoxonSignedRankPairedTest(permeabX, permeabY), ErrLengthMismatch},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.got.Err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.got.Err, tt.want)
+		}
+	}
+}
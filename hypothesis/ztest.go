@@ -2,39 +2,96 @@ package hypothesis
 
 import (
 	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/stat"
 )
 
-// OneSampleZTest performs a one-sample z-test and returns a TestResult.
-func OneSampleZTest(sampleMean, populationMean, populationStdDev float64, n int) TestResult {
-	if populationStdDev <= 0 {
-		panic("OneSampleZTest: population standard deviation must be > 0")
+// OneSampleZTest performs a one-sample z-test of the population mean and
+// returns a TestResult with a confidence interval for the mean and Cohen's
+// d, (x̄ - μ₀) / σ, as effect size. The test is two-sided unless opts sets
+// another Alternative.
+func OneSampleZTest(sampleMean, populationMean, populationStdDev float64, n int, opts ...TestOptions) TestResult {
+	if !(populationStdDev > 0) {
+		return failed(ErrInvalidParameter, "OneSampleZTest: population standard deviation must be > 0")
 	}
 	if n <= 0 {
-		panic("OneSampleZTest: sample size must be > 0")
+		return failed(ErrInvalidParameter, "OneSampleZTest: sample size must be > 0")
+	}
+	o, err := testOptions("OneSampleZTest", opts)
+	if err != nil {
+		return failedWith(err)
 	}
 	standardError := populationStdDev / math.Sqrt(float64(n))
 	z := (sampleMean - populationMean) / standardError
-	p := 2 * (1 - normalCDF(math.Abs(z), 0, 1)) // two-tailed p-value
-	return TestResult{Statistic: z, PValue: p}
+	p := pValue(standardNormal, z, o.Alternative)
+	return TestResult{
+		Statistic:      z,
+		PValue:         p,
+		Method:         "One Sample z-test",
+		StatisticName:  "z",
+		Alternative:    o.Alternative,
+		N:              []int{n},
+		Parameter:      "mean",
+		NullValue:      populationMean,
+		Estimate:       sampleMean,
+		ConfInt:        confInt(standardNormal, sampleMean, standardError, o),
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     (sampleMean - populationMean) / populationStdDev,
+		EffectSizeName: "Cohen's d",
+	}
 }
 
-// TwoSampleZTest performs a two-sample z-test and returns a TestResult.
-func TwoSampleZTest(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int) TestResult {
-	if stdDev1 <= 0 || stdDev2 <= 0 {
-		panic("TwoSampleZTest: both population standard deviations must be > 0")
+// TwoSampleZTest performs a two-sample z-test of the difference in means
+// and returns a TestResult with a confidence interval for the difference
+// and Cohen's d, standardised by the root mean square of the two standard
+// deviations, as effect size. The test is two-sided unless opts sets
+// another Alternative.
+func TwoSampleZTest(mean1, mean2, stdDev1, stdDev2 float64, n1, n2 int, opts ...TestOptions) TestResult {
+	if !(stdDev1 > 0 && stdDev2 > 0) {
+		return failed(ErrInvalidParameter, "TwoSampleZTest: both population standard deviations must be > 0")
 	}
 	if n1 <= 0 || n2 <= 0 {
-		panic("TwoSampleZTest: sample sizes must be > 0")
+		return failed(ErrInvalidParameter, "TwoSampleZTest: sample sizes must be > 0")
+	}
+	o, err := testOptions("TwoSampleZTest", opts)
+	if err != nil {
+		return failedWith(err)
 	}
 	se := math.Sqrt((stdDev1*stdDev1)/float64(n1) + (stdDev2*stdDev2)/float64(n2))
 	z := (mean1 - mean2) / se
-	p := 2 * (1 - normalCDF(math.Abs(z), 0, 1)) // two-tailed p-value
-	return TestResult{Statistic: z, PValue: p}
+	p := pValue(standardNormal, z, o.Alternative)
+	diff := mean1 - mean2
+	return TestResult{
+		Statistic:      z,
+		PValue:         p,
+		Method:         "Two Sample z-test",
+		StatisticName:  "z",
+		Alternative:    o.Alternative,
+		N:              []int{n1, n2},
+		Parameter:      "difference in means",
+		Estimate:       diff,
+		ConfInt:        confInt(standardNormal, diff, se, o),
+		ConfLevel:      o.ConfLevel,
+		EffectSize:     diff / math.Sqrt((stdDev1*stdDev1+stdDev2*stdDev2)/2),
+		EffectSizeName: "Cohen's d",
+	}
 }
 
-// --- helper ---
+// OneSampleZTestData runs OneSampleZTest on the sample x, with the mean
+// computed by the stat package and the population standard deviation known.
+func OneSampleZTestData(x []float64, populationMean, populationStdDev float64, opts ...TestOptions) TestResult {
+	if len(x) == 0 {
+		return failed(ErrEmptyInput, "OneSampleZTestData: sample must not be empty")
+	}
+	return OneSampleZTest(stat.Mean(x), populationMean, populationStdDev, len(x), opts...)
+}
 
-// normalCDF returns the cumulative distribution function for the standard normal distribution.
-func normalCDF(x, mean, stddev float64) float64 {
-	return 0.5 * (1 + math.Erf((x-mean)/(stddev*math.Sqrt2)))
+// TwoSampleZTestData runs TwoSampleZTest on the samples x and y, with the
+// means computed by the stat package and the population standard deviations
+// known.
+func TwoSampleZTestData(x, y []float64, stdDev1, stdDev2 float64, opts ...TestOptions) TestResult {
+	if len(x) == 0 || len(y) == 0 {
+		return failed(ErrEmptyInput, "TwoSampleZTestData: samples must not be empty")
+	}
+	return TwoSampleZTest(stat.Mean(x), stat.Mean(y), stdDev1, stdDev2, len(x), len(y), opts...)
 }
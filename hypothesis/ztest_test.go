@@ -1,6 +1,7 @@
 package hypothesis
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -18,12 +19,10 @@ func TestOneSampleZTest(t *testing.T) {
 }
 
 func TestOneSampleZTest_ZeroStdDev(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic with zero standard deviation")
-		}
-	}()
-	_ = OneSampleZTest(105, 100, 0, 30)
+	res := OneSampleZTest(105, 100, 0, 30)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error with zero standard deviation: err = %v", res.Err)
+	}
 }
 
 func TestTwoSampleZTest(t *testing.T) {
@@ -44,12 +43,10 @@ func TestTwoSampleZTest(t *testing.T) {
 }
 
 func TestTwoSampleZTest_ZeroStdDev(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic with zero standard deviations")
-		}
-	}()
-	_ = TwoSampleZTest(102, 100, 0, 0, 40, 35)
+	res := TwoSampleZTest(102, 100, 0, 0, 40, 35)
+	if !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("Expected error with zero standard deviations: err = %v", res.Err)
+	}
 }
 
 func TestOneSampleZTest_InvalidInput(t *testing.T) {
@@ -64,14 +61,10 @@ func TestOneSampleZTest_InvalidInput(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Expected panic with input: %+v", tt)
-				}
-			}()
-			_ = OneSampleZTest(tt.sampleMean, tt.popMean, tt.popStdDev, tt.n)
-		}()
+		res := OneSampleZTest(tt.sampleMean, tt.popMean, tt.popStdDev, tt.n)
+		if !errors.Is(res.Err, ErrInvalidParameter) || !math.IsNaN(res.PValue) {
+			t.Errorf("Expected an error and NaN p-value with input: %+v, got %+v", tt, res)
+		}
 	}
 }
 
@@ -87,13 +80,56 @@ func TestTwoSampleZTest_InvalidInput(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Expected panic with input: %+v", tt)
-				}
-			}()
-			_ = TwoSampleZTest(5.0, 4.5, tt.stdDev1, tt.stdDev2, tt.n1, tt.n2)
-		}()
+		res := TwoSampleZTest(5.0, 4.5, tt.stdDev1, tt.stdDev2, tt.n1, tt.n2)
+		if !errors.Is(res.Err, ErrInvalidParameter) || !math.IsNaN(res.PValue) {
+			t.Errorf("Expected an error and NaN p-value with input: %+v, got %+v", tt, res)
+		}
+	}
+}
+
+func TestZTestResultDetails(t *testing.T) {
+	r := OneSampleZTest(105, 100, 15, 30)
+	half := 1.959963984540054 * 15 / math.Sqrt(30)
+	if math.Abs(r.ConfInt[0]-(105-half)) > 1e-12 || math.Abs(r.ConfInt[1]-(105+half)) > 1e-12 {
+		t.Errorf("OneSampleZTest interval = %v; want 105 ± %v", r.ConfInt, half)
+	}
+	if r.Estimate != 105 || r.NullValue != 100 || math.Abs(r.EffectSize-1.0/3) > 1e-15 || r.DF != 0 {
+		t.Errorf("OneSampleZTest estimate %v, null %v, d %v, df %v", r.Estimate, r.NullValue, r.EffectSize, r.DF)
+	}
+
+	r = TwoSampleZTest(5.0, 4.5, 1.2, 1.1, 50, 40)
+	se := math.Sqrt(1.2*1.2/50 + 1.1*1.1/40)
+	half = 1.959963984540054 * se
+	if math.Abs(r.ConfInt[0]-(0.5-half)) > 1e-12 || math.Abs(r.ConfInt[1]-(0.5+half)) > 1e-12 {
+		t.Errorf("TwoSampleZTest interval = %v; want 0.5 ± %v", r.ConfInt, half)
+	}
+	if len(r.N) != 2 || r.N[0] != 50 || r.N[1] != 40 || r.Method != "Two Sample z-test" {
+		t.Errorf("TwoSampleZTest N = %v, method %q", r.N, r.Method)
+	}
+}
+
+func TestZTestData(t *testing.T) {
+	x := []float64{101, 99, 104, 110, 96}
+	got := OneSampleZTestData(x, 100, 5)
+	want := OneSampleZTest(102, 100, 5, 5)
+	if got.Statistic != want.Statistic || got.PValue != want.PValue || got.ConfInt != want.ConfInt {
+		t.Errorf("OneSampleZTestData = %+v; want %+v", got, want)
+	}
+
+	y := []float64{95, 97, 99}
+	got = TwoSampleZTestData(x, y, 5, 4, TestOptions{Alternative: Greater})
+	want = TwoSampleZTest(102, 97, 5, 4, 5, 3, TestOptions{Alternative: Greater})
+	if got.Statistic != want.Statistic || got.PValue != want.PValue || got.ConfInt != want.ConfInt || got.N[1] != 3 {
+		t.Errorf("TwoSampleZTestData = %+v; want %+v", got, want)
+	}
+
+	if res := OneSampleZTestData(nil, 0, 1); !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("OneSampleZTestData(nil): err = %v", res.Err)
+	}
+	if res := TwoSampleZTestData(x, nil, 1, 1); !errors.Is(res.Err, ErrEmptyInput) {
+		t.Errorf("TwoSampleZTestData with an empty sample: err = %v", res.Err)
+	}
+	if res := OneSampleZTestData(x, 0, 0); !errors.Is(res.Err, ErrInvalidParameter) {
+		t.Errorf("OneSampleZTestData with σ = 0: err = %v", res.Err)
 	}
 }
@@ -0,0 +1,39 @@
+// Package errs defines the sentinel errors shared by every package of the
+// module. Each public package re-exports them, so errors.Is(err,
+// stat.ErrEmptyInput) and errors.Is(err, hypothesis.ErrEmptyInput) test
+// for the same condition whichever package reported it. The error
+// text names the function and the argument.
+package errs
+
+import "errors"
+
+var (
+	// ErrEmptyInput reports a slice that is empty or too short for the
+	// computation, such as a variance of one value.
+	ErrEmptyInput = errors.New("empty input")
+	// ErrLengthMismatch reports slices or matrices whose lengths must
+	// agree but do not.
+	ErrLengthMismatch = errors.New("length mismatch")
+	// ErrInvalidParameter reports an argument outside its domain, such as
+	// a probability above 1 or a non-positive standard deviation, or data
+	// for which the result is undefined.
+	ErrInvalidParameter = errors.New("invalid parameter")
+	// ErrSingularMatrix reports a matrix that cannot be inverted or
+	// factored.
+	ErrSingularMatrix = errors.New("singular matrix")
+)
+
+// tagged is an error with its own message that unwraps to a sentinel.
+type tagged struct {
+	msg  string
+	kind error
+}
+
+func (e *tagged) Error() string { return e.msg }
+func (e *tagged) Unwrap() error { return e.kind }
+
+// New returns an error whose message is msg and which matches kind under
+// errors.Is, so callers keep a precise message and a testable category.
+func New(kind error, msg string) error {
+	return &tagged{msg: msg, kind: kind}
+}
@@ -0,0 +1,20 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	err := New(ErrEmptyInput, "Mean: data must not be empty")
+	if err.Error() != "Mean: data must not be empty" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, ErrEmptyInput) || errors.Is(err, ErrLengthMismatch) {
+		t.Error("errors.Is should match exactly the tagged sentinel")
+	}
+	if wrapped := fmt.Errorf("outer: %w", err); !errors.Is(wrapped, ErrEmptyInput) {
+		t.Error("the sentinel should survive further wrapping")
+	}
+}
@@ -0,0 +1,169 @@
+// Package linalg holds the small dense-matrix routines shared by the
+// regression and probability packages. Matrices are slices of rows.
+package linalg
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// Transpose returns the transpose of matrix A
+func Transpose(A [][]float64) [][]float64 {
+	rows := len(A)
+	cols := len(A[0])
+	result := make([][]float64, cols)
+	for i := range result {
+		result[i] = make([]float64, rows)
+		for j := 0; j < rows; j++ {
+			result[i][j] = A[j][i]
+		}
+	}
+	return result
+}
+
+// MatMul performs matrix multiplication A * B
+func MatMul(A, B [][]float64) [][]float64 {
+	m := len(A)
+	n := len(B[0])
+	p := len(B)
+	result := make([][]float64, m)
+	for i := range result {
+		result[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for k := 0; k < p; k++ {
+				sum += A[i][k] * B[k][j]
+			}
+			result[i][j] = sum
+		}
+	}
+	return result
+}
+
+// MatVecMul performs matrix-vector multiplication A * x
+func MatVecMul(A [][]float64, x []float64) []float64 {
+	m := len(A)
+	n := len(x)
+	result := make([]float64, m)
+	for i := 0; i < m; i++ {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			sum += A[i][j] * x[j]
+		}
+		result[i] = sum
+	}
+	return result
+}
+
+// Inverse computes the inverse of a square matrix A using Gauss-Jordan elimination.
+func Inverse(A [][]float64) ([][]float64, error) {
+	n := len(A)
+	// Create augmented matrix [A | I]
+	aug := make([][]float64, n)
+	for i := range aug {
+		aug[i] = make([]float64, 2*n)
+		for j := 0; j < n; j++ {
+			aug[i][j] = A[i][j]
+		}
+		aug[i][n+i] = 1
+	}
+
+	// Perform Gauss-Jordan elimination
+	for i := 0; i < n; i++ {
+		pivot := aug[i][i]
+		if pivot == 0 {
+			return nil, errs.New(errs.ErrSingularMatrix, "matrix is singular")
+		}
+		for j := 0; j < 2*n; j++ {
+			aug[i][j] /= pivot
+		}
+		for k := 0; k < n; k++ {
+			if k != i {
+				factor := aug[k][i]
+				for j := 0; j < 2*n; j++ {
+					aug[k][j] -= factor * aug[i][j]
+				}
+			}
+		}
+	}
+
+	// Extract the inverse from augmented matrix
+	inv := make([][]float64, n)
+	for i := range inv {
+		inv[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			inv[i][j] = aug[i][j+n]
+		}
+	}
+	return inv, nil
+}
+
+// Cholesky returns the lower-triangular L with A = L·Lᵀ. A must be square,
+// symmetric and positive definite; only its lower triangle is read.
+func Cholesky(A [][]float64) ([][]float64, error) {
+	n := len(A)
+	L := make([][]float64, n)
+	for i := range L {
+		if len(A[i]) != n {
+			return nil, errs.New(errs.ErrLengthMismatch, "matrix is not square")
+		}
+		L[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			sum := A[i][j]
+			for k := 0; k < j; k++ {
+				sum -= L[i][k] * L[j][k]
+			}
+			if i == j {
+				if !(sum > 0) {
+					return nil, errs.New(errs.ErrInvalidParameter, "matrix is not positive definite")
+				}
+				L[i][i] = math.Sqrt(sum)
+			} else {
+				L[i][j] = sum / L[j][j]
+			}
+		}
+	}
+	return L, nil
+}
+
+// SolveLower solves L·x = b by forward substitution for lower-triangular L.
+func SolveLower(L [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := range b {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= L[i][k] * x[k]
+		}
+		x[i] = sum / L[i][i]
+	}
+	return x
+}
+
+// SolveLowerT solves Lᵀ·x = b by back substitution for lower-triangular L.
+func SolveLowerT(L [][]float64, b []float64) []float64 {
+	n := len(b)
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := b[i]
+		for k := i + 1; k < n; k++ {
+			sum -= L[k][i] * x[k]
+		}
+		x[i] = sum / L[i][i]
+	}
+	return x
+}
+
+// CholeskySolve solves A·x = b given the Cholesky factor L of A.
+func CholeskySolve(L [][]float64, b []float64) []float64 {
+	return SolveLowerT(L, SolveLower(L, b))
+}
+
+// LogDetCholesky returns log det(A) = 2·Σ log L[i][i] from the Cholesky factor of A.
+func LogDetCholesky(L [][]float64) float64 {
+	sum := 0.0
+	for i := range L {
+		sum += math.Log(L[i][i])
+	}
+	return 2 * sum
+}
@@ -0,0 +1,77 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	A := [][]float64{
+		{4, 12, -16},
+		{12, 37, -43},
+		{-16, -43, 98},
+	}
+	want := [][]float64{
+		{2, 0, 0},
+		{6, 1, 0},
+		{-8, 5, 3},
+	}
+	L, err := Cholesky(A)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(L[i][j]-want[i][j]) > 1e-12 {
+				t.Fatalf("L = %v; want %v", L, want)
+			}
+		}
+	}
+	if got := LogDetCholesky(L); math.Abs(got-math.Log(36)) > 1e-12 {
+		t.Errorf("LogDetCholesky = %v; want log 36", got)
+	}
+
+	b := []float64{1, 2, 3}
+	x := CholeskySolve(L, b)
+	Ax := MatVecMul(A, x)
+	for i := range b {
+		if math.Abs(Ax[i]-b[i]) > 1e-10 {
+			t.Errorf("A·x = %v; want %v", Ax, b)
+		}
+	}
+}
+
+func TestCholeskyNotPositiveDefinite(t *testing.T) {
+	for _, A := range [][][]float64{
+		{{1, 2}, {2, 4}},
+		{{1, 2}, {2, 1}},
+		{{1, 2, 3}, {4, 5, 6}},
+	} {
+		if _, err := Cholesky(A); err == nil {
+			t.Errorf("Cholesky(%v) should fail", A)
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	A := [][]float64{{4, 7}, {2, 6}}
+	inv, err := Inverse(A)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	I := MatMul(A, inv)
+	for i := range I {
+		for j := range I[i] {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(I[i][j]-want) > 1e-12 {
+				t.Errorf("A·A⁻¹ = %v", I)
+			}
+		}
+	}
+	if _, err := Inverse([][]float64{{1, 2}, {2, 4}}); err == nil {
+		t.Error("expected error for singular matrix")
+	}
+}
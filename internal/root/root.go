@@ -0,0 +1,51 @@
+// Package root holds the bracketing root-finding steps shared by the
+// specfunc and probability packages.
+package root
+
+import "math"
+
+// Bisect returns the midpoint of the bracket (lo, hi). Wide brackets with
+// a positive lower end are split geometrically and an open upper end is
+// expanded, so very small or very large roots are reached in few steps.
+func Bisect(lo, hi float64) float64 {
+	switch {
+	case math.IsInf(hi, 1):
+		return 2*lo + 1
+	case lo == 0:
+		return hi / 16
+	case hi > 4*lo:
+		return math.Sqrt(lo * hi)
+	}
+	return 0.5 * (lo + hi)
+}
+
+// SolvePositive finds the root on (0, ∞) of a monotone function f, which
+// returns its value and derivative. Newton steps are taken from x0 and
+// replaced by Bisect whenever they leave the bracket.
+func SolvePositive(f func(x float64) (float64, float64), increasing bool, x0 float64) float64 {
+	lo, hi := 0.0, math.Inf(1)
+	x := x0
+	for i := 0; i < 200; i++ {
+		v, d := f(x)
+		if v == 0 {
+			return x
+		}
+		if (v < 0) == increasing {
+			lo = x
+		} else {
+			hi = x
+		}
+		next := x - v/d
+		if math.Abs(next-x) <= 1e-14*x {
+			return next
+		}
+		if !(next > lo && next < hi) {
+			next = Bisect(lo, hi)
+		}
+		x = next
+		if !math.IsInf(hi, 1) && hi-lo <= 1e-15*hi {
+			break
+		}
+	}
+	return x
+}
@@ -0,0 +1,35 @@
+package root
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBisect(t *testing.T) {
+	tests := []struct {
+		lo, hi, want float64
+	}{
+		{3, math.Inf(1), 7},
+		{0, 32, 2},
+		{1, 100, 10},
+		{2, 4, 3},
+	}
+	for _, tt := range tests {
+		if got := Bisect(tt.lo, tt.hi); math.Abs(got-tt.want) > 1e-15*tt.want {
+			t.Errorf("Bisect(%v, %v) = %v; want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestSolvePositive(t *testing.T) {
+	// x² - 2 from far above, and 1/x - 1e-6 from far below, where Newton
+	// steps overshoot and the bracket takes over
+	sqrt2 := SolvePositive(func(x float64) (float64, float64) { return x*x - 2, 2 * x }, true, 1e6)
+	if math.Abs(sqrt2-math.Sqrt2) > 1e-14 {
+		t.Errorf("root of x² - 2 = %v; want %v", sqrt2, math.Sqrt2)
+	}
+	big := SolvePositive(func(x float64) (float64, float64) { return 1/x - 1e-6, -1 / (x * x) }, false, 1)
+	if math.Abs(big-1e6) > 1e-8 {
+		t.Errorf("root of 1/x - 1e-6 = %v; want 1e6", big)
+	}
+}
@@ -0,0 +1,11 @@
+package montecarlo
+
+import "github.com/cyber-mountain-man/statistical-go/internal/errs"
+
+// Sentinel errors, the same values in every package; see internal/errs.
+var (
+	ErrEmptyInput       = errs.ErrEmptyInput
+	ErrLengthMismatch   = errs.ErrLengthMismatch
+	ErrInvalidParameter = errs.ErrInvalidParameter
+	ErrSingularMatrix   = errs.ErrSingularMatrix
+)
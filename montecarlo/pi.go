@@ -3,6 +3,8 @@ package montecarlo
 import (
 	"math/rand"
 	"time"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
 )
 
 func EstimatePi(iterations int) float64 {
@@ -17,6 +19,19 @@ func EstimatePi(iterations int) float64 {
 	return 4.0 * float64(inside) / float64(iterations)
 }
 
+// EstimatePiRand is EstimatePi driven by a caller-provided source, so a
+// seeded rng gives a reproducible estimate.
+func EstimatePiRand(iterations int, rng *rand.Rand) float64 {
+	inside := 0
+	for i := 0; i < iterations; i++ {
+		x, y := rng.Float64(), rng.Float64()
+		if x*x+y*y <= 1 {
+			inside++
+		}
+	}
+	return 4.0 * float64(inside) / float64(iterations)
+}
+
 func EstimatePiParallel(total int, workers int) float64 {
 	if workers <= 0 {
 		return EstimatePi(total)
@@ -45,3 +60,25 @@ func EstimatePiParallel(total int, workers int) float64 {
 
 	return 4.0 * float64(totalInside) / float64(total)
 }
+
+// EstimatePiE is EstimatePi, returning an error matching
+// ErrInvalidParameter instead of NaN when iterations < 1.
+func EstimatePiE(iterations int) (float64, error) {
+	if iterations < 1 {
+		return 0, errs.New(ErrInvalidParameter, "EstimatePi: iterations must be ≥ 1")
+	}
+	return EstimatePi(iterations), nil
+}
+
+// EstimatePiParallelE is EstimatePiParallel, returning an error matching
+// ErrInvalidParameter when total < 1 or when total is smaller than the
+// number of workers, which would leave every worker without samples.
+func EstimatePiParallelE(total int, workers int) (float64, error) {
+	if total < 1 {
+		return 0, errs.New(ErrInvalidParameter, "EstimatePiParallel: total must be ≥ 1")
+	}
+	if total < workers {
+		return 0, errs.New(ErrInvalidParameter, "EstimatePiParallel: total must be at least the number of workers")
+	}
+	return EstimatePiParallel(total, workers), nil
+}
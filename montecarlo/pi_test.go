@@ -1,7 +1,9 @@
 package montecarlo
 
 import (
+	"errors"
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -33,3 +35,26 @@ func TestEstimatePiParallel(t *testing.T) {
 		}
 	}
 }
+
+func TestEstimatePiRandReproducible(t *testing.T) {
+	a := EstimatePiRand(100000, rand.New(rand.NewSource(7)))
+	b := EstimatePiRand(100000, rand.New(rand.NewSource(7)))
+	if a != b {
+		t.Errorf("same seed gave %v and %v", a, b)
+	}
+	if math.Abs(a-math.Pi) > 0.05 {
+		t.Errorf("EstimatePiRand() = %.4f; want close to 3.1416", a)
+	}
+}
+
+func TestEstimatePiErrors(t *testing.T) {
+	if _, err := EstimatePiE(0); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("EstimatePiE(0) err = %v", err)
+	}
+	if _, err := EstimatePiParallelE(3, 4); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("EstimatePiParallelE(3, 4) err = %v", err)
+	}
+	if got, err := EstimatePiParallelE(40000, 4); err != nil || math.Abs(got-math.Pi) > 0.1 {
+		t.Errorf("EstimatePiParallelE(40000, 4) = %v, %v", got, err)
+	}
+}
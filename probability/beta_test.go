@@ -0,0 +1,52 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBetaClosedForms(t *testing.T) {
+	d := Beta{Alpha: 2, Beta: 5}
+	arcsine := Beta{Alpha: 0.5, Beta: 0.5}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(0.3)", d.PDF(0.3), 30 * 0.3 * math.Pow(0.7, 4)},
+		// I_x(2, 5) = P(Binomial(6, x) ≥ 2)
+		{"CDF(0.3)", d.CDF(0.3), 1 - math.Pow(0.7, 6) - 6*0.3*math.Pow(0.7, 5)},
+		{"Survival(0.3)", d.Survival(0.3), math.Pow(0.7, 6) + 6*0.3*math.Pow(0.7, 5)},
+		{"Mean", d.Mean(), 2.0 / 7},
+		{"Variance", d.Variance(), 10.0 / 392},
+		{"arcsine CDF(0.1)", arcsine.CDF(0.1), 2 / math.Pi * math.Asin(math.Sqrt(0.1))},
+		{"arcsine Quantile(0.2)", arcsine.Quantile(0.2), math.Pow(math.Sin(math.Pi*0.1), 2)},
+		{"arcsine Entropy", arcsine.Entropy(), math.Log(math.Pi / 4)},
+		{"uniform PDF", Beta{Alpha: 1, Beta: 1}.PDF(0), 1},
+		{"uniform Entropy", Beta{Alpha: 1, Beta: 1}.Entropy(), 0},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-12 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if !math.IsInf(arcsine.PDF(0), 1) || !math.IsInf(arcsine.PDF(1), 1) || d.PDF(0) != 0 || d.PDF(1.5) != 0 {
+		t.Error("unexpected density at or beyond the support boundary")
+	}
+	if d.CDF(-1) != 0 || d.CDF(2) != 1 || d.Quantile(0) != 0 || d.Quantile(1) != 1 {
+		t.Error("unexpected CDF or Quantile at the support boundary")
+	}
+	if !math.IsNaN(Beta{Alpha: 0, Beta: 1}.PDF(0.5)) || !math.IsNaN(d.Quantile(-0.1)) {
+		t.Error("expected NaN for invalid input")
+	}
+}
+
+func TestBetaRandSmallShapes(t *testing.T) {
+	// with tiny shapes both gamma variates underflow, which must not produce NaN
+	d := Beta{Alpha: 0.01, Beta: 0.01}
+	for i, x := range Sample(d, 2000, nil) {
+		if math.IsNaN(x) || x < 0 || x > 1 {
+			t.Fatalf("draw %d = %v", i, x)
+		}
+	}
+}
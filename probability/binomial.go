@@ -2,8 +2,295 @@ package probability
 
 import (
 	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
 )
 
+// Binomial is the distribution of the number of successes in N independent
+// trials with success probability P. N must be ≥ 0 and P in [0, 1];
+// methods return NaN otherwise.
+type Binomial struct {
+	N int
+	P float64
+}
+
+func (b Binomial) valid() bool {
+	return b.N >= 0 && b.P >= 0 && b.P <= 1
+}
+
+// PMF returns P(X = k); it is 0 for k outside [0, N].
+func (b Binomial) PMF(k int) float64 {
+	return math.Exp(b.LogPMF(k))
+}
+
+// LogPMF returns log P(X = k). It is evaluated in the saddle-point form of
+// Loader (2000) rather than as LogChoose + k·log P + (N-k)·log(1-P), whose
+// terms cancel and would lose about log10(N) digits for large N.
+func (b Binomial) LogPMF(k int) float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	if k < 0 || k > b.N {
+		return math.Inf(-1)
+	}
+	return binomialLogPMF(float64(k), float64(b.N), b.P, 1-b.P)
+}
+
+// binomialLogPMF returns log P(X = k) for X ~ Binomial(n, p) with q = 1 - p
+// given separately, for real 0 ≤ k ≤ n.
+func binomialLogPMF(k, n, p, q float64) float64 {
+	switch {
+	case p == 0:
+		return zeroOrNegInf(k == 0)
+	case q == 0:
+		return zeroOrNegInf(k == n)
+	case k == 0:
+		return n * logOneMinus(p, q)
+	case k == n:
+		return n * logOneMinus(q, p)
+	}
+	// C(n, k)·p^(k+1)·q^(n-k+1)·(n+1) = p^a·q^b / B(a, b) with a = k+1, b = n-k+1
+	return specfunc.LogBetaPrefix(k+1, n-k+1, p, q) - math.Log(p) - math.Log(q) - math.Log(n+1)
+}
+
+// logOneMinus returns log(1 - p) given both p and q = 1 - p, choosing the
+// form that does not round.
+func logOneMinus(p, q float64) float64 {
+	if p < 0.5 {
+		return math.Log1p(-p)
+	}
+	return math.Log(q)
+}
+
+// zeroOrNegInf returns log 1 when ok and log 0 otherwise.
+func zeroOrNegInf(ok bool) float64 {
+	if ok {
+		return 0
+	}
+	return math.Inf(-1)
+}
+
+// CDF returns P(X ≤ x) = I_(1-P)(N-k, k+1) with k = ⌊x⌋. The incomplete beta
+// function keeps the cost independent of N.
+func (b Binomial) CDF(x float64) float64 {
+	if !b.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < 0:
+		return 0
+	case x >= float64(b.N):
+		return 1
+	}
+	k := math.Floor(x)
+	return specfunc.BetaIncPair(float64(b.N)-k, k+1, 1-b.P, b.P)
+}
+
+// Survival returns P(X > x) = I_P(k+1, N-k) with k = ⌊x⌋.
+func (b Binomial) Survival(x float64) float64 {
+	if !b.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < 0:
+		return 1
+	case x >= float64(b.N):
+		return 0
+	}
+	k := math.Floor(x)
+	return specfunc.BetaIncPair(k+1, float64(b.N)-k, b.P, 1-b.P)
+}
+
+// Quantile returns the smallest k such that P(X ≤ k) ≥ p.
+func (b Binomial) Quantile(p float64) float64 {
+	if !b.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return float64(discreteQuantile(p, 0, b.N, func(k int) float64 { return b.CDF(float64(k)) }))
+}
+
+// Mean returns N·P.
+func (b Binomial) Mean() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	return float64(b.N) * b.P
+}
+
+// Variance returns N·P·(1-P).
+func (b Binomial) Variance() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	return float64(b.N) * b.P * (1 - b.P)
+}
+
+// Entropy returns -Σ P(X=k)·log P(X=k), summed over the part of the
+// support where the probabilities are not negligible.
+func (b Binomial) Entropy() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	mean, width := b.Mean(), 40*math.Sqrt(b.Variance())+40
+	from := int(math.Max(0, mean-width))
+	to := int(math.Min(float64(b.N), mean+width))
+	h := 0.0
+	for k := from; k <= to; k++ {
+		if lp := b.LogPMF(k); !math.IsInf(lp, -1) {
+			h -= math.Exp(lp) * lp
+		}
+	}
+	return h
+}
+
+// Rand draws a random variate. Small means use sequential inversion and
+// large ones the BTPE algorithm of Kachitvichyanukul & Schmeiser (1988).
+// A nil rng uses the global math/rand source.
+func (b Binomial) Rand(rng *rand.Rand) float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	r := math.Min(b.P, 1-b.P)
+	var k int
+	switch {
+	case r == 0:
+		k = 0
+	case float64(b.N)*r < 30:
+		k = binomialInversion(b.N, r, rng)
+	default:
+		k = binomialBTPE(b.N, r, rng)
+	}
+	if b.P > 0.5 {
+		k = b.N - k
+	}
+	return float64(k)
+}
+
+// binomialInversion draws from Binomial(n, p), p ≤ 0.5, by walking the PMF
+// recurrence from zero.
+func binomialInversion(n int, p float64, rng *rand.Rand) int {
+	q := 1 - p
+	p0 := math.Exp(float64(n) * math.Log1p(-p))
+	bound := math.Min(float64(n), float64(n)*p+10*math.Sqrt(float64(n)*p*q+1))
+	for {
+		k, pk := 0, p0
+		u := uniformOpen(rng)
+		for u > pk {
+			u -= pk
+			k++
+			if float64(k) > bound {
+				break
+			}
+			pk *= float64(n-k+1) * p / (float64(k) * q)
+		}
+		if float64(k) <= bound {
+			return k
+		}
+	}
+}
+
+// binomialBTPE draws from Binomial(n, p), p ≤ 0.5 and n·p ≥ 30, using the
+// triangle/parallelogram/exponential acceptance-rejection scheme.
+func binomialBTPE(n int, p float64, rng *rand.Rand) int {
+	nf := float64(n)
+	q := 1 - p
+	nrq := nf * p * q
+	fm := nf*p + p
+	m := math.Floor(fm)
+	p1 := math.Floor(2.195*math.Sqrt(nrq)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl, xr := xm-p1, xm+p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*p)
+	lamL := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamR := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/lamL
+	p4 := p3 + c/lamR
+
+	for {
+		u := uniformOpen(rng) * p4
+		v := uniformOpen(rng)
+		var y float64
+		switch {
+		case u <= p1:
+			// triangular centre: accept immediately
+			return int(math.Floor(xm - p1*v + u))
+		case u <= p2:
+			// parallelograms
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			// left exponential tail
+			y = math.Floor(xl + math.Log(v)/lamL)
+			if y < 0 {
+				continue
+			}
+			v *= (u - p2) * lamL
+		default:
+			// right exponential tail
+			y = math.Floor(xr - math.Log(v)/lamR)
+			if y > nf {
+				continue
+			}
+			v *= (u - p3) * lamR
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			// evaluate f(y)/f(m) explicitly
+			s := p / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return int(y)
+			}
+			continue
+		}
+
+		// squeeze on log f(y)/f(m), then the Stirling-based bound
+		rho := (k / nrq) * ((k*(k/3+0.625)+1.0/6)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		alpha := math.Log(v)
+		if alpha < t-rho {
+			return int(y)
+		}
+		if alpha > t+rho {
+			continue
+		}
+		x1, f1 := y+1, m+1
+		z, w := nf+1-m, nf-y+1
+		bound := xm*math.Log(f1/x1) + (nf-m+0.5)*math.Log(z/w) +
+			(y-m)*math.Log(w*p/(x1*q)) +
+			stirlingTail(f1) + stirlingTail(z) + stirlingTail(x1) + stirlingTail(w)
+		if alpha <= bound {
+			return int(y)
+		}
+	}
+}
+
+// stirlingTail is the BTPE series approximation of the Stirling correction
+// 1/(12x) − 1/(360x³) + … used in the final acceptance test.
+func stirlingTail(x float64) float64 {
+	x2 := x * x
+	return (13680 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
+
 // BinomialPMF returns the probability of getting exactly k successes in n trials
 // with success probability p (P(X = k)).
 // Returns 0.0 for invalid inputs.
@@ -11,8 +298,17 @@ func BinomialPMF(n, k int, p float64) float64 {
 	if n < 0 || k < 0 || k > n || p < 0 || p > 1 {
 		return 0.0
 	}
-	coef := float64(binomialCoefficient(n, k))
-	return coef * math.Pow(p, float64(k)) * math.Pow(1-p, float64(n-k))
+	return Binomial{N: n, P: p}.PMF(k)
+}
+
+// LogBinomialPMF returns log P(X = k) for X ~ Binomial(n, p). It stays finite
+// where BinomialPMF underflows to 0, e.g. for n in the millions.
+// Returns -Inf for invalid inputs, matching BinomialPMF's 0.
+func LogBinomialPMF(n, k int, p float64) float64 {
+	if n < 0 || k < 0 || k > n || p < 0 || p > 1 {
+		return math.Inf(-1)
+	}
+	return Binomial{N: n, P: p}.LogPMF(k)
 }
 
 // BinomialCDF returns the cumulative probability of getting at most k successes (P(X ≤ k)).
@@ -21,11 +317,7 @@ func BinomialCDF(n, k int, p float64) float64 {
 	if n < 0 || k < 0 || k > n || p < 0 || p > 1 {
 		return 0.0
 	}
-	sum := 0.0
-	for i := 0; i <= k; i++ {
-		sum += BinomialPMF(n, i, p)
-	}
-	return sum
+	return Binomial{N: n, P: p}.CDF(float64(k))
 }
 
 // BinomialQuantile returns the smallest integer k such that P(X ≤ k) ≥ targetP
@@ -35,29 +327,7 @@ func BinomialCDF(n, k int, p float64) float64 {
 func BinomialQuantile(targetP float64, n int, p float64) int {
 	// ── invalid-input guard ───────────────────────────────────────────────
 	if targetP < 0 || targetP > 1 || n < 0 || p < 0 || p > 1 {
-		return n            // ← restore previous behaviour
-	}
-
-	cumulative := 0.0
-	for k := 0; k <= n; k++ {
-		cumulative += BinomialPMF(n, k, p)
-		if cumulative >= targetP {
-			return k
-		}
-	}
-	return n                // ← fallback remains ‘n’, not 0
-}
-
-
-// binomialCoefficient calculates "n choose k" using an iterative approach.
-func binomialCoefficient(n, k int) float64 {
-	if k > n-k {
-		k = n - k
-	}
-	result := 1
-	for i := 0; i < k; i++ {
-		result *= (n - i)
-		result /= (i + 1)
+		return n // ← restore previous behaviour
 	}
-	return float64(result)
+	return int(Binomial{N: n, P: p}.Quantile(targetP))
 }
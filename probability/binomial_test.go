@@ -51,6 +51,47 @@ func TestBinomialCDF(t *testing.T) {
 	}
 }
 
+func TestBinomialLargeN(t *testing.T) {
+	// references computed with 50-digit arithmetic
+	tests := []struct {
+		n, k int
+		want float64
+	}{
+		{10000000, 3000000, 0.50015600124588318},
+		{10000000, 2997000, 0.019226039439443702},
+	}
+	for _, tt := range tests {
+		if got := BinomialCDF(tt.n, tt.k, 0.3); math.Abs(got-tt.want) > 1e-10*tt.want {
+			t.Errorf("BinomialCDF(%d, %d, 0.3) = %.17g; want %.17g", tt.n, tt.k, got, tt.want)
+		}
+	}
+
+	const want = -871775.22056309199
+	if got := LogBinomialPMF(10000000, 5000000, 0.3); math.Abs(got-want) > 1e-14*math.Abs(want) {
+		t.Errorf("LogBinomialPMF = %.17g; want %.17g", got, want)
+	}
+	if got := BinomialPMF(10000000, 5000000, 0.3); got != 0 {
+		t.Errorf("BinomialPMF = %v; want underflow to 0", got)
+	}
+}
+
+func TestLogBinomialPMF(t *testing.T) {
+	if got, want := LogBinomialPMF(7, 3, 0.3), math.Log(BinomialPMF(7, 3, 0.3)); math.Abs(got-want) > 1e-14 {
+		t.Errorf("LogBinomialPMF(7, 3, 0.3) = %v; want %v", got, want)
+	}
+	if got := LogBinomialPMF(10, 0, 0); got != 0 {
+		t.Errorf("LogBinomialPMF(10, 0, 0) = %v; want 0", got)
+	}
+	for _, c := range []struct {
+		n, k int
+		p    float64
+	}{{10, 11, 0.5}, {10, -1, 0.5}, {10, 3, 1.5}, {10, 3, 0}} {
+		if got := LogBinomialPMF(c.n, c.k, c.p); !math.IsInf(got, -1) {
+			t.Errorf("LogBinomialPMF(%d, %d, %v) = %v; want -Inf", c.n, c.k, c.p, got)
+		}
+	}
+}
+
 func TestBinomialPMFInvalidInputs(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,96 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Cauchy is the Cauchy (Lorentz) distribution with location X0 and scale
+// Gamma. Gamma must be > 0; methods return NaN otherwise. The mean and
+// variance are undefined, so Mean and Variance always return NaN.
+type Cauchy struct {
+	X0, Gamma float64
+}
+
+func (c Cauchy) valid() bool {
+	return c.Gamma > 0 && !math.IsInf(c.Gamma, 1) && !math.IsNaN(c.X0) && !math.IsInf(c.X0, 0)
+}
+
+// PDF returns the probability density at x.
+func (c Cauchy) PDF(x float64) float64 {
+	if !c.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	z := (x - c.X0) / c.Gamma
+	return 1 / (math.Pi * c.Gamma * (1 + z*z))
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (c Cauchy) LogPDF(x float64) float64 {
+	if !c.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	z := (x - c.X0) / c.Gamma
+	return -math.Log(math.Pi*c.Gamma) - math.Log1p(z*z)
+}
+
+// CDF returns P(X ≤ x). The lower tail is computed as atan(-1/z)/π to avoid
+// cancellation in 1/2 + atan(z)/π.
+func (c Cauchy) CDF(x float64) float64 {
+	if !c.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	z := (x - c.X0) / c.Gamma
+	if z < 0 {
+		return math.Atan(-1/z) / math.Pi
+	}
+	return 0.5 + math.Atan(z)/math.Pi
+}
+
+// Survival returns P(X > x).
+func (c Cauchy) Survival(x float64) float64 {
+	if !c.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return c.CDF(2*c.X0 - x)
+}
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+// Quantile(0) is -Inf and Quantile(1) is +Inf.
+func (c Cauchy) Quantile(p float64) float64 {
+	if !c.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	switch {
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	case p < 0.5:
+		return c.X0 - c.Gamma/math.Tan(math.Pi*p)
+	}
+	return c.X0 + c.Gamma/math.Tan(math.Pi*(1-p))
+}
+
+// Mean is undefined for the Cauchy distribution and returns NaN.
+func (c Cauchy) Mean() float64 { return math.NaN() }
+
+// Variance is undefined for the Cauchy distribution and returns NaN.
+func (c Cauchy) Variance() float64 { return math.NaN() }
+
+// Entropy returns log(4π·Gamma).
+func (c Cauchy) Entropy() float64 {
+	if !c.valid() {
+		return math.NaN()
+	}
+	return math.Log(4 * math.Pi * c.Gamma)
+}
+
+// Rand draws a random variate by inverse-transform sampling.
+// A nil rng uses the global math/rand source.
+func (c Cauchy) Rand(rng *rand.Rand) float64 {
+	if !c.valid() {
+		return math.NaN()
+	}
+	return c.Quantile(uniformOpen(rng))
+}
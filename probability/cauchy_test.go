@@ -0,0 +1,46 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCauchyClosedForms(t *testing.T) {
+	d := Cauchy{X0: 0, Gamma: 1}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(0)", d.PDF(0), 1 / math.Pi},
+		{"PDF(1)", d.PDF(1), 0.5 / math.Pi},
+		{"CDF(1)", d.CDF(1), 0.75},
+		{"CDF(-1)", d.CDF(-1), 0.25},
+		{"Survival(1)", d.Survival(1), 0.25},
+		{"Quantile(0.75)", d.Quantile(0.75), 1},
+		{"Quantile(0.25)", d.Quantile(0.25), -1},
+		{"shifted CDF", Cauchy{X0: 3, Gamma: 2}.CDF(5), 0.75},
+		{"Entropy", d.Entropy(), math.Log(4 * math.Pi)},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// the far tail behaves like 1/(π|x|) without cancellation
+	if got, want := d.CDF(-1e10), 1/(math.Pi*1e10); math.Abs(got/want-1) > 1e-12 {
+		t.Errorf("CDF(-1e10) = %v; want %v", got, want)
+	}
+	if got := d.Quantile(1 / (math.Pi * 1e10)); math.Abs(got/-1e10-1) > 1e-9 {
+		t.Errorf("Quantile of the far tail = %v; want -1e10", got)
+	}
+	if !math.IsNaN(d.Mean()) || !math.IsNaN(d.Variance()) {
+		t.Error("Cauchy mean and variance are undefined")
+	}
+	if !math.IsInf(d.Quantile(0), -1) || !math.IsInf(d.Quantile(1), 1) {
+		t.Error("Quantile(0) and Quantile(1) should be ∓Inf")
+	}
+	if !math.IsNaN(Cauchy{X0: 0, Gamma: 0}.PDF(1)) {
+		t.Error("expected NaN for invalid scale")
+	}
+}
@@ -0,0 +1,299 @@
+package probability
+
+import (
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// The functions in this file are error-returning variants of the
+// package-level rules and distribution helpers. The plain functions panic
+// (the rules in basic.go through log.Panic) or return 0 on invalid
+// arguments; each variant below returns an error matching
+// ErrInvalidParameter instead. Outside the support, where the plain
+// function may panic, a variant returns the true probability (0 for a PMF
+// or a CDF below the support) rather than an error.
+
+// isProb reports whether every p lies in [0, 1].
+func isProb(ps ...float64) bool {
+	for _, p := range ps {
+		if !(p >= 0 && p <= 1) {
+			return false
+		}
+	}
+	return true
+}
+
+func invalid(msg string) error { return errs.New(ErrInvalidParameter, msg) }
+
+// AdditionRuleE is AdditionRule, returning an error instead of panicking.
+func AdditionRuleE(probA, probB, probAandB float64) (float64, error) {
+	if !isProb(probA, probB, probAandB) {
+		return 0, invalid("AdditionRule: all probabilities must be between 0 and 1")
+	}
+	return probA + probB - probAandB, nil
+}
+
+// MultiplicationIndependentE is MultiplicationIndependent, returning an
+// error instead of panicking.
+func MultiplicationIndependentE(probA, probB float64) (float64, error) {
+	if !isProb(probA, probB) {
+		return 0, invalid("MultiplicationIndependent: probabilities must be between 0 and 1")
+	}
+	return probA * probB, nil
+}
+
+// MultiplicationDependentE is MultiplicationDependent, returning an error
+// instead of panicking.
+func MultiplicationDependentE(probA, probBgivenA float64) (float64, error) {
+	if !isProb(probA, probBgivenA) {
+		return 0, invalid("MultiplicationDependent: probabilities must be between 0 and 1")
+	}
+	return probA * probBgivenA, nil
+}
+
+// ComplementRuleE is ComplementRule, returning an error instead of
+// panicking.
+func ComplementRuleE(probA float64) (float64, error) {
+	if !isProb(probA) {
+		return 0, invalid("ComplementRule: probability must be between 0 and 1")
+	}
+	return 1 - probA, nil
+}
+
+// IntersectionE is Intersection, returning an error instead of panicking.
+func IntersectionE(probA, probB float64) (float64, error) {
+	if !isProb(probA, probB) {
+		return 0, invalid("Intersection: probabilities must be between 0 and 1")
+	}
+	return probA * probB, nil
+}
+
+// UnionE is Union, returning an error instead of panicking.
+func UnionE(probA, probB, overlap float64) (float64, error) {
+	if !isProb(probA, probB, overlap) {
+		return 0, invalid("Union: all probabilities must be between 0 and 1")
+	}
+	return probA + probB - overlap, nil
+}
+
+// ConditionalE is Conditional, returning an error instead of panicking.
+func ConditionalE(probAandB, probB float64) (float64, error) {
+	if !(probB > 0 && probB <= 1) {
+		return 0, invalid("Conditional: P(B) must be > 0 and ≤ 1")
+	}
+	if !isProb(probAandB) {
+		return 0, invalid("Conditional: P(A ∩ B) must be between 0 and 1")
+	}
+	return probAandB / probB, nil
+}
+
+// checkNormal reports an invalid mean or standard deviation.
+func checkNormal(name string, mean, stddev float64) error {
+	if !(Normal{Mu: mean, Sigma: stddev}).valid() {
+		return invalid(name + ": standard deviation must be positive and finite, and the mean not NaN")
+	}
+	return nil
+}
+
+// NormalPDFE is NormalPDF, returning an error for an invalid mean or
+// standard deviation instead of NaN.
+func NormalPDFE(x, mean, stdDev float64) (float64, error) {
+	if err := checkNormal("NormalPDF", mean, stdDev); err != nil {
+		return 0, err
+	}
+	return Normal{Mu: mean, Sigma: stdDev}.PDF(x), nil
+}
+
+// NormalCDFE is NormalCDF, returning an error for an invalid mean or
+// standard deviation instead of NaN.
+func NormalCDFE(x, mean, stdDev float64) (float64, error) {
+	if err := checkNormal("NormalCDF", mean, stdDev); err != nil {
+		return 0, err
+	}
+	return Normal{Mu: mean, Sigma: stdDev}.CDF(x), nil
+}
+
+// NormalInverseCDFE is NormalInverseCDF, returning an error instead of
+// panicking.
+func NormalInverseCDFE(p, mean, stddev float64) (float64, error) {
+	if err := checkNormal("NormalInverseCDF", mean, stddev); err != nil {
+		return 0, err
+	}
+	if !isProb(p) {
+		return 0, invalid("NormalInverseCDF: p must be in [0,1]")
+	}
+	return Normal{Mu: mean, Sigma: stddev}.Quantile(p), nil
+}
+
+// NormalInverseCDFLogE is NormalInverseCDFLog, returning an error instead
+// of panicking.
+func NormalInverseCDFLogE(logp, mean, stddev float64) (float64, error) {
+	if err := checkNormal("NormalInverseCDFLog", mean, stddev); err != nil {
+		return 0, err
+	}
+	if !(logp <= 0) {
+		return 0, invalid("NormalInverseCDFLog: logp must be ≤ 0")
+	}
+	return Normal{Mu: mean, Sigma: stddev}.QuantileLog(logp), nil
+}
+
+// checkBinomial reports an invalid number of trials or success probability.
+func checkBinomial(name string, n int, p float64) error {
+	if n < 0 || !isProb(p) {
+		return invalid(name + ": n must be ≥ 0 and p in [0,1]")
+	}
+	return nil
+}
+
+// BinomialPMFE is BinomialPMF, returning an error for invalid n or p
+// instead of 0. A k outside [0, n] has probability 0 and is not an error.
+func BinomialPMFE(n, k int, p float64) (float64, error) {
+	if err := checkBinomial("BinomialPMF", n, p); err != nil {
+		return 0, err
+	}
+	return Binomial{N: n, P: p}.PMF(k), nil
+}
+
+// LogBinomialPMFE is LogBinomialPMF, returning an error for invalid n or p
+// instead of -Inf.
+func LogBinomialPMFE(n, k int, p float64) (float64, error) {
+	if err := checkBinomial("LogBinomialPMF", n, p); err != nil {
+		return math.Inf(-1), err
+	}
+	return Binomial{N: n, P: p}.LogPMF(k), nil
+}
+
+// BinomialCDFE is BinomialCDF, returning an error for invalid n or p
+// instead of 0. It returns 0 for k < 0 and 1 for k ≥ n.
+func BinomialCDFE(n, k int, p float64) (float64, error) {
+	if err := checkBinomial("BinomialCDF", n, p); err != nil {
+		return 0, err
+	}
+	return Binomial{N: n, P: p}.CDF(float64(k)), nil
+}
+
+// BinomialQuantileE is BinomialQuantile, returning an error for invalid
+// inputs instead of n.
+func BinomialQuantileE(targetP float64, n int, p float64) (int, error) {
+	if err := checkBinomial("BinomialQuantile", n, p); err != nil {
+		return 0, err
+	}
+	if !isProb(targetP) {
+		return 0, invalid("BinomialQuantile: targetP must be in [0,1]")
+	}
+	return int(Binomial{N: n, P: p}.Quantile(targetP)), nil
+}
+
+// checkRate reports a rate or mean that is not positive and finite.
+func checkRate(name string, lambda float64) error {
+	if !(lambda > 0) || math.IsInf(lambda, 1) {
+		return invalid(name + ": lambda must be > 0")
+	}
+	return nil
+}
+
+// PoissonPMFE is PoissonPMF, returning an error instead of panicking for
+// λ ≤ 0. A negative k has probability 0 and is not an error.
+func PoissonPMFE(k int, lambda float64) (float64, error) {
+	if err := checkRate("PoissonPMF", lambda); err != nil {
+		return 0, err
+	}
+	return Poisson{Lambda: lambda}.PMF(k), nil
+}
+
+// LogPoissonPMFE is LogPoissonPMF, returning an error instead of panicking
+// for λ ≤ 0. A negative k gives -Inf.
+func LogPoissonPMFE(k int, lambda float64) (float64, error) {
+	if err := checkRate("LogPoissonPMF", lambda); err != nil {
+		return math.Inf(-1), err
+	}
+	return Poisson{Lambda: lambda}.LogPMF(k), nil
+}
+
+// PoissonCDFE is PoissonCDF, returning an error instead of panicking for
+// λ ≤ 0. A negative k gives 0.
+func PoissonCDFE(k int, lambda float64) (float64, error) {
+	if err := checkRate("PoissonCDF", lambda); err != nil {
+		return 0, err
+	}
+	return Poisson{Lambda: lambda}.CDF(float64(k)), nil
+}
+
+// PoissonQuantileE is PoissonQuantile, returning an error instead of
+// panicking.
+func PoissonQuantileE(p, lambda float64) (int, error) {
+	if err := checkRate("PoissonQuantile", lambda); err != nil {
+		return 0, err
+	}
+	if !isProb(p) {
+		return 0, invalid("PoissonQuantile: p must be in [0,1]")
+	}
+	return PoissonQuantile(p, lambda), nil
+}
+
+// ExponentialPDFE is ExponentialPDF, returning an error instead of
+// panicking for λ ≤ 0. A negative x has density 0 and is not an error.
+func ExponentialPDFE(x, lambda float64) (float64, error) {
+	if err := checkRate("ExponentialPDF", lambda); err != nil {
+		return 0, err
+	}
+	return Exponential{Lambda: lambda}.PDF(x), nil
+}
+
+// ExponentialCDFE is ExponentialCDF, returning an error instead of
+// panicking for λ ≤ 0. A negative x gives 0.
+func ExponentialCDFE(x, lambda float64) (float64, error) {
+	if err := checkRate("ExponentialCDF", lambda); err != nil {
+		return 0, err
+	}
+	return Exponential{Lambda: lambda}.CDF(x), nil
+}
+
+// ExponentialQuantileE is ExponentialQuantile, returning an error instead
+// of panicking.
+func ExponentialQuantileE(p, lambda float64) (float64, error) {
+	if err := checkRate("ExponentialQuantile", lambda); err != nil {
+		return 0, err
+	}
+	if !isProb(p) {
+		return 0, invalid("ExponentialQuantile: p must be in [0,1]")
+	}
+	return Exponential{Lambda: lambda}.Quantile(p), nil
+}
+
+// checkUniform reports bounds that are not finite with a < b.
+func checkUniform(name string, a, b float64) error {
+	if !(Uniform{A: a, B: b}).valid() {
+		return invalid(name + ": a must be less than b and both finite")
+	}
+	return nil
+}
+
+// UniformPDFE is UniformPDF, returning an error instead of panicking.
+func UniformPDFE(x, a, b float64) (float64, error) {
+	if err := checkUniform("UniformPDF", a, b); err != nil {
+		return 0, err
+	}
+	return Uniform{A: a, B: b}.PDF(x), nil
+}
+
+// UniformCDFE is UniformCDF, returning an error instead of panicking.
+func UniformCDFE(x, a, b float64) (float64, error) {
+	if err := checkUniform("UniformCDF", a, b); err != nil {
+		return 0, err
+	}
+	return Uniform{A: a, B: b}.CDF(x), nil
+}
+
+// UniformQuantileE is UniformQuantile, returning an error instead of
+// panicking.
+func UniformQuantileE(p, a, b float64) (float64, error) {
+	if err := checkUniform("UniformQuantile", a, b); err != nil {
+		return 0, err
+	}
+	if !isProb(p) {
+		return 0, invalid("UniformQuantile: p must be in [0,1]")
+	}
+	return Uniform{A: a, B: b}.Quantile(p), nil
+}
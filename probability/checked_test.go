@@ -0,0 +1,119 @@
+package probability
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCheckedVariantsMatchPlain(t *testing.T) {
+	tests := []struct {
+		name  string
+		got   func() (float64, error)
+		plain float64
+	}{
+		{"AdditionRule", func() (float64, error) { return AdditionRuleE(0.5, 0.4, 0.2) }, AdditionRule(0.5, 0.4, 0.2)},
+		{"MultiplicationIndependent", func() (float64, error) { return MultiplicationIndependentE(0.5, 0.4) }, MultiplicationIndependent(0.5, 0.4)},
+		{"MultiplicationDependent", func() (float64, error) { return MultiplicationDependentE(0.5, 0.4) }, MultiplicationDependent(0.5, 0.4)},
+		{"ComplementRule", func() (float64, error) { return ComplementRuleE(0.3) }, ComplementRule(0.3)},
+		{"Intersection", func() (float64, error) { return IntersectionE(0.5, 0.4) }, Intersection(0.5, 0.4)},
+		{"Union", func() (float64, error) { return UnionE(0.5, 0.4, 0.1) }, Union(0.5, 0.4, 0.1)},
+		{"Conditional", func() (float64, error) { return ConditionalE(0.2, 0.5) }, Conditional(0.2, 0.5)},
+		{"NormalPDF", func() (float64, error) { return NormalPDFE(1, 0, 2) }, NormalPDF(1, 0, 2)},
+		{"NormalCDF", func() (float64, error) { return NormalCDFE(1, 0, 2) }, NormalCDF(1, 0, 2)},
+		{"NormalInverseCDF", func() (float64, error) { return NormalInverseCDFE(0.9, 0, 2) }, NormalInverseCDF(0.9, 0, 2)},
+		{"NormalInverseCDFLog", func() (float64, error) { return NormalInverseCDFLogE(-50, 0, 2) }, NormalInverseCDFLog(-50, 0, 2)},
+		{"BinomialPMF", func() (float64, error) { return BinomialPMFE(10, 3, 0.4) }, BinomialPMF(10, 3, 0.4)},
+		{"LogBinomialPMF", func() (float64, error) { return LogBinomialPMFE(10, 3, 0.4) }, LogBinomialPMF(10, 3, 0.4)},
+		{"BinomialCDF", func() (float64, error) { return BinomialCDFE(10, 3, 0.4) }, BinomialCDF(10, 3, 0.4)},
+		{"PoissonPMF", func() (float64, error) { return PoissonPMFE(3, 2.5) }, PoissonPMF(3, 2.5)},
+		{"LogPoissonPMF", func() (float64, error) { return LogPoissonPMFE(3, 2.5) }, LogPoissonPMF(3, 2.5)},
+		{"PoissonCDF", func() (float64, error) { return PoissonCDFE(3, 2.5) }, PoissonCDF(3, 2.5)},
+		{"ExponentialPDF", func() (float64, error) { return ExponentialPDFE(1, 1.5) }, ExponentialPDF(1, 1.5)},
+		{"ExponentialCDF", func() (float64, error) { return ExponentialCDFE(1, 1.5) }, ExponentialCDF(1, 1.5)},
+		{"ExponentialQuantile", func() (float64, error) { return ExponentialQuantileE(0.3, 1.5) }, ExponentialQuantile(0.3, 1.5)},
+		{"UniformPDF", func() (float64, error) { return UniformPDFE(1, 0, 4) }, UniformPDF(1, 0, 4)},
+		{"UniformCDF", func() (float64, error) { return UniformCDFE(1, 0, 4) }, UniformCDF(1, 0, 4)},
+		{"UniformQuantile", func() (float64, error) { return UniformQuantileE(0.3, 0, 4) }, UniformQuantile(0.3, 0, 4)},
+	}
+	for _, tt := range tests {
+		if got, err := tt.got(); err != nil || got != tt.plain {
+			t.Errorf("%sE = %v, %v; want %v, nil", tt.name, got, err, tt.plain)
+		}
+	}
+	if k, err := BinomialQuantileE(0.5, 5, 0.5); err != nil || k != BinomialQuantile(0.5, 5, 0.5) {
+		t.Errorf("BinomialQuantileE = %v, %v", k, err)
+	}
+	if k, err := PoissonQuantileE(0.5, 3); err != nil || k != PoissonQuantile(0.5, 3) {
+		t.Errorf("PoissonQuantileE = %v, %v", k, err)
+	}
+	// outside the support is a zero probability, not an error
+	if p, err := PoissonPMFE(-1, 2); err != nil || p != 0 {
+		t.Errorf("PoissonPMFE(-1, 2) = %v, %v; want 0, nil", p, err)
+	}
+	if p, err := ExponentialCDFE(-1, 2); err != nil || p != 0 {
+		t.Errorf("ExponentialCDFE(-1, 2) = %v, %v; want 0, nil", p, err)
+	}
+	if p, err := BinomialCDFE(5, 7, 0.3); err != nil || p != 1 {
+		t.Errorf("BinomialCDFE(5, 7, 0.3) = %v, %v; want 1, nil", p, err)
+	}
+}
+
+func TestCheckedVariantsReportErrors(t *testing.T) {
+	errOf := func(_ float64, err error) error { return err }
+	errOfInt := func(_ int, err error) error { return err }
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"AdditionRule", errOf(AdditionRuleE(1.2, 0.4, 0.2))},
+		{"MultiplicationIndependent", errOf(MultiplicationIndependentE(-0.1, 0.4))},
+		{"MultiplicationDependent", errOf(MultiplicationDependentE(0.5, 2))},
+		{"ComplementRule", errOf(ComplementRuleE(math.NaN()))},
+		{"Intersection", errOf(IntersectionE(0.5, 1.4))},
+		{"Union", errOf(UnionE(0.5, 0.4, -1))},
+		{"Conditional P(B)", errOf(ConditionalE(0.2, 0))},
+		{"Conditional P(A∩B)", errOf(ConditionalE(1.2, 0.5))},
+		{"NormalPDF", errOf(NormalPDFE(0, 0, 0))},
+		{"NormalCDF", errOf(NormalCDFE(0, math.NaN(), 1))},
+		{"NormalInverseCDF", errOf(NormalInverseCDFE(1.5, 0, 1))},
+		{"NormalInverseCDFLog", errOf(NormalInverseCDFLogE(0.5, 0, 1))},
+		{"BinomialPMF", errOf(BinomialPMFE(-1, 0, 0.5))},
+		{"LogBinomialPMF", errOf(LogBinomialPMFE(5, 2, 1.5))},
+		{"BinomialCDF", errOf(BinomialCDFE(5, 2, -0.5))},
+		{"BinomialQuantile", errOfInt(BinomialQuantileE(1.1, 5, 0.5))},
+		{"PoissonPMF", errOf(PoissonPMFE(1, 0))},
+		{"LogPoissonPMF", errOf(LogPoissonPMFE(1, -2))},
+		{"PoissonCDF", errOf(PoissonCDFE(1, math.Inf(1)))},
+		{"PoissonQuantile", errOfInt(PoissonQuantileE(-0.5, 2))},
+		{"ExponentialPDF", errOf(ExponentialPDFE(1, 0))},
+		{"ExponentialCDF", errOf(ExponentialCDFE(1, -1))},
+		{"ExponentialQuantile", errOf(ExponentialQuantileE(2, 1))},
+		{"UniformPDF", errOf(UniformPDFE(1, 2, 2))},
+		{"UniformCDF", errOf(UniformCDFE(1, 3, 2))},
+		{"UniformQuantile", errOf(UniformQuantileE(2, 0, 1))},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, ErrInvalidParameter) {
+			t.Errorf("%sE: err = %v; want ErrInvalidParameter", tt.name, tt.err)
+		}
+	}
+}
+
+func TestConstructorErrorsMatchSentinels(t *testing.T) {
+	if _, err := NewMultivariateNormal(nil, nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("empty mean: err = %v", err)
+	}
+	if _, err := NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 0}}); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("wrong covariance shape: err = %v", err)
+	}
+	if _, err := NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 2}, {2, 1}}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("indefinite covariance: err = %v", err)
+	}
+	if _, err := Fit(nil, FamilyNormal); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Fit(nil): err = %v", err)
+	}
+	if _, err := NewWishart(1, [][]float64{{1, 0}, {0, 1}}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Wishart with too few degrees of freedom: err = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package probability
+
+import "math/rand"
+
+// ChiSquared is the chi-square distribution with K degrees of freedom,
+// i.e. Gamma{Alpha: K/2, Beta: 1/2}. K must be > 0; methods return NaN
+// otherwise.
+type ChiSquared struct {
+	K float64
+}
+
+func (c ChiSquared) gamma() Gamma {
+	return Gamma{Alpha: c.K / 2, Beta: 0.5}
+}
+
+// PDF returns the probability density at x.
+func (c ChiSquared) PDF(x float64) float64 { return c.gamma().PDF(x) }
+
+// LogPDF returns the logarithm of the density at x.
+func (c ChiSquared) LogPDF(x float64) float64 { return c.gamma().LogPDF(x) }
+
+// CDF returns P(X ≤ x).
+func (c ChiSquared) CDF(x float64) float64 { return c.gamma().CDF(x) }
+
+// Survival returns the upper-tail probability P(X > x), the p-value of a
+// chi-square test statistic x. It remains accurate down to about 1e-300.
+func (c ChiSquared) Survival(x float64) float64 { return c.gamma().Survival(x) }
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+func (c ChiSquared) Quantile(p float64) float64 { return c.gamma().Quantile(p) }
+
+// Mean returns K.
+func (c ChiSquared) Mean() float64 { return c.gamma().Mean() }
+
+// Variance returns 2K.
+func (c ChiSquared) Variance() float64 { return c.gamma().Variance() }
+
+// Entropy returns the differential entropy.
+func (c ChiSquared) Entropy() float64 { return c.gamma().Entropy() }
+
+// Rand draws a random variate. A nil rng uses the global math/rand source.
+func (c ChiSquared) Rand(rng *rand.Rand) float64 { return c.gamma().Rand(rng) }
@@ -0,0 +1,81 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+// Critical values χ²_p(k) from published chi-square tables.
+func TestChiSquaredQuantileTable(t *testing.T) {
+	tests := []struct {
+		k, p, expected float64
+	}{
+		{1, 0.95, 3.841},
+		{2, 0.95, 5.991},
+		{5, 0.95, 11.070},
+		{10, 0.95, 18.307},
+		{10, 0.99, 23.209},
+		{10, 0.05, 3.940},
+		{30, 0.95, 43.773},
+		{100, 0.95, 124.342},
+		{100, 0.01, 70.065},
+	}
+	for _, tt := range tests {
+		c := ChiSquared{K: tt.k}
+		if got := c.Quantile(tt.p); math.Abs(got-tt.expected) > 1e-3 {
+			t.Errorf("ChiSquared{%v}.Quantile(%v) = %.4f; want %.3f", tt.k, tt.p, got, tt.expected)
+		}
+	}
+}
+
+// evenChiSquareSurvival is the closed form Q for even k:
+// e^(-x/2)·Σ_{j<k/2} (x/2)^j / j!.
+func evenChiSquareSurvival(k int, x float64) float64 {
+	sum, term := 0.0, 1.0
+	for j := 0; j < k/2; j++ {
+		if j > 0 {
+			term *= x / 2 / float64(j)
+		}
+		sum += term
+	}
+	return math.Exp(-x/2) * sum
+}
+
+func TestChiSquaredSurvivalClosedForm(t *testing.T) {
+	for _, k := range []int{2, 6, 10, 30, 100, 1000} {
+		c := ChiSquared{K: float64(k)}
+		for _, x := range []float64{1, float64(k), 1.05 * float64(k), 2 * float64(k), 1380} {
+			want := evenChiSquareSurvival(k, x)
+			got := c.Survival(x)
+			if math.Abs(got-want) > 1e-12*want {
+				t.Errorf("ChiSquared{%d}.Survival(%v) = %g; want %g", k, x, got, want)
+			}
+			if sum := c.CDF(x) + got; math.Abs(sum-1) > 1e-14 {
+				t.Errorf("ChiSquared{%d}: CDF+Survival = %v at x=%v", k, sum, x)
+			}
+		}
+	}
+}
+
+func TestChiSquaredExtremeTail(t *testing.T) {
+	// Q(1, 690) = e^-690 ≈ 2.2e-300 for k = 2.
+	got := ChiSquared{K: 2}.Survival(1380)
+	want := math.Exp(-690)
+	if got <= 0 || math.Abs(got-want) > 1e-12*want {
+		t.Errorf("Survival(1380) = %g; want %g", got, want)
+	}
+	// high degrees of freedom, far in the tail
+	if got := (ChiSquared{K: 500}).Survival(2000); !(got > 0 && got < 1e-150) {
+		t.Errorf("ChiSquared{500}.Survival(2000) = %g; want a tiny positive value", got)
+	}
+}
+
+func TestChiSquaredMoments(t *testing.T) {
+	c := ChiSquared{K: 7}
+	if c.Mean() != 7 || c.Variance() != 14 {
+		t.Errorf("Mean/Variance = %v/%v; want 7/14", c.Mean(), c.Variance())
+	}
+	if !math.IsNaN(ChiSquared{K: 0}.CDF(1)) {
+		t.Error("K = 0 should give NaN")
+	}
+}
@@ -0,0 +1,40 @@
+package probability
+
+import "math"
+
+// LogFactorial returns log(n!) computed from the log-gamma function, so it
+// stays finite long after n! itself overflows (n > 170).
+// Returns NaN for n < 0.
+func LogFactorial(n int) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+	if n < 2 {
+		return 0
+	}
+	lf, _ := math.Lgamma(float64(n) + 1)
+	return lf
+}
+
+// LogChoose returns log C(n, k), the logarithm of the binomial coefficient.
+// It is -Inf when k < 0 or k > n (C(n, k) = 0) and NaN for n < 0.
+func LogChoose(n, k int) float64 {
+	switch {
+	case n < 0:
+		return math.NaN()
+	case k < 0 || k > n:
+		return math.Inf(-1)
+	case k == 0 || k == n:
+		return 0
+	}
+	return lchoose(float64(n), float64(k))
+}
+
+// lchoose returns log C(n, k) for real n ≥ k ≥ 0 via the log-gamma function,
+// so large arguments do not overflow.
+func lchoose(n, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
@@ -0,0 +1,53 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogFactorial(t *testing.T) {
+	tests := []struct {
+		n    int
+		want float64
+	}{
+		{0, 0},
+		{1, 0},
+		{5, math.Log(120)},
+		{20, math.Log(2432902008176640000)},
+		{1000000, 12815518.384658170}, // far beyond where n! overflows
+	}
+	for _, tt := range tests {
+		if got := LogFactorial(tt.n); math.Abs(got-tt.want) > 1e-14*math.Max(1, tt.want) {
+			t.Errorf("LogFactorial(%d) = %.17g; want %.17g", tt.n, got, tt.want)
+		}
+	}
+	if got := LogFactorial(-1); !math.IsNaN(got) {
+		t.Errorf("LogFactorial(-1) = %v; want NaN", got)
+	}
+}
+
+func TestLogChoose(t *testing.T) {
+	tests := []struct {
+		n, k int
+		want float64
+	}{
+		{10, 0, 0},
+		{10, 10, 0},
+		{10, 3, math.Log(120)},
+		{52, 5, math.Log(2598960)},
+		{1000000, 500000, 693140.04701306368},
+	}
+	for _, tt := range tests {
+		if got := LogChoose(tt.n, tt.k); math.Abs(got-tt.want) > 1e-14*math.Max(1, tt.want) {
+			t.Errorf("LogChoose(%d, %d) = %.17g; want %.17g", tt.n, tt.k, got, tt.want)
+		}
+	}
+	for _, k := range []int{-1, 11} {
+		if got := LogChoose(10, k); !math.IsInf(got, -1) {
+			t.Errorf("LogChoose(10, %d) = %v; want -Inf", k, got)
+		}
+	}
+	if got := LogChoose(-1, 0); !math.IsNaN(got) {
+		t.Errorf("LogChoose(-1, 0) = %v; want NaN", got)
+	}
+}
@@ -0,0 +1,174 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
+)
+
+// Dirichlet is the distribution on the probability simplex
+// {x : xᵢ ≥ 0, Σxᵢ = 1} with concentration parameters Alpha, all > 0,
+// of length at least 2. It is the conjugate prior of the Multinomial
+// category probabilities; see Posterior.
+//
+// Dirichlet is multivariate, so it does not implement Continuous; Marginal
+// returns the Beta distribution of a single component.
+type Dirichlet struct {
+	Alpha []float64
+}
+
+func (d Dirichlet) valid() bool {
+	if len(d.Alpha) < 2 {
+		return false
+	}
+	for _, a := range d.Alpha {
+		if !(a > 0) || math.IsInf(a, 1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d Dirichlet) sum() float64 {
+	s := 0.0
+	for _, a := range d.Alpha {
+		s += a
+	}
+	return s
+}
+
+// logNorm returns log B(α) = Σ log Γ(αᵢ) - log Γ(Σαᵢ).
+func (d Dirichlet) logNorm() float64 {
+	lb := 0.0
+	for _, a := range d.Alpha {
+		lg, _ := math.Lgamma(a)
+		lb += lg
+	}
+	lg, _ := math.Lgamma(d.sum())
+	return lb - lg
+}
+
+// LogPDF returns log f(x) = Σ(αᵢ-1)·log xᵢ - log B(α). It is -Inf off the
+// simplex (components negative or not summing to 1 within 1e-9), and NaN
+// for invalid parameters or a length mismatch.
+func (d Dirichlet) LogPDF(x []float64) float64 {
+	if !d.valid() || len(x) != len(d.Alpha) {
+		return math.NaN()
+	}
+	total, lp := 0.0, -d.logNorm()
+	for i, xi := range x {
+		if !(xi >= 0) {
+			return math.Inf(-1)
+		}
+		total += xi
+		if d.Alpha[i] != 1 {
+			lp += (d.Alpha[i] - 1) * math.Log(xi)
+		}
+	}
+	if math.Abs(total-1) > 1e-9 {
+		return math.Inf(-1)
+	}
+	return lp
+}
+
+// PDF returns the density at x, evaluated in log space.
+func (d Dirichlet) PDF(x []float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+// Marginal returns the Beta(αᵢ, Σα - αᵢ) distribution of component i.
+func (d Dirichlet) Marginal(i int) Beta {
+	if !d.valid() || i < 0 || i >= len(d.Alpha) {
+		return Beta{Alpha: math.NaN(), Beta: math.NaN()}
+	}
+	return Beta{Alpha: d.Alpha[i], Beta: d.sum() - d.Alpha[i]}
+}
+
+// Mean returns αᵢ / Σα, or nil for invalid parameters.
+func (d Dirichlet) Mean() []float64 {
+	if !d.valid() {
+		return nil
+	}
+	a0 := d.sum()
+	mean := make([]float64, len(d.Alpha))
+	for i, a := range d.Alpha {
+		mean[i] = a / a0
+	}
+	return mean
+}
+
+// Covariance returns the matrix (δᵢⱼ·mᵢ - mᵢmⱼ) / (Σα + 1) with m the mean,
+// or nil for invalid parameters.
+func (d Dirichlet) Covariance() [][]float64 {
+	mean := d.Mean()
+	if mean == nil {
+		return nil
+	}
+	a0 := d.sum()
+	cov := make([][]float64, len(mean))
+	for i := range cov {
+		cov[i] = make([]float64, len(mean))
+		for j := range cov[i] {
+			cov[i][j] = -mean[i] * mean[j] / (a0 + 1)
+		}
+		cov[i][i] += mean[i] / (a0 + 1)
+	}
+	return cov
+}
+
+// Entropy returns log B(α) + (α₀ - K)·ψ(α₀) - Σ(αᵢ - 1)·ψ(αᵢ) in nats,
+// where α₀ = Σα and K is the number of components.
+func (d Dirichlet) Entropy() float64 {
+	if !d.valid() {
+		return math.NaN()
+	}
+	a0 := d.sum()
+	h := d.logNorm() + (a0-float64(len(d.Alpha)))*specfunc.Digamma(a0)
+	for _, a := range d.Alpha {
+		h -= (a - 1) * specfunc.Digamma(a)
+	}
+	return h
+}
+
+// Posterior returns the Dirichlet(α + counts) posterior after observing
+// multinomial category counts. Returns a Dirichlet with nil Alpha (invalid)
+// when the counts are negative or their length differs from Alpha.
+func (d Dirichlet) Posterior(counts []int) Dirichlet {
+	if !d.valid() || len(counts) != len(d.Alpha) {
+		return Dirichlet{}
+	}
+	alpha := make([]float64, len(d.Alpha))
+	for i, c := range counts {
+		if c < 0 {
+			return Dirichlet{}
+		}
+		alpha[i] = d.Alpha[i] + float64(c)
+	}
+	return Dirichlet{Alpha: alpha}
+}
+
+// Rand draws a point on the simplex by normalising independent
+// Gamma(αᵢ, 1) variates, which are generated in log space so that small
+// concentrations do not underflow to an all-zero draw. A nil rng uses the
+// global math/rand source. Returns nil for invalid parameters.
+func (d Dirichlet) Rand(rng *rand.Rand) []float64 {
+	if !d.valid() {
+		return nil
+	}
+	x := make([]float64, len(d.Alpha))
+	maxLog := math.Inf(-1)
+	for i, a := range d.Alpha {
+		x[i] = logStandardGammaRand(a, rng)
+		maxLog = math.Max(maxLog, x[i])
+	}
+	sum := 0.0
+	for i := range x {
+		x[i] = math.Exp(x[i] - maxLog)
+		sum += x[i]
+	}
+	for i := range x {
+		x[i] /= sum
+	}
+	return x
+}
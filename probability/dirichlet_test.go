@@ -0,0 +1,119 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDirichletTwoComponentsIsBeta(t *testing.T) {
+	d := Dirichlet{Alpha: []float64{2.5, 4}}
+	b := Beta{Alpha: 2.5, Beta: 4}
+	for _, x := range []float64{0.05, 0.3, 0.5, 0.9} {
+		if got, want := d.LogPDF([]float64{x, 1 - x}), b.LogPDF(x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("LogPDF(%v) = %v; want %v", x, got, want)
+		}
+	}
+	if got, want := d.Entropy(), b.Entropy(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Entropy() = %v; want %v", got, want)
+	}
+	if got := d.Marginal(1); got != (Beta{Alpha: 4, Beta: 2.5}) {
+		t.Errorf("Marginal(1) = %v", got)
+	}
+}
+
+func TestDirichletLogPDF(t *testing.T) {
+	// Dirichlet(1, 2, 3): B(α) = Γ(1)Γ(2)Γ(3)/Γ(6) = 2/120
+	d := Dirichlet{Alpha: []float64{1, 2, 3}}
+	x := []float64{0.2, 0.3, 0.5}
+	want := math.Log(60 * 0.3 * 0.5 * 0.5)
+	if got := d.LogPDF(x); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogPDF = %v; want %v", got, want)
+	}
+	if got := d.PDF(x); math.Abs(got-math.Exp(want)) > 1e-12 {
+		t.Errorf("PDF = %v; want %v", got, math.Exp(want))
+	}
+	// x₀ = 0 is in the support when α₀ = 1
+	if got := d.LogPDF([]float64{0, 0.5, 0.5}); math.IsInf(got, 0) || math.IsNaN(got) {
+		t.Errorf("LogPDF on the α=1 face = %v; want finite", got)
+	}
+	for _, x := range [][]float64{{0.2, 0.3, 0.6}, {-0.1, 0.6, 0.5}} {
+		if got := d.LogPDF(x); !math.IsInf(got, -1) {
+			t.Errorf("LogPDF(%v) = %v; want -Inf", x, got)
+		}
+	}
+	if got := d.LogPDF([]float64{0.5, 0.5}); !math.IsNaN(got) {
+		t.Errorf("LogPDF with length mismatch = %v; want NaN", got)
+	}
+}
+
+func TestDirichletPosterior(t *testing.T) {
+	prior := Dirichlet{Alpha: []float64{1, 1, 1}}
+	post := prior.Posterior([]int{3, 0, 7})
+	want := []float64{4, 1, 8}
+	for i := range want {
+		if post.Alpha[i] != want[i] {
+			t.Fatalf("Posterior = %v; want %v", post.Alpha, want)
+		}
+	}
+	if got := prior.Posterior([]int{1, -1, 0}); got.Alpha != nil {
+		t.Errorf("Posterior with negative count = %v; want invalid", got)
+	}
+	if got := prior.Posterior([]int{1, 2}); got.Alpha != nil {
+		t.Errorf("Posterior with length mismatch = %v; want invalid", got)
+	}
+}
+
+func TestDirichletRand(t *testing.T) {
+	for _, d := range []Dirichlet{
+		{Alpha: []float64{2, 3, 5}},
+		{Alpha: []float64{0.05, 0.1, 0.2}}, // draws concentrate on the vertices
+	} {
+		rng := rand.New(rand.NewSource(11))
+		const n = 100000
+		mean, cov := d.Mean(), d.Covariance()
+		sum := make([]float64, len(mean))
+		sq := make([]float64, len(mean))
+		for s := 0; s < n; s++ {
+			x := d.Rand(rng)
+			total := 0.0
+			for i, xi := range x {
+				if !(xi >= 0) {
+					t.Fatalf("Rand() = %v has a negative or NaN component", x)
+				}
+				total += xi
+				sum[i] += xi
+				sq[i] += xi * xi
+			}
+			if math.Abs(total-1) > 1e-12 {
+				t.Fatalf("Rand() = %v does not sum to 1", x)
+			}
+		}
+		for i := range mean {
+			m := sum[i] / n
+			v := sq[i]/n - m*m
+			if se := math.Sqrt(cov[i][i] / n); math.Abs(m-mean[i]) > 5*se {
+				t.Errorf("%v: sample mean[%d] = %v; want %v", d.Alpha, i, m, mean[i])
+			}
+			if math.Abs(v-cov[i][i]) > 0.03*cov[i][i] {
+				t.Errorf("%v: sample var[%d] = %v; want %v", d.Alpha, i, v, cov[i][i])
+			}
+		}
+	}
+}
+
+func TestDirichletInvalid(t *testing.T) {
+	for _, d := range []Dirichlet{
+		{},
+		{Alpha: []float64{1}},
+		{Alpha: []float64{1, 0}},
+		{Alpha: []float64{1, math.NaN()}},
+	} {
+		if d.Mean() != nil || d.Covariance() != nil || d.Rand(nil) != nil {
+			t.Errorf("%v: Mean/Covariance/Rand should be nil", d.Alpha)
+		}
+		if !math.IsNaN(d.Entropy()) || !math.IsNaN(d.LogPDF(make([]float64, len(d.Alpha)))) {
+			t.Errorf("%v: Entropy/LogPDF should be NaN", d.Alpha)
+		}
+	}
+}
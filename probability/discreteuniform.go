@@ -0,0 +1,114 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// DiscreteUniform is the uniform distribution on the integers A, A+1, …, B.
+// A must not exceed B; methods return NaN otherwise.
+type DiscreteUniform struct {
+	A, B int
+}
+
+func (d DiscreteUniform) valid() bool {
+	return d.A <= d.B
+}
+
+// count returns the number of support points as a float64.
+func (d DiscreteUniform) count() float64 {
+	return float64(d.B) - float64(d.A) + 1
+}
+
+// PMF returns 1 / (B - A + 1) for k in [A, B] and 0 otherwise.
+func (d DiscreteUniform) PMF(k int) float64 {
+	return math.Exp(d.LogPMF(k))
+}
+
+// LogPMF returns -log(B - A + 1) for k in [A, B] and -Inf otherwise.
+func (d DiscreteUniform) LogPMF(k int) float64 {
+	if !d.valid() {
+		return math.NaN()
+	}
+	if k < d.A || k > d.B {
+		return math.Inf(-1)
+	}
+	return -math.Log(d.count())
+}
+
+// CDF returns P(X ≤ x).
+func (d DiscreteUniform) CDF(x float64) float64 {
+	if !d.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < float64(d.A):
+		return 0
+	case x >= float64(d.B):
+		return 1
+	}
+	return (math.Floor(x) - float64(d.A) + 1) / d.count()
+}
+
+// Survival returns P(X > x).
+func (d DiscreteUniform) Survival(x float64) float64 {
+	if !d.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < float64(d.A):
+		return 1
+	case x >= float64(d.B):
+		return 0
+	}
+	return (float64(d.B) - math.Floor(x)) / d.count()
+}
+
+// Quantile returns the smallest k such that P(X ≤ k) ≥ p.
+func (d DiscreteUniform) Quantile(p float64) float64 {
+	if !d.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	k := math.Max(float64(d.A), float64(d.A)+math.Ceil(p*d.count())-1)
+	// correct for rounding in p·count
+	for k > float64(d.A) && d.CDF(k-1) >= p {
+		k--
+	}
+	for k < float64(d.B) && d.CDF(k) < p {
+		k++
+	}
+	return k
+}
+
+// Mean returns (A + B) / 2.
+func (d DiscreteUniform) Mean() float64 {
+	if !d.valid() {
+		return math.NaN()
+	}
+	return (float64(d.A) + float64(d.B)) / 2
+}
+
+// Variance returns ((B - A + 1)² - 1) / 12.
+func (d DiscreteUniform) Variance() float64 {
+	if !d.valid() {
+		return math.NaN()
+	}
+	n := d.count()
+	return (n*n - 1) / 12
+}
+
+// Entropy returns log(B - A + 1).
+func (d DiscreteUniform) Entropy() float64 {
+	if !d.valid() {
+		return math.NaN()
+	}
+	return math.Log(d.count())
+}
+
+// Rand draws a random variate. A nil rng uses the global math/rand source.
+func (d DiscreteUniform) Rand(rng *rand.Rand) float64 {
+	if !d.valid() {
+		return math.NaN()
+	}
+	return float64(d.A) + float64(int63n(rng, int64(d.B)-int64(d.A)+1))
+}
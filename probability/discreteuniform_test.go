@@ -0,0 +1,38 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiscreteUniformDie(t *testing.T) {
+	die := DiscreteUniform{A: 1, B: 6}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PMF(3)", die.PMF(3), 1.0 / 6},
+		{"PMF(7)", die.PMF(7), 0},
+		{"CDF(3.5)", die.CDF(3.5), 0.5},
+		{"CDF(0)", die.CDF(0), 0},
+		{"Survival(4)", die.Survival(4), 1.0 / 3},
+		{"Quantile(0)", die.Quantile(0), 1},
+		{"Quantile(0.5)", die.Quantile(0.5), 3},
+		{"Quantile(0.51)", die.Quantile(0.51), 4},
+		{"Quantile(1)", die.Quantile(1), 6},
+		{"Mean", die.Mean(), 3.5},
+		{"Variance", die.Variance(), 35.0 / 12},
+		{"Entropy", die.Entropy(), math.Log(6)},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if got := (DiscreteUniform{A: -3, B: -3}).Rand(nil); got != -3 {
+		t.Errorf("single-point Rand() = %v", got)
+	}
+	if !math.IsNaN(DiscreteUniform{A: 2, B: 1}.PMF(1)) {
+		t.Error("expected NaN for A > B")
+	}
+}
@@ -0,0 +1,106 @@
+package probability
+
+import "math/rand"
+
+// Distribution is the behaviour shared by every univariate distribution in
+// this package, so generic code (goodness-of-fit, simulation, plotting) can
+// work with any of them. Discrete distributions evaluate CDF and Survival at
+// real x and return integer values from Quantile and Rand.
+//
+// Methods return NaN when the distribution's parameters are invalid.
+type Distribution interface {
+	// CDF returns P(X ≤ x).
+	CDF(x float64) float64
+	// Survival returns P(X > x), computed directly where that is more
+	// accurate than 1 - CDF(x).
+	Survival(x float64) float64
+	// Quantile returns the smallest x such that CDF(x) ≥ p.
+	Quantile(p float64) float64
+	Mean() float64
+	Variance() float64
+	// Entropy returns the (differential, for continuous distributions)
+	// entropy in nats.
+	Entropy() float64
+	// Rand draws a random variate from rng, or from the global math/rand
+	// source when rng is nil.
+	Rand(rng *rand.Rand) float64
+}
+
+// Continuous is a distribution with a probability density function.
+type Continuous interface {
+	Distribution
+	PDF(x float64) float64
+	// LogPDF returns log PDF(x), finite wherever the density is positive
+	// even if PDF(x) underflows.
+	LogPDF(x float64) float64
+}
+
+// Discrete is a distribution on the integers with a probability mass function.
+type Discrete interface {
+	Distribution
+	PMF(k int) float64
+	// LogPMF returns log PMF(k), finite wherever the mass is positive
+	// even if PMF(k) underflows.
+	LogPMF(k int) float64
+}
+
+var (
+	_ Continuous = Normal{}
+	_ Continuous = Exponential{}
+	_ Continuous = Uniform{}
+	_ Continuous = StudentT{}
+	_ Continuous = FDist{}
+	_ Continuous = ChiSquared{}
+	_ Continuous = Gamma{}
+	_ Continuous = LogNormal{}
+	_ Continuous = Weibull{}
+	_ Continuous = Beta{}
+	_ Continuous = Cauchy{}
+	_ Continuous = Laplace{}
+	_ Continuous = Pareto{}
+	_ Continuous = Logistic{}
+	_ Continuous = Mixture{}
+	_ Continuous = truncatedContinuous{}
+	_ Continuous = locationScaleContinuous{}
+
+	_ Discrete = Binomial{}
+	_ Discrete = Poisson{}
+	_ Discrete = Geometric{}
+	_ Discrete = NegativeBinomial{}
+	_ Discrete = Hypergeometric{}
+	_ Discrete = DiscreteUniform{}
+	_ Discrete = truncatedDiscrete{}
+	_ Discrete = locationScaleDiscrete{}
+)
+
+// quantileFuzz relaxes the comparison P(X ≤ k) ≥ p in discrete quantile
+// searches by 64 ulps, so that a CDF accumulated with rounding error still
+// stops at k when P(X ≤ k) equals p exactly.
+const quantileFuzz = 1 - 64*2.220446049250313e-16
+
+// discreteQuantile returns the smallest integer k ≥ lo with cdf(k) ≥ p by
+// bisection. hi is an upper bound with cdf(hi) ≥ p; pass a negative hi for
+// unbounded support and it is found by doubling.
+func discreteQuantile(p float64, lo, hi int, cdf func(k int) float64) int {
+	p *= quantileFuzz
+	if cdf(lo) >= p {
+		return lo
+	}
+	if hi < 0 {
+		step := 1
+		for hi = lo + step; cdf(hi) < p; hi = lo + step {
+			lo = hi
+			step *= 2
+		}
+	}
+	// invariant: cdf(lo) < p ≤ cdf(hi)
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if cdf(mid) >= p {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
@@ -0,0 +1,172 @@
+package probability
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var continuousCases = []Continuous{
+	Normal{Mu: 1, Sigma: 2},
+	Exponential{Lambda: 0.5},
+	Uniform{A: -1, B: 3},
+	StudentT{Nu: 1},
+	StudentT{Nu: 7},
+	FDist{D1: 4, D2: 12},
+	ChiSquared{K: 3},
+	Gamma{Alpha: 2.5, Beta: 1.5},
+	LogNormal{Mu: 0.3, Sigma: 0.6},
+	Weibull{K: 0.8, Lambda: 2},
+	Weibull{K: 3.5, Lambda: 1},
+	Beta{Alpha: 2, Beta: 5},
+	Beta{Alpha: 0.5, Beta: 0.5},
+	Cauchy{X0: -1, Gamma: 0.5},
+	Laplace{Mu: 2, B: 1.5},
+	Pareto{Xm: 1, Alpha: 3},
+	Logistic{Mu: 1, S: 2},
+	Truncated(Normal{Mu: 0, Sigma: 1}, -1, 2).(Continuous),
+	Truncated(Normal{Mu: 0, Sigma: 1}, 9, 10).(Continuous), // Z ≈ 1e-19
+	Truncated(Gamma{Alpha: 2, Beta: 1}, 1, math.Inf(1)).(Continuous),
+	LocationScale(StudentT{Nu: 5}, 3, 2).(Continuous),
+	LocationScale(Exponential{Lambda: 1}, -1, 0.5).(Continuous),
+	Mixture{Weights: []float64{0.3, 0.7}, Components: []Continuous{Normal{Mu: -2, Sigma: 1}, Gamma{Alpha: 3, Beta: 2}}},
+}
+
+var discreteCases = []Discrete{
+	Binomial{N: 12, P: 0.3},
+	Poisson{Lambda: 4.5},
+	Geometric{P: 0.35},
+	NegativeBinomial{R: 2.5, P: 0.6},
+	Hypergeometric{Total: 50, Successes: 12, Draws: 15},
+	Hypergeometric{Total: 20, Successes: 15, Draws: 12}, // support starts at 7
+	DiscreteUniform{A: 3, B: 11},
+	Truncated(Poisson{Lambda: 4.5}, 2, 9).(Discrete),
+	Truncated(Geometric{P: 0.35}, 2.5, math.Inf(1)).(Discrete),
+	LocationScale(Binomial{N: 12, P: 0.3}, 5, 1).(Discrete),
+}
+
+// integrateOverQuantiles approximates ∫₀¹ g(Quantile(u)) du with the midpoint rule.
+func integrateOverQuantiles(d Distribution, g func(x float64) float64) float64 {
+	const n = 20000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += g(d.Quantile((float64(i) + 0.5) / n))
+	}
+	return sum / n
+}
+
+func TestContinuousInterface(t *testing.T) {
+	for _, d := range continuousCases {
+		t.Run(fmt.Sprintf("%T%v", d, d), func(t *testing.T) {
+			for _, p := range []float64{0.001, 0.1, 0.5, 0.77, 0.999} {
+				x := d.Quantile(p)
+				if got := d.CDF(x); math.Abs(got-p) > 1e-9 {
+					t.Errorf("CDF(Quantile(%v)) = %v", p, got)
+				}
+				if got := d.CDF(x) + d.Survival(x); math.Abs(got-1) > 1e-12 {
+					t.Errorf("CDF + Survival = %v at x=%v", got, x)
+				}
+				// the density is the derivative of the CDF
+				// with a step that stays inside the support
+				h := 1e-5 * math.Max(1e-3, math.Abs(x))
+				h = math.Min(h, math.Min(x-d.Quantile(0), d.Quantile(1)-x)/1000)
+				deriv := (d.CDF(x+h) - d.CDF(x-h)) / (2 * h)
+				if pdf := d.PDF(x); math.Abs(deriv-pdf) > 1e-5*math.Max(1, pdf) {
+					t.Errorf("PDF(%v) = %v; numerical derivative %v", x, pdf, deriv)
+				}
+				if got, want := d.LogPDF(x), math.Log(d.PDF(x)); math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+					t.Errorf("LogPDF(%v) = %v; want %v", x, got, want)
+				}
+			}
+
+			entropy := integrateOverQuantiles(d, func(x float64) float64 { return -math.Log(d.PDF(x)) })
+			if math.Abs(entropy-d.Entropy()) > 5e-3 {
+				t.Errorf("Entropy() = %v; numerical %v", d.Entropy(), entropy)
+			}
+			if m := d.Mean(); !math.IsNaN(m) {
+				if got := integrateOverQuantiles(d, func(x float64) float64 { return x }); math.Abs(got-m) > 1e-2 {
+					t.Errorf("Mean() = %v; numerical %v", m, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscreteInterface(t *testing.T) {
+	for _, d := range discreteCases {
+		t.Run(fmt.Sprintf("%T%v", d, d), func(t *testing.T) {
+			cum, mean, second, entropy := 0.0, 0.0, 0.0, 0.0
+			for k := 0; k <= 200; k++ {
+				pk := d.PMF(k)
+				if got, want := d.LogPMF(k), math.Log(pk); !(got == want || math.Abs(got-want) <= 1e-12*math.Max(1, math.Abs(want))) {
+					t.Errorf("LogPMF(%d) = %v; want %v", k, got, want)
+				}
+				cum += pk
+				mean += float64(k) * pk
+				second += float64(k*k) * pk
+				if pk > 0 {
+					entropy -= pk * math.Log(pk)
+				}
+				if got := d.CDF(float64(k)); math.Abs(got-cum) > 1e-12 {
+					t.Errorf("CDF(%d) = %v; want %v", k, got, cum)
+				}
+				// CDF is a right-continuous step function
+				if got := d.CDF(float64(k) + 0.5); math.Abs(got-cum) > 1e-12 {
+					t.Errorf("CDF(%v) = %v; want %v", float64(k)+0.5, got, cum)
+				}
+				if got := d.Survival(float64(k)); math.Abs(got-(1-cum)) > 1e-12 {
+					t.Errorf("Survival(%d) = %v; want %v", k, got, 1-cum)
+				}
+			}
+			if math.Abs(mean-d.Mean()) > 1e-9 || math.Abs(second-mean*mean-d.Variance()) > 1e-9 {
+				t.Errorf("Mean/Variance = %v/%v; want %v/%v", d.Mean(), d.Variance(), mean, second-mean*mean)
+			}
+			if math.Abs(entropy-d.Entropy()) > 1e-9 {
+				t.Errorf("Entropy() = %v; want %v", d.Entropy(), entropy)
+			}
+			for _, p := range []float64{0.01, 0.3, 0.5, 0.9} {
+				k := d.Quantile(p)
+				if d.CDF(k) < p || d.CDF(k-1) >= p {
+					t.Errorf("Quantile(%v) = %v is not the smallest k with CDF(k) ≥ p", p, k)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidParametersReturnNaN(t *testing.T) {
+	invalid := []Distribution{
+		Normal{Mu: 0, Sigma: 0},
+		Exponential{Lambda: -1},
+		Uniform{A: 2, B: 1},
+		Binomial{N: -1, P: 0.5},
+		Binomial{N: 3, P: 1.5},
+		Poisson{Lambda: 0},
+		Geometric{P: 0},
+		NegativeBinomial{R: 0, P: 0.5},
+		Hypergeometric{Total: 5, Successes: 6, Draws: 1},
+		DiscreteUniform{A: 2, B: 1},
+	}
+	for _, d := range invalid {
+		if !math.IsNaN(d.CDF(0.5)) || !math.IsNaN(d.Mean()) || !math.IsNaN(d.Entropy()) || !math.IsNaN(d.Quantile(0.5)) {
+			t.Errorf("%T%v should return NaN for invalid parameters", d, d)
+		}
+	}
+}
+
+func TestKnownEntropies(t *testing.T) {
+	tests := []struct {
+		d    Distribution
+		want float64
+	}{
+		{StudentT{Nu: 1}, math.Log(4 * math.Pi)},
+		{Gamma{Alpha: 1, Beta: 1}, 1},
+		{Normal{Mu: 0, Sigma: 1}, 0.5 * math.Log(2*math.Pi*math.E)},
+		{Uniform{A: 0, B: math.E}, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Entropy(); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%T%v.Entropy() = %v; want %v", tt.d, tt.d, got, tt.want)
+		}
+	}
+}
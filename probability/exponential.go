@@ -1,13 +1,110 @@
 package probability
 
-import "math"
+import (
+	"math"
+	"math/rand"
+)
+
+// Exponential is the exponential distribution with rate Lambda.
+// Lambda must be > 0; methods return NaN otherwise.
+type Exponential struct {
+	Lambda float64
+}
+
+func (e Exponential) valid() bool {
+	return e.Lambda > 0 && !math.IsInf(e.Lambda, 1)
+}
+
+// PDF returns the probability density at x; it is 0 for x < 0.
+func (e Exponential) PDF(x float64) float64 {
+	if !e.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	return e.Lambda * math.Exp(-e.Lambda*x)
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (e Exponential) LogPDF(x float64) float64 {
+	if !e.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(e.Lambda) - e.Lambda*x
+}
+
+// CDF returns P(X ≤ x).
+func (e Exponential) CDF(x float64) float64 {
+	if !e.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-e.Lambda * x)
+}
+
+// Survival returns P(X > x).
+func (e Exponential) Survival(x float64) float64 {
+	if !e.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	return math.Exp(-e.Lambda * x)
+}
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+func (e Exponential) Quantile(p float64) float64 {
+	if !e.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / e.Lambda
+}
+
+// Mean returns 1 / Lambda.
+func (e Exponential) Mean() float64 {
+	if !e.valid() {
+		return math.NaN()
+	}
+	return 1 / e.Lambda
+}
+
+// Variance returns 1 / Lambda².
+func (e Exponential) Variance() float64 {
+	if !e.valid() {
+		return math.NaN()
+	}
+	return 1 / (e.Lambda * e.Lambda)
+}
+
+// Entropy returns 1 - log(Lambda).
+func (e Exponential) Entropy() float64 {
+	if !e.valid() {
+		return math.NaN()
+	}
+	return 1 - math.Log(e.Lambda)
+}
+
+// Rand draws a random variate. A nil rng uses the global math/rand source.
+func (e Exponential) Rand(rng *rand.Rand) float64 {
+	if !e.valid() {
+		return math.NaN()
+	}
+	return expFloat(rng) / e.Lambda
+}
 
 // ExponentialPDF returns the probability density at x for a given rate λ.
 func ExponentialPDF(x, lambda float64) float64 {
 	if x < 0 || lambda <= 0 {
 		panic("ExponentialPDF: x must be >= 0 and lambda must be > 0")
 	}
-	return lambda * math.Exp(-lambda*x)
+	return Exponential{Lambda: lambda}.PDF(x)
 }
 
 // ExponentialCDF returns the cumulative probability for a given x and rate λ.
@@ -15,5 +112,14 @@ func ExponentialCDF(x, lambda float64) float64 {
 	if x < 0 || lambda <= 0 {
 		panic("ExponentialCDF: x must be >= 0 and lambda must be > 0")
 	}
-	return 1 - math.Exp(-lambda*x)
+	return Exponential{Lambda: lambda}.CDF(x)
+}
+
+// ExponentialQuantile returns the x such that P(X ≤ x) = p for a rate λ,
+// -log(1 - p)/λ. ExponentialQuantile(1, λ) is +Inf.
+func ExponentialQuantile(p, lambda float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 || lambda <= 0 {
+		panic("ExponentialQuantile: p must be in [0,1] and lambda must be > 0")
+	}
+	return Exponential{Lambda: lambda}.Quantile(p)
 }
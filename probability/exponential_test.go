@@ -78,3 +78,28 @@ func TestExponentialCDFPanicOnNegativeLambda(t *testing.T) {
 	}()
 	_ = ExponentialCDF(2, -0.5)
 }
+
+func TestExponentialQuantile(t *testing.T) {
+	for _, p := range []float64{0, 1e-12, 0.25, 0.5, 0.99} {
+		x := ExponentialQuantile(p, 0.5)
+		if got := ExponentialCDF(x, 0.5); math.Abs(got-p) > 1e-15 {
+			t.Errorf("ExponentialCDF(ExponentialQuantile(%v, 0.5)) = %v", p, got)
+		}
+	}
+	if got, want := ExponentialQuantile(0.5, 2), math.Ln2/2; math.Abs(got-want) > 1e-15 {
+		t.Errorf("ExponentialQuantile(0.5, 2) = %v; want %v", got, want)
+	}
+	if !math.IsInf(ExponentialQuantile(1, 2), 1) {
+		t.Error("ExponentialQuantile(1, 2) should be +Inf")
+	}
+	for _, in := range [][2]float64{{-0.1, 1}, {1.1, 1}, {0.5, 0}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for ExponentialQuantile(%v, %v)", in[0], in[1])
+				}
+			}()
+			_ = ExponentialQuantile(in[0], in[1])
+		}()
+	}
+}
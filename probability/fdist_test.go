@@ -0,0 +1,99 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Upper critical values F_{p}(d1, d2) from published F tables.
+func TestFDistQuantileTable(t *testing.T) {
+	tests := []struct {
+		d1, d2, p, expected float64
+	}{
+		{1, 1, 0.95, 161.448},
+		{1, 10, 0.95, 4.965},
+		{2, 6, 0.95, 5.143},
+		{3, 20, 0.95, 3.098},
+		{5, 10, 0.95, 3.326},
+		{10, 30, 0.95, 2.165},
+		{2, 10, 0.99, 7.559},
+		{4, 20, 0.99, 4.431},
+		{20, 120, 0.99, 2.035},
+	}
+	for _, tt := range tests {
+		d := FDist{D1: tt.d1, D2: tt.d2}
+		got := d.Quantile(tt.p)
+		if math.Abs(got-tt.expected) > 1e-3*tt.expected {
+			t.Errorf("FDist{%v,%v}.Quantile(%v) = %.4f; want %.3f", tt.d1, tt.d2, tt.p, got, tt.expected)
+		}
+		if back := d.CDF(got); math.Abs(back-tt.p) > 1e-10 {
+			t.Errorf("FDist{%v,%v}.CDF(Quantile(%v)) = %.12f", tt.d1, tt.d2, tt.p, back)
+		}
+	}
+}
+
+func TestFDistClosedForms(t *testing.T) {
+	// With d1 = 2 the survival function is (1 + 2x/d2)^(-d2/2).
+	for _, d2 := range []float64{1, 3, 6, 25} {
+		d := FDist{D1: 2, D2: d2}
+		for _, x := range []float64{0.1, 1, 5, 19, 400} {
+			want := math.Pow(1+2*x/d2, -d2/2)
+			if got := d.Survival(x); math.Abs(got-want) > 1e-12*math.Max(want, 1e-300)+1e-15 {
+				t.Errorf("FDist{2,%v}.Survival(%v) = %g; want %g", d2, x, got, want)
+			}
+			if got := d.CDF(x) + d.Survival(x); math.Abs(got-1) > 1e-14 {
+				t.Errorf("FDist{2,%v}: CDF+Survival = %v at x=%v", d2, got, x)
+			}
+			wantPDF := math.Pow(1+2*x/d2, -d2/2-1)
+			if got := d.PDF(x); math.Abs(got-wantPDF) > 1e-12 {
+				t.Errorf("FDist{2,%v}.PDF(%v) = %g; want %g", d2, x, got, wantPDF)
+			}
+		}
+	}
+
+	// F(1, ν) is the square of a Student's t with ν degrees of freedom.
+	tq := StudentT{Nu: 12}.Quantile(0.975)
+	if got := (FDist{D1: 1, D2: 12}).Quantile(0.95); math.Abs(got-tq*tq) > 1e-9 {
+		t.Errorf("FDist{1,12}.Quantile(0.95) = %v; want %v", got, tq*tq)
+	}
+}
+
+func TestFDistMoments(t *testing.T) {
+	d := FDist{D1: 5, D2: 10}
+	if got := d.Mean(); math.Abs(got-1.25) > 1e-12 {
+		t.Errorf("Mean() = %v; want 1.25", got)
+	}
+	want := 2 * 100 * 13 / (5 * 64 * 6.0)
+	if got := d.Variance(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Variance() = %v; want %v", got, want)
+	}
+	if got := (FDist{D1: 5, D2: 2}).Mean(); !math.IsNaN(got) {
+		t.Errorf("Mean() with D2 ≤ 2 = %v; want NaN", got)
+	}
+}
+
+func TestFDistInvalid(t *testing.T) {
+	for _, d := range []FDist{{0, 5}, {5, 0}, {-1, 3}, {math.NaN(), 3}} {
+		if !math.IsNaN(d.PDF(1)) || !math.IsNaN(d.CDF(1)) || !math.IsNaN(d.Survival(1)) || !math.IsNaN(d.Quantile(0.5)) {
+			t.Errorf("%+v should return NaN for invalid degrees of freedom", d)
+		}
+	}
+	d := FDist{D1: 3, D2: 7}
+	if d.CDF(-1) != 0 || d.Survival(-1) != 1 || d.PDF(-1) != 0 {
+		t.Error("negative x should have zero mass")
+	}
+}
+
+func TestFDistRand(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	d := FDist{D1: 6, D2: 20}
+	const n = 20000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += d.Rand(rng)
+	}
+	if mean := sum / n; math.Abs(mean-d.Mean()) > 0.03 {
+		t.Errorf("sample mean = %.4f; want ≈ %.4f", mean, d.Mean())
+	}
+}
@@ -0,0 +1,347 @@
+package probability
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+	"github.com/cyber-mountain-man/statistical-go/internal/root"
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
+)
+
+// Family identifies a parametric family that Fit can estimate from data.
+type Family int
+
+const (
+	FamilyNormal Family = iota
+	FamilyExponential
+	FamilyPoisson
+	FamilyBinomial // needs a known number of trials; see FitBinomial
+	FamilyGamma
+	FamilyLogNormal
+	FamilyWeibull
+)
+
+// String returns the family name.
+func (f Family) String() string {
+	switch f {
+	case FamilyNormal:
+		return "Normal"
+	case FamilyExponential:
+		return "Exponential"
+	case FamilyPoisson:
+		return "Poisson"
+	case FamilyBinomial:
+		return "Binomial"
+	case FamilyGamma:
+		return "Gamma"
+	case FamilyLogNormal:
+		return "LogNormal"
+	case FamilyWeibull:
+		return "Weibull"
+	}
+	return "Unknown"
+}
+
+// Param is one fitted parameter with its standard error, taken from the
+// inverse of the observed Fisher information at the estimate.
+type Param struct {
+	Name   string
+	Value  float64
+	StdErr float64
+}
+
+// FitResult holds a maximum-likelihood fit. Dist is the fitted distribution,
+// e.g. Gamma{Alpha, Beta}, and Params lists its parameters in field order.
+type FitResult struct {
+	Family Family
+	Dist   Distribution
+	Params []Param
+	LogLik float64
+	AIC    float64 // 2k - 2·LogLik
+	BIC    float64 // k·log(n) - 2·LogLik
+	N      int
+}
+
+// Fit returns the maximum-likelihood fit of family to data.
+// Poisson data must be non-negative integers; Exponential data must be
+// non-negative; Gamma, LogNormal and Weibull data must be strictly positive.
+// Scale-type families need at least two distinct values.
+func Fit(data []float64, family Family) (FitResult, error) {
+	if len(data) == 0 {
+		return FitResult{}, errs.New(ErrEmptyInput, "Fit: data is empty")
+	}
+	for _, x := range data {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return FitResult{}, errs.New(ErrInvalidParameter, "Fit: data must be finite")
+		}
+	}
+	switch family {
+	case FamilyNormal:
+		return fitNormal(data)
+	case FamilyExponential:
+		return fitExponential(data)
+	case FamilyPoisson:
+		return fitPoisson(data)
+	case FamilyBinomial:
+		return FitResult{}, errs.New(ErrInvalidParameter, "Fit: Binomial needs the number of trials; use FitBinomial")
+	case FamilyGamma:
+		return fitGamma(data)
+	case FamilyLogNormal:
+		return fitLogNormal(data)
+	case FamilyWeibull:
+		return fitWeibull(data)
+	}
+	return FitResult{}, errs.New(ErrInvalidParameter, "Fit: unknown family")
+}
+
+// FitBinomial fits the success probability of a binomial distribution with a
+// known number of trials to counts in [0, trials].
+func FitBinomial(data []float64, trials int) (FitResult, error) {
+	if len(data) == 0 {
+		return FitResult{}, errs.New(ErrEmptyInput, "FitBinomial: data is empty")
+	}
+	if trials <= 0 {
+		return FitResult{}, errs.New(ErrInvalidParameter, "FitBinomial: trials must be > 0")
+	}
+	sum := 0.0
+	for _, x := range data {
+		if x < 0 || x > float64(trials) || x != math.Floor(x) {
+			return FitResult{}, errs.New(ErrInvalidParameter, "FitBinomial: data must be integers in [0, trials]")
+		}
+		sum += x
+	}
+	n := float64(len(data))
+	p := sum / (n * float64(trials))
+	d := Binomial{N: trials, P: p}
+	ll := 0.0
+	for _, x := range data {
+		ll += math.Log(d.PMF(int(x)))
+	}
+	// observed information Σx/p² + Σ(N-x)/(1-p)² = nN / (p(1-p)) at the MLE
+	se := math.Sqrt(p * (1 - p) / (n * float64(trials)))
+	return newFitResult(FamilyBinomial, d, []Param{{"P", p, se}}, ll, len(data)), nil
+}
+
+// RankFits fits every family to data and returns the successful fits sorted
+// by AIC, best first. Families whose support excludes the data are skipped.
+func RankFits(data []float64, families ...Family) []FitResult {
+	var fits []FitResult
+	for _, f := range families {
+		if r, err := Fit(data, f); err == nil {
+			fits = append(fits, r)
+		}
+	}
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].AIC < fits[j].AIC })
+	return fits
+}
+
+func newFitResult(family Family, d Distribution, params []Param, ll float64, n int) FitResult {
+	k := float64(len(params))
+	return FitResult{
+		Family: family,
+		Dist:   d,
+		Params: params,
+		LogLik: ll,
+		AIC:    2*k - 2*ll,
+		BIC:    k*math.Log(float64(n)) - 2*ll,
+		N:      n,
+	}
+}
+
+// meanAndSD returns the mean and the maximum-likelihood (divide by n)
+// standard deviation of xs.
+func meanAndSD(xs []float64) (float64, float64) {
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(xs)))
+}
+
+func fitNormal(data []float64) (FitResult, error) {
+	mu, sigma := meanAndSD(data)
+	if sigma == 0 {
+		return FitResult{}, errs.New(ErrInvalidParameter, "Fit: data must contain at least two distinct values")
+	}
+	n := float64(len(data))
+	ll := -n / 2 * (math.Log(2*math.Pi*sigma*sigma) + 1)
+	return newFitResult(FamilyNormal, Normal{Mu: mu, Sigma: sigma}, []Param{
+		{"Mu", mu, sigma / math.Sqrt(n)},
+		{"Sigma", sigma, sigma / math.Sqrt(2*n)},
+	}, ll, len(data)), nil
+}
+
+func fitLogNormal(data []float64) (FitResult, error) {
+	logs := make([]float64, len(data))
+	sumLog := 0.0
+	for i, x := range data {
+		if x <= 0 {
+			return FitResult{}, errs.New(ErrInvalidParameter, "Fit: LogNormal data must be > 0")
+		}
+		logs[i] = math.Log(x)
+		sumLog += logs[i]
+	}
+	r, err := fitNormal(logs)
+	if err != nil {
+		return FitResult{}, err
+	}
+	mu, sigma := r.Params[0].Value, r.Params[1].Value
+	return newFitResult(FamilyLogNormal, LogNormal{Mu: mu, Sigma: sigma}, r.Params,
+		r.LogLik-sumLog, len(data)), nil
+}
+
+func fitExponential(data []float64) (FitResult, error) {
+	sum := 0.0
+	for _, x := range data {
+		if x < 0 {
+			return FitResult{}, errs.New(ErrInvalidParameter, "Fit: Exponential data must be >= 0")
+		}
+		sum += x
+	}
+	if sum == 0 {
+		return FitResult{}, errs.New(ErrInvalidParameter, "Fit: Exponential data must not be all zero")
+	}
+	n := float64(len(data))
+	lambda := n / sum
+	ll := n*math.Log(lambda) - lambda*sum
+	return newFitResult(FamilyExponential, Exponential{Lambda: lambda}, []Param{
+		{"Lambda", lambda, lambda / math.Sqrt(n)},
+	}, ll, len(data)), nil
+}
+
+func fitPoisson(data []float64) (FitResult, error) {
+	sum := 0.0
+	for _, x := range data {
+		if x < 0 || x != math.Floor(x) {
+			return FitResult{}, errs.New(ErrInvalidParameter, "Fit: Poisson data must be non-negative integers")
+		}
+		sum += x
+	}
+	if sum == 0 {
+		return FitResult{}, errs.New(ErrInvalidParameter, "Fit: Poisson data must not be all zero")
+	}
+	n := float64(len(data))
+	lambda := sum / n
+	ll := sum*math.Log(lambda) - n*lambda
+	for _, x := range data {
+		lg, _ := math.Lgamma(x + 1)
+		ll -= lg
+	}
+	return newFitResult(FamilyPoisson, Poisson{Lambda: lambda}, []Param{
+		{"Lambda", lambda, math.Sqrt(lambda / n)},
+	}, ll, len(data)), nil
+}
+
+// positiveLogStats returns mean(x) and mean(log x) for strictly positive
+// data.
+func positiveLogStats(data []float64, family Family) (mean, meanLog float64, err error) {
+	for _, x := range data {
+		if x <= 0 {
+			return 0, 0, errs.New(ErrInvalidParameter, "Fit: "+family.String()+" data must be > 0")
+		}
+		mean += x
+		meanLog += math.Log(x)
+	}
+	n := float64(len(data))
+	return mean / n, meanLog / n, nil
+}
+
+func fitGamma(data []float64) (FitResult, error) {
+	mean, meanLog, err := positiveLogStats(data, FamilyGamma)
+	if err != nil {
+		return FitResult{}, err
+	}
+	// the shape solves log α - ψ(α) = log(mean) - mean(log x) = s
+	s := math.Log(mean) - meanLog
+	if !(s > 0) {
+		return FitResult{}, errs.New(ErrInvalidParameter, "Fit: data must contain at least two distinct values")
+	}
+	alpha0 := (3 - s + math.Sqrt((s-3)*(s-3)+24*s)) / (12 * s) // Minka's start
+	alpha := root.SolvePositive(func(a float64) (float64, float64) {
+		return math.Log(a) - specfunc.Digamma(a) - s, 1/a - specfunc.Trigamma(a)
+	}, false, alpha0)
+	beta := alpha / mean
+
+	n := float64(len(data))
+	lg, _ := math.Lgamma(alpha)
+	ll := n * (alpha*math.Log(beta) - lg + (alpha-1)*meanLog - beta*mean)
+	// observed information: [[nψ'(α), -n/β], [-n/β, nα/β²]]
+	seAlpha, seBeta := inverseDiagonal2(n*specfunc.Trigamma(alpha), -n/beta, n*alpha/(beta*beta))
+	return newFitResult(FamilyGamma, Gamma{Alpha: alpha, Beta: beta}, []Param{
+		{"Alpha", alpha, seAlpha},
+		{"Beta", beta, seBeta},
+	}, ll, len(data)), nil
+}
+
+func fitWeibull(data []float64) (FitResult, error) {
+	_, meanLog, err := positiveLogStats(data, FamilyWeibull)
+	if err != nil {
+		return FitResult{}, err
+	}
+	// work with y = log(x / max x) ≤ 0 so that exp(k·y) cannot overflow
+	logMax := math.Inf(-1)
+	for _, x := range data {
+		logMax = math.Max(logMax, math.Log(x))
+	}
+	ys := make([]float64, len(data))
+	meanY := meanLog - logMax
+	for i, x := range data {
+		ys[i] = math.Log(x) - logMax
+	}
+	if meanY == 0 {
+		return FitResult{}, errs.New(ErrInvalidParameter, "Fit: data must contain at least two distinct values")
+	}
+	// the shape solves Σwy/Σw - 1/k - mean(y) = 0 with w = exp(k·y)
+	_, sdLog := meanAndSD(ys)
+	k := root.SolvePositive(func(k float64) (float64, float64) {
+		s0, s1, s2 := 0.0, 0.0, 0.0
+		for _, y := range ys {
+			w := math.Exp(k * y)
+			s0 += w
+			s1 += w * y
+			s2 += w * y * y
+		}
+		m := s1 / s0
+		return m - 1/k - meanY, s2/s0 - m*m + 1/(k*k)
+	}, true, 1.2/sdLog)
+
+	n := float64(len(data))
+	s0 := 0.0
+	for _, y := range ys {
+		s0 += math.Exp(k * y)
+	}
+	lambda := math.Exp(logMax + math.Log(s0/n)/k)
+
+	// derivatives of the log-likelihood with z = x/λ
+	var sumZk, sumZkL, sumZkL2, sumL float64
+	for _, x := range data {
+		l := math.Log(x / lambda)
+		zk := math.Exp(k * l)
+		sumL += l
+		sumZk += zk
+		sumZkL += zk * l
+		sumZkL2 += zk * l * l
+	}
+	ll := n*math.Log(k/lambda) + (k-1)*sumL - sumZk
+	ikk := n/(k*k) + sumZkL2
+	ikl := (n - sumZk - k*sumZkL) / lambda
+	ill := (k*(k+1)*sumZk - n*k) / (lambda * lambda)
+	seK, seLambda := inverseDiagonal2(ikk, ikl, ill)
+	return newFitResult(FamilyWeibull, Weibull{K: k, Lambda: lambda}, []Param{
+		{"K", k, seK},
+		{"Lambda", lambda, seLambda},
+	}, ll, len(data)), nil
+}
+
+// inverseDiagonal2 returns the square roots of the diagonal of the inverse
+// of the symmetric matrix [[a, b], [b, c]].
+func inverseDiagonal2(a, b, c float64) (float64, float64) {
+	det := a*c - b*b
+	return math.Sqrt(c / det), math.Sqrt(a / det)
+}
@@ -0,0 +1,190 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitClosedForms(t *testing.T) {
+	r, err := Fit([]float64{2, 4, 4, 4, 5, 5, 7, 9}, FamilyNormal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParams(t, r, []Param{{"Mu", 5, 2 / math.Sqrt(8)}, {"Sigma", 2, 2 / math.Sqrt(16)}})
+	assertClose(t, "Normal loglik", r.LogLik, -4*(math.Log(8*math.Pi)+1))
+	assertClose(t, "Normal AIC", r.AIC, 4-2*r.LogLik)
+	assertClose(t, "Normal BIC", r.BIC, 2*math.Log(8)-2*r.LogLik)
+
+	r, err = Fit([]float64{1, 2, 3}, FamilyExponential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParams(t, r, []Param{{"Lambda", 0.5, 0.5 / math.Sqrt(3)}})
+	assertClose(t, "Exponential loglik", r.LogLik, 3*math.Log(0.5)-3)
+
+	r, err = Fit([]float64{0, 1, 2, 3, 4}, FamilyPoisson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParams(t, r, []Param{{"Lambda", 2, math.Sqrt(2.0 / 5)}})
+	assertClose(t, "Poisson loglik", r.LogLik, 10*math.Log(2)-10-math.Log(1*1*2*6*24))
+
+	r, err = FitBinomial([]float64{3, 5, 4}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParams(t, r, []Param{{"P", 0.4, math.Sqrt(0.4 * 0.6 / 30)}})
+	if r.Family != FamilyBinomial || r.Dist != (Binomial{N: 10, P: 0.4}) {
+		t.Errorf("FitBinomial returned %v %v", r.Family, r.Dist)
+	}
+
+	r, err = Fit([]float64{1, math.E, math.E * math.E}, FamilyLogNormal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigma := math.Sqrt(2.0 / 3)
+	assertParams(t, r, []Param{{"Mu", 1, sigma / math.Sqrt(3)}, {"Sigma", sigma, sigma / math.Sqrt(6)}})
+}
+
+// TestFitTwoParameterFamilies checks that the score vanishes at the estimate
+// and that the standard errors match a numerically differentiated Hessian.
+func TestFitTwoParameterFamilies(t *testing.T) {
+	tests := []struct {
+		family Family
+		truth  Distribution
+		make   func(a, b float64) Continuous
+	}{
+		{FamilyGamma, Gamma{Alpha: 2.3, Beta: 0.7}, func(a, b float64) Continuous { return Gamma{Alpha: a, Beta: b} }},
+		{FamilyGamma, Gamma{Alpha: 0.3, Beta: 5}, func(a, b float64) Continuous { return Gamma{Alpha: a, Beta: b} }},
+		{FamilyWeibull, Weibull{K: 1.7, Lambda: 3e5}, func(a, b float64) Continuous { return Weibull{K: a, Lambda: b} }},
+		{FamilyWeibull, Weibull{K: 0.6, Lambda: 0.01}, func(a, b float64) Continuous { return Weibull{K: a, Lambda: b} }},
+		{FamilyLogNormal, LogNormal{Mu: 1, Sigma: 0.5}, func(a, b float64) Continuous { return LogNormal{Mu: a, Sigma: b} }},
+	}
+	rng := rand.New(rand.NewSource(11))
+	for _, tt := range tests {
+		xs := Sample(tt.truth, 2000, rng)
+		r, err := Fit(xs, tt.family)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.family, err)
+		}
+		ll := func(a, b float64) float64 {
+			d := tt.make(a, b)
+			s := 0.0
+			for _, x := range xs {
+				s += math.Log(d.PDF(x))
+			}
+			return s
+		}
+		a, b := r.Params[0].Value, r.Params[1].Value
+		if math.Abs(ll(a, b)-r.LogLik) > 1e-9*math.Abs(r.LogLik) {
+			t.Errorf("%v: LogLik = %v; direct sum %v", tt.family, r.LogLik, ll(a, b))
+		}
+		ha, hb := a*1e-4, b*1e-4
+		ga := (ll(a+ha, b) - ll(a-ha, b)) / (2 * ha)
+		gb := (ll(a, b+hb) - ll(a, b-hb)) / (2 * hb)
+		if math.Abs(ga*a) > 1e-4 || math.Abs(gb*b) > 1e-4 {
+			t.Errorf("%v: score at the MLE = (%g, %g)", tt.family, ga, gb)
+		}
+		haa := (ll(a+ha, b) - 2*ll(a, b) + ll(a-ha, b)) / (ha * ha)
+		hbb := (ll(a, b+hb) - 2*ll(a, b) + ll(a, b-hb)) / (hb * hb)
+		hab := (ll(a+ha, b+hb) - ll(a+ha, b-hb) - ll(a-ha, b+hb) + ll(a-ha, b-hb)) / (4 * ha * hb)
+		det := haa*hbb - hab*hab
+		seA, seB := math.Sqrt(-hbb/det), math.Sqrt(-haa/det)
+		if math.Abs(r.Params[0].StdErr/seA-1) > 1e-3 || math.Abs(r.Params[1].StdErr/seB-1) > 1e-3 {
+			t.Errorf("%v: StdErr = (%v, %v); numerical (%v, %v)", tt.family,
+				r.Params[0].StdErr, r.Params[1].StdErr, seA, seB)
+		}
+		// the estimates should recover the generating parameters
+		truth := []float64{0, 0}
+		switch d := tt.truth.(type) {
+		case Gamma:
+			truth = []float64{d.Alpha, d.Beta}
+		case Weibull:
+			truth = []float64{d.K, d.Lambda}
+		case LogNormal:
+			truth = []float64{d.Mu, d.Sigma}
+		}
+		for i, p := range r.Params {
+			if math.Abs(p.Value-truth[i]) > 4*p.StdErr {
+				t.Errorf("%v: %s = %v ± %v; generated with %v", tt.family, p.Name, p.Value, p.StdErr, truth[i])
+			}
+		}
+	}
+}
+
+func TestRankFits(t *testing.T) {
+	xs := Sample(LogNormal{Mu: 0, Sigma: 1}, 3000, rand.New(rand.NewSource(12)))
+	fits := RankFits(xs, FamilyNormal, FamilyPoisson, FamilyExponential, FamilyGamma, FamilyWeibull, FamilyLogNormal)
+	if len(fits) != 5 {
+		t.Fatalf("got %d fits; the Poisson family should be skipped for non-integer data", len(fits))
+	}
+	if fits[0].Family != FamilyLogNormal {
+		t.Errorf("best fit = %v; want LogNormal", fits[0].Family)
+	}
+	for i := 1; i < len(fits); i++ {
+		if fits[i].AIC < fits[i-1].AIC {
+			t.Errorf("fits not sorted by AIC: %v before %v", fits[i-1].AIC, fits[i].AIC)
+		}
+	}
+}
+
+func TestFitErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []float64
+		family Family
+	}{
+		{"empty", nil, FamilyNormal},
+		{"NaN", []float64{1, math.NaN()}, FamilyNormal},
+		{"constant", []float64{3, 3, 3}, FamilyNormal},
+		{"constant gamma", []float64{3, 3, 3}, FamilyGamma},
+		{"constant weibull", []float64{3, 3, 3}, FamilyWeibull},
+		{"negative exponential", []float64{1, -1}, FamilyExponential},
+		{"zero exponential", []float64{0, 0}, FamilyExponential},
+		{"fractional poisson", []float64{1, 2.5}, FamilyPoisson},
+		{"zero gamma", []float64{0, 1, 2}, FamilyGamma},
+		{"negative lognormal", []float64{-1, 1, 2}, FamilyLogNormal},
+		{"binomial without trials", []float64{1, 2}, FamilyBinomial},
+		{"unknown family", []float64{1, 2}, Family(99)},
+	}
+	for _, tt := range tests {
+		if _, err := Fit(tt.data, tt.family); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+	if _, err := FitBinomial([]float64{1, 11}, 10); err == nil {
+		t.Error("FitBinomial: expected an error for counts above trials")
+	}
+	if _, err := FitBinomial(nil, 10); err == nil {
+		t.Error("FitBinomial: expected an error for empty data")
+	}
+	if _, err := FitBinomial([]float64{1}, 0); err == nil {
+		t.Error("FitBinomial: expected an error for zero trials")
+	}
+	if Family(99).String() != "Unknown" || FamilyWeibull.String() != "Weibull" {
+		t.Error("unexpected Family.String output")
+	}
+}
+
+func assertParams(t *testing.T, r FitResult, want []Param) {
+	t.Helper()
+	if len(r.Params) != len(want) {
+		t.Fatalf("%v: got %d params; want %d", r.Family, len(r.Params), len(want))
+	}
+	for i, w := range want {
+		got := r.Params[i]
+		if got.Name != w.Name {
+			t.Errorf("%v: param %d is %q; want %q", r.Family, i, got.Name, w.Name)
+		}
+		assertClose(t, r.Family.String()+" "+w.Name, got.Value, w.Value)
+		assertClose(t, r.Family.String()+" "+w.Name+" StdErr", got.StdErr, w.StdErr)
+	}
+}
+
+func assertClose(t *testing.T, what string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v; want %v", what, got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGammaExponentialCase(t *testing.T) {
+	// Gamma(1, λ) is the exponential distribution.
+	g := Gamma{Alpha: 1, Beta: 1.5}
+	for _, x := range []float64{0.01, 0.5, 2, 10, 400} {
+		if got, want := g.CDF(x), -math.Expm1(-1.5*x); math.Abs(got-want) > 1e-14 {
+			t.Errorf("CDF(%v) = %v; want %v", x, got, want)
+		}
+		if got, want := g.Survival(x), math.Exp(-1.5*x); math.Abs(got-want) > 1e-13*want {
+			t.Errorf("Survival(%v) = %g; want %g", x, got, want)
+		}
+		if got, want := g.PDF(x), 1.5*math.Exp(-1.5*x); math.Abs(got-want) > 1e-13*want {
+			t.Errorf("PDF(%v) = %g; want %g", x, got, want)
+		}
+	}
+}
+
+func TestGammaQuantileRoundTrip(t *testing.T) {
+	for _, alpha := range []float64{0.05, 0.5, 1, 2.5, 20, 500, 1e5} {
+		g := Gamma{Alpha: alpha, Beta: 2}
+		for _, p := range []float64{1e-12, 0.01, 0.3, 0.5, 0.9, 0.999, 1 - 1e-12} {
+			x := g.Quantile(p)
+			var back float64
+			if p > 0.5 {
+				back = 1 - g.Survival(x)
+			} else {
+				back = g.CDF(x)
+			}
+			if math.Abs(back-p) > 1e-12*math.Min(p, 1-p)+1e-15 {
+				t.Errorf("Gamma{%v,2}: CDF(Quantile(%v)) = %.15g", alpha, p, back)
+			}
+		}
+	}
+}
+
+func TestGammaMomentsAndInvalid(t *testing.T) {
+	g := Gamma{Alpha: 3, Beta: 2}
+	if g.Mean() != 1.5 || g.Variance() != 0.75 {
+		t.Errorf("Mean/Variance = %v/%v; want 1.5/0.75", g.Mean(), g.Variance())
+	}
+	for _, bad := range []Gamma{{0, 1}, {1, 0}, {-2, 1}, {math.NaN(), 1}} {
+		if !math.IsNaN(bad.PDF(1)) || !math.IsNaN(bad.CDF(1)) || !math.IsNaN(bad.Quantile(0.5)) || !math.IsNaN(bad.Rand(nil)) {
+			t.Errorf("%+v should return NaN for invalid parameters", bad)
+		}
+	}
+	if g.CDF(-1) != 0 || g.Survival(-1) != 1 || g.PDF(-1) != 0 {
+		t.Error("negative x should have zero mass")
+	}
+}
+
+func TestGammaRand(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, g := range []Gamma{{Alpha: 0.4, Beta: 1}, {Alpha: 2.5, Beta: 0.5}, {Alpha: 40, Beta: 4}} {
+		const n = 40000
+		sum, sumSq := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			x := g.Rand(rng)
+			sum += x
+			sumSq += x * x
+		}
+		mean := sum / n
+		variance := sumSq/n - mean*mean
+		if math.Abs(mean-g.Mean()) > 0.03*g.Mean() {
+			t.Errorf("%+v: sample mean = %.4f; want ≈ %.4f", g, mean, g.Mean())
+		}
+		if math.Abs(variance-g.Variance()) > 0.06*g.Variance() {
+			t.Errorf("%+v: sample variance = %.4f; want ≈ %.4f", g, variance, g.Variance())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Geometric is the distribution of the number of failures before the first
+// success in independent trials with success probability P. P must be in
+// (0, 1]; methods return NaN otherwise.
+type Geometric struct {
+	P float64
+}
+
+func (g Geometric) valid() bool {
+	return g.P > 0 && g.P <= 1
+}
+
+// PMF returns P(X = k) = (1-P)^k·P.
+func (g Geometric) PMF(k int) float64 {
+	return math.Exp(g.LogPMF(k))
+}
+
+// LogPMF returns log P(X = k) = k·log(1-P) + log P.
+func (g Geometric) LogPMF(k int) float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	switch {
+	case k < 0:
+		return math.Inf(-1)
+	case k == 0:
+		return math.Log(g.P)
+	}
+	return float64(k)*math.Log1p(-g.P) + math.Log(g.P)
+}
+
+// CDF returns P(X ≤ x) = 1 - (1-P)^(⌊x⌋+1).
+func (g Geometric) CDF(x float64) float64 {
+	if !g.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1((math.Floor(x) + 1) * math.Log1p(-g.P))
+}
+
+// Survival returns P(X > x) = (1-P)^(⌊x⌋+1).
+func (g Geometric) Survival(x float64) float64 {
+	if !g.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 1
+	}
+	return math.Exp((math.Floor(x) + 1) * math.Log1p(-g.P))
+}
+
+// Quantile returns the smallest k such that P(X ≤ k) ≥ q.
+func (g Geometric) Quantile(q float64) float64 {
+	if !g.valid() || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if q <= g.P {
+		return 0
+	}
+	if q == 1 {
+		return math.Inf(1)
+	}
+	k := math.Max(0, math.Ceil(math.Log1p(-q)/math.Log1p(-g.P)-1))
+	// correct for rounding in the closed form
+	for k > 0 && g.CDF(k-1) >= q {
+		k--
+	}
+	for g.CDF(k) < q {
+		k++
+	}
+	return k
+}
+
+// Mean returns (1-P) / P.
+func (g Geometric) Mean() float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	return (1 - g.P) / g.P
+}
+
+// Variance returns (1-P) / P².
+func (g Geometric) Variance() float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	return (1 - g.P) / (g.P * g.P)
+}
+
+// Entropy returns (-(1-P)·log(1-P) - P·log P) / P.
+func (g Geometric) Entropy() float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	if g.P == 1 {
+		return 0
+	}
+	q := 1 - g.P
+	return -(q*math.Log1p(-g.P) + g.P*math.Log(g.P)) / g.P
+}
+
+// Rand draws a random variate as ⌊E / -log(1-P)⌋ with E standard
+// exponential. A nil rng uses the global math/rand source.
+func (g Geometric) Rand(rng *rand.Rand) float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	if g.P == 1 {
+		return 0
+	}
+	return math.Floor(expFloat(rng) / -math.Log1p(-g.P))
+}
@@ -0,0 +1,47 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeometricClosedForms(t *testing.T) {
+	d := Geometric{P: 0.25}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PMF(0)", d.PMF(0), 0.25},
+		{"PMF(2)", d.PMF(2), 0.140625},
+		{"CDF(2)", d.CDF(2), 1 - 0.421875},
+		{"CDF(2.7)", d.CDF(2.7), 1 - 0.421875},
+		{"Survival(2)", d.Survival(2), 0.421875},
+		{"Quantile(0.578125)", d.Quantile(0.578125), 2},
+		{"Quantile(0.579)", d.Quantile(0.579), 3},
+		{"Quantile(0.1)", d.Quantile(0.1), 0},
+		{"Mean", d.Mean(), 3},
+		{"Variance", d.Variance(), 12},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// a rare event: the log-space PMF stays accurate far into the tail
+	rare := Geometric{P: 1e-6}
+	if got, want := rare.PMF(5000000), 1e-6*math.Exp(5000000*math.Log1p(-1e-6)); math.Abs(got/want-1) > 1e-12 {
+		t.Errorf("PMF(5e6) = %v; want %v", got, want)
+	}
+	if q := rare.Quantile(0.5); q != math.Ceil(math.Log(0.5)/math.Log1p(-1e-6)-1) {
+		t.Errorf("Quantile(0.5) = %v", q)
+	}
+
+	sure := Geometric{P: 1}
+	if sure.PMF(0) != 1 || sure.PMF(1) != 0 || sure.Quantile(0.9) != 0 || sure.Entropy() != 0 {
+		t.Error("Geometric{1} should be a point mass at 0")
+	}
+	if !math.IsInf(d.Quantile(1), 1) || !math.IsNaN(Geometric{P: 0}.PMF(1)) {
+		t.Error("unexpected boundary behaviour")
+	}
+}
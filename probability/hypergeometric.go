@@ -0,0 +1,196 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Hypergeometric is the distribution of the number of successes in Draws
+// draws without replacement from a population of Total items, Successes of
+// which count as successes, as in lot-acceptance sampling. Methods return
+// NaN unless 0 ≤ Successes ≤ Total and 0 ≤ Draws ≤ Total.
+type Hypergeometric struct {
+	Total, Successes, Draws int
+}
+
+func (h Hypergeometric) valid() bool {
+	return h.Total >= 0 && h.Successes >= 0 && h.Successes <= h.Total &&
+		h.Draws >= 0 && h.Draws <= h.Total
+}
+
+// support returns the smallest and largest attainable values.
+func (h Hypergeometric) support() (int, int) {
+	lo := h.Draws + h.Successes - h.Total
+	if lo < 0 {
+		lo = 0
+	}
+	hi := h.Draws
+	if h.Successes < hi {
+		hi = h.Successes
+	}
+	return lo, hi
+}
+
+// mode returns ⌊(Draws+1)(Successes+1)/(Total+2)⌋.
+func (h Hypergeometric) mode() int {
+	return int(float64(h.Draws+1) * float64(h.Successes+1) / float64(h.Total+2))
+}
+
+// PMF returns P(X = k) = C(K, k)·C(N-K, n-k) / C(N, n).
+func (h Hypergeometric) PMF(k int) float64 {
+	return math.Exp(h.LogPMF(k))
+}
+
+// LogPMF returns log P(X = k). Rather than differencing three LogChoose
+// terms, it divides binomial probabilities with success rate n/N, which
+// stays accurate for populations in the millions.
+func (h Hypergeometric) LogPMF(k int) float64 {
+	if !h.valid() {
+		return math.NaN()
+	}
+	if lo, hi := h.support(); k < lo || k > hi {
+		return math.Inf(-1)
+	}
+	N, K, n, kf := float64(h.Total), float64(h.Successes), float64(h.Draws), float64(k)
+	p := n / N
+	q := (N - n) / N
+	return binomialLogPMF(kf, K, p, q) + binomialLogPMF(n-kf, N-K, p, q) - binomialLogPMF(n, N, p, q)
+}
+
+// lowerSum returns P(X ≤ k) for k in the support, summing downward with the
+// PMF recurrence until the terms are negligible. Terms shrink monotonically
+// below the mode, so k should not exceed it.
+func (h Hypergeometric) lowerSum(k int) float64 {
+	lo, _ := h.support()
+	N, K, n := float64(h.Total), float64(h.Successes), float64(h.Draws)
+	sum, pk := 0.0, h.PMF(k)
+	for j := k; j >= lo && pk > 0; j-- {
+		sum += pk
+		if pk < 1e-17*sum {
+			break
+		}
+		jf := float64(j)
+		pk *= jf * (N - K - n + jf) / ((K - jf + 1) * (n - jf + 1))
+	}
+	return sum
+}
+
+// upperSum returns P(X ≥ k), the mirror image of lowerSum for k above the mode.
+func (h Hypergeometric) upperSum(k int) float64 {
+	_, hi := h.support()
+	N, K, n := float64(h.Total), float64(h.Successes), float64(h.Draws)
+	sum, pk := 0.0, h.PMF(k)
+	for j := k; j <= hi && pk > 0; j++ {
+		sum += pk
+		if pk < 1e-17*sum {
+			break
+		}
+		jf := float64(j)
+		pk *= (K - jf) * (n - jf) / ((jf + 1) * (N - K - n + jf + 1))
+	}
+	return sum
+}
+
+// CDF returns P(X ≤ x). Only the tail on the far side of the mode is summed,
+// so the cost grows with the standard deviation rather than the population.
+func (h Hypergeometric) CDF(x float64) float64 {
+	if !h.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	switch {
+	case x < float64(lo):
+		return 0
+	case x >= float64(hi):
+		return 1
+	}
+	k := int(math.Floor(x))
+	if k < h.mode() {
+		return h.lowerSum(k)
+	}
+	return 1 - h.upperSum(k+1)
+}
+
+// Survival returns P(X > x).
+func (h Hypergeometric) Survival(x float64) float64 {
+	if !h.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	switch {
+	case x < float64(lo):
+		return 1
+	case x >= float64(hi):
+		return 0
+	}
+	k := int(math.Floor(x))
+	if k < h.mode() {
+		return 1 - h.lowerSum(k)
+	}
+	return h.upperSum(k + 1)
+}
+
+// Quantile returns the smallest k such that P(X ≤ k) ≥ q.
+func (h Hypergeometric) Quantile(q float64) float64 {
+	if !h.valid() || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	return float64(discreteQuantile(q, lo, hi, func(k int) float64 { return h.CDF(float64(k)) }))
+}
+
+// Mean returns Draws·Successes / Total.
+func (h Hypergeometric) Mean() float64 {
+	if !h.valid() {
+		return math.NaN()
+	}
+	if h.Total == 0 {
+		return 0
+	}
+	return float64(h.Draws) * float64(h.Successes) / float64(h.Total)
+}
+
+// Variance returns n·(K/N)·((N-K)/N)·((N-n)/(N-1)).
+func (h Hypergeometric) Variance() float64 {
+	if !h.valid() {
+		return math.NaN()
+	}
+	if h.Total <= 1 {
+		return 0
+	}
+	N, K, n := float64(h.Total), float64(h.Successes), float64(h.Draws)
+	return n * (K / N) * ((N - K) / N) * ((N - n) / (N - 1))
+}
+
+// Entropy returns -Σ P(X=k)·log P(X=k) over the part of the support where
+// the probabilities are not negligible.
+func (h Hypergeometric) Entropy() float64 {
+	if !h.valid() {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	width := int(40*math.Sqrt(h.Variance()) + 40)
+	from, to := h.mode()-width, h.mode()+width
+	if from < lo {
+		from = lo
+	}
+	if to > hi {
+		to = hi
+	}
+	ent := 0.0
+	for k := from; k <= to; k++ {
+		if pk := h.PMF(k); pk > 0 {
+			ent -= pk * math.Log(pk)
+		}
+	}
+	return ent
+}
+
+// Rand draws a random variate by inverse-transform sampling.
+// A nil rng uses the global math/rand source.
+func (h Hypergeometric) Rand(rng *rand.Rand) float64 {
+	if !h.valid() {
+		return math.NaN()
+	}
+	return h.Quantile(uniformOpen(rng))
+}
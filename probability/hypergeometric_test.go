@@ -0,0 +1,58 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHypergeometricLotSampling(t *testing.T) {
+	// a lot of 50 items with 5 defective; inspect 10 and accept on ≤ 1 defect
+	lot := Hypergeometric{Total: 50, Successes: 5, Draws: 10}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PMF(0)", lot.PMF(0), 0.3105627820045687},
+		{"CDF(1)", lot.CDF(1), 0.7418999792331363},
+		{"Survival(1)", lot.Survival(1), 1 - 0.7418999792331363},
+		{"Mean", lot.Mean(), 1},
+		{"Variance", lot.Variance(), 10 * 0.1 * 0.9 * 40 / 49},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-13 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if lot.PMF(6) != 0 || lot.CDF(5) != 1 || lot.Quantile(1) != 5 {
+		t.Error("at most 5 defects can be drawn")
+	}
+}
+
+func TestHypergeometricLargePopulation(t *testing.T) {
+	d := Hypergeometric{Total: 1000000, Successes: 100000, Draws: 10000}
+	sd := math.Sqrt(d.Variance())
+	// far outside int64 range for C(N, n), but fine in log space
+	if got, want := d.PMF(1000), 1/math.Sqrt(2*math.Pi)/sd; math.Abs(got/want-1) > 1e-2 {
+		t.Errorf("PMF at the mean = %v; want ≈ %v", got, want)
+	}
+	// the CDF one standard deviation below the mean is close to Φ(-1)
+	if got := d.CDF(math.Floor(1000 - sd)); math.Abs(got-0.1587) > 0.02 {
+		t.Errorf("CDF(mean - sd) = %v; want ≈ 0.159", got)
+	}
+	if got := d.CDF(1100) + d.Survival(1100); math.Abs(got-1) > 1e-12 {
+		t.Errorf("CDF + Survival = %v", got)
+	}
+	if q := d.Quantile(0.5); math.Abs(q-1000) > 1 {
+		t.Errorf("median = %v; want ≈ 1000", q)
+	}
+}
+
+func TestHypergeometricDegenerate(t *testing.T) {
+	all := Hypergeometric{Total: 10, Successes: 4, Draws: 10}
+	if all.PMF(4) != 1 || all.Variance() != 0 {
+		t.Error("drawing the whole population is deterministic")
+	}
+	if !math.IsNaN(Hypergeometric{Total: 10, Successes: 4, Draws: 11}.PMF(1)) {
+		t.Error("expected NaN when drawing more than the population")
+	}
+}
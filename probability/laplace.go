@@ -0,0 +1,95 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Laplace is the Laplace (double exponential) distribution with location Mu
+// and scale B. B must be > 0; methods return NaN otherwise.
+type Laplace struct {
+	Mu, B float64
+}
+
+func (l Laplace) valid() bool {
+	return l.B > 0 && !math.IsInf(l.B, 1) && !math.IsNaN(l.Mu) && !math.IsInf(l.Mu, 0)
+}
+
+// PDF returns the probability density at x.
+func (l Laplace) PDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return math.Exp(-math.Abs(x-l.Mu)/l.B) / (2 * l.B)
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (l Laplace) LogPDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return -math.Abs(x-l.Mu)/l.B - math.Log(2*l.B)
+}
+
+// CDF returns P(X ≤ x).
+func (l Laplace) CDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < l.Mu {
+		return 0.5 * math.Exp((x-l.Mu)/l.B)
+	}
+	return 1 - 0.5*math.Exp(-(x-l.Mu)/l.B)
+}
+
+// Survival returns P(X > x).
+func (l Laplace) Survival(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return l.CDF(2*l.Mu - x)
+}
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+func (l Laplace) Quantile(p float64) float64 {
+	if !l.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	if p < 0.5 {
+		return l.Mu + l.B*math.Log(2*p)
+	}
+	return l.Mu - l.B*math.Log(2*(1-p))
+}
+
+// Mean returns Mu.
+func (l Laplace) Mean() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.Mu
+}
+
+// Variance returns 2·B².
+func (l Laplace) Variance() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return 2 * l.B * l.B
+}
+
+// Entropy returns log(2e·B).
+func (l Laplace) Entropy() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return math.Log(2*l.B) + 1
+}
+
+// Rand draws a random variate as Mu + B·(E1 - E2) with E1, E2 standard
+// exponential. A nil rng uses the global math/rand source.
+func (l Laplace) Rand(rng *rand.Rand) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.Mu + l.B*(expFloat(rng)-expFloat(rng))
+}
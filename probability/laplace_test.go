@@ -0,0 +1,32 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLaplaceClosedForms(t *testing.T) {
+	d := Laplace{Mu: 0, B: 1}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(0)", d.PDF(0), 0.5},
+		{"PDF(-2)", d.PDF(-2), 0.5 * math.Exp(-2)},
+		{"CDF(-1)", d.CDF(-1), 0.5 * math.Exp(-1)},
+		{"CDF(1)", d.CDF(1), 1 - 0.5*math.Exp(-1)},
+		{"Survival(3)", d.Survival(3), 0.5 * math.Exp(-3)},
+		{"Quantile(0.9)", d.Quantile(0.9), -math.Log(0.2)},
+		{"Quantile(0.1)", d.Quantile(0.1), math.Log(0.2)},
+		{"Variance", Laplace{Mu: 5, B: 3}.Variance(), 18},
+		{"Entropy", d.Entropy(), math.Log(2) + 1},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if !math.IsNaN(Laplace{Mu: 0, B: -1}.CDF(0)) || !math.IsNaN(d.PDF(math.NaN())) {
+		t.Error("expected NaN for invalid input")
+	}
+}
@@ -0,0 +1,136 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// LocationScale returns the distribution of loc + scale·X for X ~ dist and
+// scale > 0, e.g. an Exponential shifted to start at loc. The result
+// implements Continuous when dist does. It implements Discrete only for an
+// integer shift of a Discrete dist (scale = 1, integral loc), since other
+// transforms move the mass off the integers; it remains a Distribution
+// either way. Every method returns NaN for a non-finite loc or a scale
+// that is not positive and finite.
+func LocationScale(dist Distribution, loc, scale float64) Distribution {
+	ls := locationScale{dist: dist, loc: loc, scale: scale}
+	switch dist.(type) {
+	case Continuous:
+		return locationScaleContinuous{ls}
+	case Discrete:
+		if scale == 1 && loc == math.Trunc(loc) {
+			return locationScaleDiscrete{ls}
+		}
+	}
+	return ls
+}
+
+type locationScale struct {
+	dist       Distribution
+	loc, scale float64
+}
+
+type locationScaleContinuous struct{ locationScale }
+
+type locationScaleDiscrete struct{ locationScale }
+
+func (l locationScale) valid() bool {
+	return l.dist != nil && !math.IsNaN(l.loc) && !math.IsInf(l.loc, 0) &&
+		l.scale > 0 && !math.IsInf(l.scale, 1)
+}
+
+// standardize maps x back to the scale of the base distribution.
+func (l locationScale) standardize(x float64) float64 {
+	return (x - l.loc) / l.scale
+}
+
+// CDF returns the base CDF at (x - loc) / scale.
+func (l locationScale) CDF(x float64) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.dist.CDF(l.standardize(x))
+}
+
+// Survival returns the base Survival at (x - loc) / scale.
+func (l locationScale) Survival(x float64) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.dist.Survival(l.standardize(x))
+}
+
+// Quantile returns loc + scale·Q(p) for the base quantile Q.
+func (l locationScale) Quantile(p float64) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.loc + l.scale*l.dist.Quantile(p)
+}
+
+// Mean returns loc + scale·E[X].
+func (l locationScale) Mean() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.loc + l.scale*l.dist.Mean()
+}
+
+// Variance returns scale²·Var(X).
+func (l locationScale) Variance() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.scale * l.scale * l.dist.Variance()
+}
+
+// Entropy returns the base entropy; relabelling the outcomes of a discrete
+// distribution does not change it. Continuous distributions add log scale.
+func (l locationScale) Entropy() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.dist.Entropy()
+}
+
+// Rand returns loc + scale·X for a base draw X.
+func (l locationScale) Rand(rng *rand.Rand) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.loc + l.scale*l.dist.Rand(rng)
+}
+
+// PDF returns f((x - loc) / scale) / scale.
+func (l locationScaleContinuous) PDF(x float64) float64 {
+	return math.Exp(l.LogPDF(x))
+}
+
+// LogPDF returns log f((x - loc) / scale) - log scale.
+func (l locationScaleContinuous) LogPDF(x float64) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.dist.(Continuous).LogPDF(l.standardize(x)) - math.Log(l.scale)
+}
+
+// Entropy returns the base differential entropy plus log scale.
+func (l locationScaleContinuous) Entropy() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.dist.Entropy() + math.Log(l.scale)
+}
+
+// PMF returns the base PMF at k - loc.
+func (l locationScaleDiscrete) PMF(k int) float64 {
+	return math.Exp(l.LogPMF(k))
+}
+
+// LogPMF returns the base LogPMF at k - loc.
+func (l locationScaleDiscrete) LogPMF(k int) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.dist.(Discrete).LogPMF(k - int(l.loc))
+}
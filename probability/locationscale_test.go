@@ -0,0 +1,69 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocationScaleNormal(t *testing.T) {
+	ls := LocationScale(Normal{Mu: 0, Sigma: 1}, 3, 2).(Continuous)
+	n := Normal{Mu: 3, Sigma: 2}
+	for _, x := range []float64{-2, 1, 3, 6.5} {
+		if math.Abs(ls.PDF(x)-n.PDF(x)) > 1e-15 || math.Abs(ls.CDF(x)-n.CDF(x)) > 1e-15 || math.Abs(ls.Survival(x)-n.Survival(x)) > 1e-15 {
+			t.Errorf("x=%v: PDF/CDF/Survival %v/%v/%v; want %v/%v/%v", x, ls.PDF(x), ls.CDF(x), ls.Survival(x), n.PDF(x), n.CDF(x), n.Survival(x))
+		}
+	}
+	if got, want := ls.Quantile(0.9), n.Quantile(0.9); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Quantile(0.9) = %v; want %v", got, want)
+	}
+	if ls.Mean() != 3 || ls.Variance() != 4 {
+		t.Errorf("Mean/Variance = %v/%v; want 3/4", ls.Mean(), ls.Variance())
+	}
+	if got, want := ls.Entropy(), n.Entropy(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Entropy() = %v; want %v", got, want)
+	}
+}
+
+func TestLocationScaleDiscrete(t *testing.T) {
+	p := Poisson{Lambda: 2.5}
+	shifted, ok := LocationScale(p, -3, 1).(Discrete)
+	if !ok {
+		t.Fatal("integer shift of a Poisson should be Discrete")
+	}
+	for k := -4; k < 6; k++ {
+		if got, want := shifted.PMF(k), p.PMF(k+3); got != want {
+			t.Errorf("PMF(%d) = %v; want %v", k, got, want)
+		}
+	}
+	if got := shifted.Entropy(); got != p.Entropy() {
+		t.Errorf("Entropy() = %v; want %v", got, p.Entropy())
+	}
+
+	// other transforms leave the integers, so they are only a Distribution
+	for _, d := range []Distribution{LocationScale(p, 0.5, 1), LocationScale(p, 0, 2)} {
+		if _, ok := d.(Discrete); ok {
+			t.Errorf("%v should not be Discrete", d)
+		}
+	}
+	scaled := LocationScale(p, 0, 2)
+	if got, want := scaled.CDF(4), p.CDF(2); got != want {
+		t.Errorf("scaled CDF(4) = %v; want %v", got, want)
+	}
+	if got := scaled.Entropy(); got != p.Entropy() {
+		t.Errorf("scaled Entropy() = %v; want %v", got, p.Entropy())
+	}
+}
+
+func TestLocationScaleInvalid(t *testing.T) {
+	for _, d := range []Distribution{
+		LocationScale(Normal{Mu: 0, Sigma: 1}, 0, 0),
+		LocationScale(Normal{Mu: 0, Sigma: 1}, 0, -1),
+		LocationScale(Normal{Mu: 0, Sigma: 1}, math.Inf(1), 1),
+		LocationScale(Normal{Mu: 0, Sigma: 1}, math.NaN(), 1),
+		LocationScale(nil, 0, 1),
+	} {
+		if !math.IsNaN(d.CDF(0.5)) || !math.IsNaN(d.Mean()) || !math.IsNaN(d.Quantile(0.5)) || !math.IsNaN(d.Rand(nil)) {
+			t.Errorf("%v should return NaN", d)
+		}
+	}
+}
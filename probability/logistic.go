@@ -0,0 +1,91 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Logistic is the logistic distribution with location Mu and scale S.
+// S must be > 0; methods return NaN otherwise.
+type Logistic struct {
+	Mu, S float64
+}
+
+func (l Logistic) valid() bool {
+	return l.S > 0 && !math.IsInf(l.S, 1) && !math.IsNaN(l.Mu) && !math.IsInf(l.Mu, 0)
+}
+
+// PDF returns the probability density at x.
+func (l Logistic) PDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	e := math.Exp(-math.Abs(x-l.Mu) / l.S) // symmetric form avoids overflow
+	return e / (l.S * (1 + e) * (1 + e))
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (l Logistic) LogPDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	z := math.Abs(x-l.Mu) / l.S
+	return -z - math.Log(l.S) - 2*math.Log1p(math.Exp(-z))
+}
+
+// CDF returns P(X ≤ x) = 1 / (1 + exp(-(x-Mu)/S)).
+func (l Logistic) CDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return 1 / (1 + math.Exp(-(x-l.Mu)/l.S))
+}
+
+// Survival returns P(X > x) = 1 / (1 + exp((x-Mu)/S)).
+func (l Logistic) Survival(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return 1 / (1 + math.Exp((x-l.Mu)/l.S))
+}
+
+// Quantile returns Mu + S·log(p / (1-p)).
+func (l Logistic) Quantile(p float64) float64 {
+	if !l.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return l.Mu + l.S*(math.Log(p)-math.Log1p(-p))
+}
+
+// Mean returns Mu.
+func (l Logistic) Mean() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.Mu
+}
+
+// Variance returns S²·π²/3.
+func (l Logistic) Variance() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.S * l.S * math.Pi * math.Pi / 3
+}
+
+// Entropy returns log(S) + 2.
+func (l Logistic) Entropy() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return math.Log(l.S) + 2
+}
+
+// Rand draws a random variate by inverse-transform sampling.
+// A nil rng uses the global math/rand source.
+func (l Logistic) Rand(rng *rand.Rand) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.Quantile(uniformOpen(rng))
+}
@@ -0,0 +1,36 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogisticClosedForms(t *testing.T) {
+	d := Logistic{Mu: 0, S: 1}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(0)", d.PDF(0), 0.25},
+		{"PDF(ln 3)", d.PDF(math.Log(3)), 3.0 / 16},
+		{"CDF(0)", d.CDF(0), 0.5},
+		{"CDF(ln 3)", d.CDF(math.Log(3)), 0.75},
+		{"Survival(ln 3)", d.Survival(math.Log(3)), 0.25},
+		{"Quantile(0.75)", d.Quantile(0.75), math.Log(3)},
+		{"shifted Quantile", Logistic{Mu: 2, S: 0.5}.Quantile(0.25), 2 - 0.5*math.Log(3)},
+		{"Variance", d.Variance(), math.Pi * math.Pi / 3},
+		{"Entropy", Logistic{Mu: 0, S: math.E}.Entropy(), 3},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	// extreme arguments must not overflow
+	if d.PDF(1000) != 0 || d.PDF(-1000) != 0 || d.CDF(-1000) != 0 || d.Survival(1000) != 0 {
+		t.Error("unexpected tail values")
+	}
+	if !math.IsNaN(Logistic{Mu: 0, S: 0}.PDF(0)) {
+		t.Error("expected NaN for invalid scale")
+	}
+}
@@ -0,0 +1,104 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// LogNormal is the distribution of exp(Y) where Y is normal with mean Mu and
+// standard deviation Sigma. Sigma must be > 0; methods return NaN otherwise.
+type LogNormal struct {
+	Mu, Sigma float64
+}
+
+func (l LogNormal) valid() bool {
+	return l.normal().valid()
+}
+
+func (l LogNormal) normal() Normal { return Normal{Mu: l.Mu, Sigma: l.Sigma} }
+
+// PDF returns the probability density at x; it is 0 for x ≤ 0.
+func (l LogNormal) PDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 || math.IsInf(x, 1) {
+		return 0
+	}
+	return l.normal().PDF(math.Log(x)) / x
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (l LogNormal) LogPDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	lx := math.Log(x)
+	return l.normal().LogPDF(lx) - lx
+}
+
+// CDF returns P(X ≤ x).
+func (l LogNormal) CDF(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	return l.normal().CDF(math.Log(x))
+}
+
+// Survival returns P(X > x).
+func (l LogNormal) Survival(x float64) float64 {
+	if !l.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	return l.normal().Survival(math.Log(x))
+}
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+func (l LogNormal) Quantile(p float64) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return math.Exp(l.normal().Quantile(p))
+}
+
+// Mean returns exp(Mu + Sigma²/2).
+func (l LogNormal) Mean() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return math.Exp(l.Mu + l.Sigma*l.Sigma/2)
+}
+
+// Variance returns (exp(Sigma²) - 1)·exp(2·Mu + Sigma²).
+func (l LogNormal) Variance() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	s2 := l.Sigma * l.Sigma
+	return math.Expm1(s2) * math.Exp(2*l.Mu+s2)
+}
+
+// Entropy returns Mu + ½·log(2πe·Sigma²).
+func (l LogNormal) Entropy() float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return l.Mu + l.normal().Entropy()
+}
+
+// Rand draws a random variate. A nil rng uses the global math/rand source.
+func (l LogNormal) Rand(rng *rand.Rand) float64 {
+	if !l.valid() {
+		return math.NaN()
+	}
+	return math.Exp(l.normal().Rand(rng))
+}
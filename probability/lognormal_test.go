@@ -0,0 +1,36 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogNormalClosedForms(t *testing.T) {
+	d := LogNormal{Mu: 0, Sigma: 1}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(1)", d.PDF(1), 1 / math.Sqrt(2*math.Pi)},
+		{"PDF(e)", d.PDF(math.E), math.Exp(-0.5) / (math.E * math.Sqrt(2*math.Pi))},
+		{"CDF(1)", d.CDF(1), 0.5},
+		{"Survival(e)", d.Survival(math.E), 0.15865525393145705},
+		{"Mean", d.Mean(), math.Exp(0.5)},
+		{"Variance", d.Variance(), (math.E - 1) * math.E},
+		{"Mean{2, 0.5}", LogNormal{Mu: 2, Sigma: 0.5}.Mean(), math.Exp(2.125)},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-12*math.Max(1, math.Abs(tt.want)) {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if q := d.Quantile(0.975); math.Abs(q-math.Exp(1.959963984540054)) > 1e-8*q {
+		t.Errorf("Quantile(0.975) = %v; want %v", q, math.Exp(1.959963984540054))
+	}
+	if d.PDF(0) != 0 || d.PDF(-1) != 0 || d.CDF(-1) != 0 || d.Survival(0) != 1 {
+		t.Error("LogNormal should put no mass on x ≤ 0")
+	}
+	if !math.IsNaN(LogNormal{Mu: 0, Sigma: -1}.PDF(1)) || !math.IsNaN(d.CDF(math.NaN())) {
+		t.Error("expected NaN for invalid input")
+	}
+}
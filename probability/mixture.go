@@ -0,0 +1,274 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Mixture is the finite mixture Σ Weights[i]·Components[i] of continuous
+// distributions, e.g. a two-Normal model of bimodal latencies. Weights must
+// be non-negative, sum to 1 (to within 1e-9) and match Components in length.
+// FitGaussianMixture estimates a Mixture of Normals from data.
+type Mixture struct {
+	Weights    []float64
+	Components []Continuous
+}
+
+func (m Mixture) valid() bool {
+	if len(m.Weights) == 0 || len(m.Weights) != len(m.Components) {
+		return false
+	}
+	sum := 0.0
+	for i, w := range m.Weights {
+		if !(w >= 0) || m.Components[i] == nil {
+			return false
+		}
+		sum += w
+	}
+	return math.Abs(sum-1) <= 1e-9
+}
+
+// PDF returns Σ wᵢ·fᵢ(x).
+func (m Mixture) PDF(x float64) float64 {
+	return math.Exp(m.LogPDF(x))
+}
+
+// LogPDF returns log Σ wᵢ·fᵢ(x), accumulated with log-sum-exp so that it
+// stays finite far in the tails.
+func (m Mixture) LogPDF(x float64) float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	terms := make([]float64, 0, len(m.Weights))
+	for i, w := range m.Weights {
+		if w > 0 {
+			terms = append(terms, math.Log(w)+m.Components[i].LogPDF(x))
+		}
+	}
+	return logSumExp(terms)
+}
+
+// CDF returns Σ wᵢ·Fᵢ(x).
+func (m Mixture) CDF(x float64) float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	p := 0.0
+	for i, w := range m.Weights {
+		if w > 0 {
+			p += w * m.Components[i].CDF(x)
+		}
+	}
+	return math.Min(1, p)
+}
+
+// Survival returns Σ wᵢ·Sᵢ(x).
+func (m Mixture) Survival(x float64) float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	q := 0.0
+	for i, w := range m.Weights {
+		if w > 0 {
+			q += w * m.Components[i].Survival(x)
+		}
+	}
+	return math.Min(1, q)
+}
+
+// Quantile solves CDF(x) = p numerically. The root lies between the
+// smallest and largest component quantiles at p, and that bracket is
+// bisected on the CDF for p ≤ ½ and on the Survival function above, so
+// both tails keep full precision.
+func (m Mixture) Quantile(p float64) float64 {
+	if !m.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	a, b := math.Inf(1), math.Inf(-1)
+	for i, w := range m.Weights {
+		if w > 0 {
+			q := m.Components[i].Quantile(p)
+			a, b = math.Min(a, q), math.Max(b, q)
+		}
+	}
+	if p == 0 {
+		return a
+	}
+	if p == 1 {
+		return b
+	}
+	// below reports whether x lies strictly below the quantile
+	below := func(x float64) bool { return m.CDF(x) < p }
+	if p > 0.5 {
+		below = func(x float64) bool { return m.Survival(x) > 1-p }
+	}
+	for i := 0; i < 200; i++ {
+		mid := a + (b-a)/2
+		if mid <= a || mid >= b {
+			break
+		}
+		if below(mid) {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+	return b
+}
+
+// Mean returns Σ wᵢ·μᵢ.
+func (m Mixture) Mean() float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	mean := 0.0
+	for i, w := range m.Weights {
+		if w > 0 {
+			mean += w * m.Components[i].Mean()
+		}
+	}
+	return mean
+}
+
+// Variance returns Σ wᵢ·(σᵢ² + μᵢ²) - μ², the law of total variance.
+func (m Mixture) Variance() float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	mean, second := m.Mean(), 0.0
+	for i, w := range m.Weights {
+		if w > 0 {
+			mu := m.Components[i].Mean()
+			second += w * (m.Components[i].Variance() + mu*mu)
+		}
+	}
+	return second - mean*mean
+}
+
+// Entropy returns -E[log f(X)], integrated numerically over the quantile
+// function since mixtures have no closed form.
+func (m Mixture) Entropy() float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	return -integrateUnit(func(u float64) float64 { return m.LogPDF(m.Quantile(u)) })
+}
+
+// Rand picks a component with probability Weights[i] and draws from it,
+// using rng (or the global source if rng is nil).
+func (m Mixture) Rand(rng *rand.Rand) float64 {
+	if !m.valid() {
+		return math.NaN()
+	}
+	return m.Components[pickWeighted(m.Weights, rng)].Rand(rng)
+}
+
+// MultivariateMixture is a finite mixture of multivariate normals with the
+// same dimension, as estimated by FitMultivariateGaussianMixture. Weights
+// must be non-negative, sum to 1 (to within 1e-9) and match Components in
+// length.
+type MultivariateMixture struct {
+	Weights    []float64
+	Components []MultivariateNormal
+}
+
+func (m MultivariateMixture) valid() bool {
+	if len(m.Weights) == 0 || len(m.Weights) != len(m.Components) {
+		return false
+	}
+	sum := 0.0
+	for i, w := range m.Weights {
+		if !(w >= 0) || m.Components[i].Dim() != m.Components[0].Dim() || m.Components[i].Dim() == 0 {
+			return false
+		}
+		sum += w
+	}
+	return math.Abs(sum-1) <= 1e-9
+}
+
+// Dim returns the dimension of the components, or 0 for invalid parameters.
+func (m MultivariateMixture) Dim() int {
+	if !m.valid() {
+		return 0
+	}
+	return m.Components[0].Dim()
+}
+
+// LogPDF returns log Σ wᵢ·fᵢ(x) by log-sum-exp. Returns NaN for invalid
+// parameters or if len(x) differs from the dimension.
+func (m MultivariateMixture) LogPDF(x []float64) float64 {
+	if !m.valid() || len(x) != m.Dim() {
+		return math.NaN()
+	}
+	terms := make([]float64, 0, len(m.Weights))
+	for i, w := range m.Weights {
+		if w > 0 {
+			terms = append(terms, math.Log(w)+m.Components[i].LogPDF(x))
+		}
+	}
+	return logSumExp(terms)
+}
+
+// PDF returns the density at x.
+func (m MultivariateMixture) PDF(x []float64) float64 {
+	return math.Exp(m.LogPDF(x))
+}
+
+// Mean returns Σ wᵢ·μᵢ, or nil for invalid parameters.
+func (m MultivariateMixture) Mean() []float64 {
+	if !m.valid() {
+		return nil
+	}
+	mean := make([]float64, m.Dim())
+	for i, w := range m.Weights {
+		for j, mu := range m.Components[i].mu {
+			mean[j] += w * mu
+		}
+	}
+	return mean
+}
+
+// Rand picks a component with probability Weights[i] and draws from it,
+// using rng (or the global source if rng is nil). Returns nil for invalid
+// parameters.
+func (m MultivariateMixture) Rand(rng *rand.Rand) []float64 {
+	if !m.valid() {
+		return nil
+	}
+	return m.Components[pickWeighted(m.Weights, rng)].Rand(rng)
+}
+
+// pickWeighted returns index i with probability weights[i].
+func pickWeighted(weights []float64, rng *rand.Rand) int {
+	u := uniformOpen(rng)
+	last := 0
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if u < w {
+			return i
+		}
+		u -= w
+		last = i
+	}
+	// rounding left u just above the total; use the last live component
+	return last
+}
+
+// logSumExp returns log Σ exp(terms[i]) without overflow, or -Inf for an
+// empty slice.
+func logSumExp(terms []float64) float64 {
+	top := math.Inf(-1)
+	for _, t := range terms {
+		top = math.Max(top, t)
+	}
+	if math.IsInf(top, 0) {
+		return top
+	}
+	sum := 0.0
+	for _, t := range terms {
+		sum += math.Exp(t - top)
+	}
+	return top + math.Log(sum)
+}
@@ -0,0 +1,116 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func bimodal() Mixture {
+	return Mixture{
+		Weights:    []float64{0.3, 0.7},
+		Components: []Continuous{Normal{Mu: -2, Sigma: 1}, Normal{Mu: 3, Sigma: 0.5}},
+	}
+}
+
+func TestMixtureDensityAndCDF(t *testing.T) {
+	m := bimodal()
+	a, b := Normal{Mu: -2, Sigma: 1}, Normal{Mu: 3, Sigma: 0.5}
+	for _, x := range []float64{-5, -2, 0.5, 3, 4} {
+		if got, want := m.PDF(x), 0.3*a.PDF(x)+0.7*b.PDF(x); math.Abs(got-want) > 1e-15 {
+			t.Errorf("PDF(%v) = %v; want %v", x, got, want)
+		}
+		if got, want := m.CDF(x), 0.3*a.CDF(x)+0.7*b.CDF(x); math.Abs(got-want) > 1e-15 {
+			t.Errorf("CDF(%v) = %v; want %v", x, got, want)
+		}
+	}
+	// far in the right tail only the wide component contributes
+	if got, want := m.LogPDF(-60), math.Log(0.3)+a.LogPDF(-60); math.Abs(got-want) > 1e-9 {
+		t.Errorf("LogPDF(-60) = %v; want %v", got, want)
+	}
+	if got, want := m.Mean(), 0.3*-2+0.7*3; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Mean() = %v; want %v", got, want)
+	}
+	if got, want := m.Variance(), 0.3*(1+4)+0.7*(0.25+9)-1.5*1.5; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Variance() = %v; want %v", got, want)
+	}
+}
+
+func TestMixtureQuantileTails(t *testing.T) {
+	m := bimodal()
+	for _, p := range []float64{1e-12, 0.3, 0.5, 0.9, 1 - 1e-12} {
+		x := m.Quantile(p)
+		if p <= 0.5 {
+			if got := m.CDF(x); math.Abs(got-p) > 1e-12*math.Max(p, 1e-3) {
+				t.Errorf("CDF(Quantile(%v)) = %v", p, got)
+			}
+		} else if got := m.Survival(x); math.Abs(got-(1-p)) > 1e-12*math.Max(1-p, 1e-3) {
+			t.Errorf("Survival(Quantile(%v)) = %v; want %v", p, got, 1-p)
+		}
+	}
+	if !math.IsInf(m.Quantile(0), -1) || !math.IsInf(m.Quantile(1), 1) {
+		t.Errorf("Quantile(0), Quantile(1) = %v, %v; want ∓Inf", m.Quantile(0), m.Quantile(1))
+	}
+}
+
+func TestMixtureSingleComponent(t *testing.T) {
+	g := Gamma{Alpha: 2, Beta: 3}
+	m := Mixture{Weights: []float64{1}, Components: []Continuous{g}}
+	if got, want := m.Entropy(), g.Entropy(); math.Abs(got-want) > 1e-8 {
+		t.Errorf("Entropy() = %v; want %v", got, want)
+	}
+	if got, want := m.Quantile(0.37), g.Quantile(0.37); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Quantile(0.37) = %v; want %v", got, want)
+	}
+}
+
+func TestMixtureRandWeights(t *testing.T) {
+	m := bimodal()
+	rng := rand.New(rand.NewSource(9))
+	const n = 100000
+	right := 0
+	for i := 0; i < n; i++ {
+		if m.Rand(rng) > 0.5 {
+			right++
+		}
+	}
+	want := 0.3*Normal{Mu: -2, Sigma: 1}.Survival(0.5) + 0.7*Normal{Mu: 3, Sigma: 0.5}.Survival(0.5)
+	if got := float64(right) / n; math.Abs(got-want) > 5*math.Sqrt(want*(1-want)/n) {
+		t.Errorf("fraction above 0.5 = %v; want %v", got, want)
+	}
+}
+
+func TestMultivariateMixture(t *testing.T) {
+	a, _ := NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 0}, {0, 1}})
+	b, _ := NewMultivariateNormal([]float64{4, 1}, [][]float64{{2, 0.5}, {0.5, 1}})
+	m := MultivariateMixture{Weights: []float64{0.4, 0.6}, Components: []MultivariateNormal{a, b}}
+	x := []float64{1, 0.5}
+	if got, want := m.PDF(x), 0.4*a.PDF(x)+0.6*b.PDF(x); math.Abs(got-want) > 1e-15 {
+		t.Errorf("PDF = %v; want %v", got, want)
+	}
+	if got := m.Mean(); math.Abs(got[0]-2.4) > 1e-15 || math.Abs(got[1]-0.6) > 1e-15 {
+		t.Errorf("Mean() = %v; want [2.4 0.6]", got)
+	}
+	if got := m.Rand(rand.New(rand.NewSource(1))); len(got) != 2 {
+		t.Errorf("Rand() = %v; want a 2-vector", got)
+	}
+	if got := m.LogPDF([]float64{1}); !math.IsNaN(got) {
+		t.Errorf("LogPDF with wrong dimension = %v; want NaN", got)
+	}
+}
+
+func TestMixtureInvalid(t *testing.T) {
+	for _, m := range []Mixture{
+		{},
+		{Weights: []float64{0.5, 0.6}, Components: []Continuous{Normal{Mu: 0, Sigma: 1}, Normal{Mu: 1, Sigma: 1}}},
+		{Weights: []float64{1}, Components: []Continuous{nil}},
+		{Weights: []float64{0.5, 0.5}, Components: []Continuous{Normal{Mu: 0, Sigma: 1}}},
+	} {
+		if !math.IsNaN(m.PDF(0)) || !math.IsNaN(m.CDF(0)) || !math.IsNaN(m.Quantile(0.5)) || !math.IsNaN(m.Rand(nil)) {
+			t.Errorf("%v should return NaN", m)
+		}
+	}
+	if (MultivariateMixture{}).Rand(nil) != nil {
+		t.Error("Rand on invalid MultivariateMixture should return nil")
+	}
+}
@@ -0,0 +1,458 @@
+package probability
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// EMOptions controls the expectation-maximization runs of
+// FitGaussianMixture and FitMultivariateGaussianMixture. Zero fields select
+// the defaults.
+type EMOptions struct {
+	MaxIter  int        // iteration cap per run; default 1000
+	Tol      float64    // stop once an iteration gains less than Tol·|LogLik|; default 1e-10
+	Restarts int        // k-means++ initialisations, keeping the best; default 5
+	Rand     *rand.Rand // drives the initialisations; nil uses the global source
+}
+
+func (o EMOptions) withDefaults() EMOptions {
+	if o.MaxIter <= 0 {
+		o.MaxIter = 1000
+	}
+	if !(o.Tol > 0) {
+		o.Tol = 1e-10
+	}
+	if o.Restarts <= 0 {
+		o.Restarts = 5
+	}
+	return o
+}
+
+// EMDiagnostics describes the best run of an EM fit. Trace holds the
+// log-likelihood before each M-step and at the end, which EM guarantees is
+// non-decreasing; Converged is false when MaxIter stopped the run first.
+type EMDiagnostics struct {
+	K          int // number of components
+	N          int // number of observations
+	LogLik     float64
+	AIC        float64 // 2p - 2·LogLik for p free parameters
+	BIC        float64 // p·log(n) - 2·LogLik
+	Iterations int
+	Converged  bool
+	Trace      []float64
+}
+
+// MixtureFit is a Gaussian mixture fitted to 1-D data. The components of
+// Mixture are Normal values, sorted by mean.
+type MixtureFit struct {
+	Mixture Mixture
+	EMDiagnostics
+}
+
+// MultivariateMixtureFit is a Gaussian mixture fitted to multivariate data.
+type MultivariateMixtureFit struct {
+	Mixture MultivariateMixture
+	EMDiagnostics
+}
+
+// FitGaussianMixture fits a k-component mixture of Normals to data by
+// expectation maximization from opts.Restarts k-means++ initialisations,
+// returning the run with the highest likelihood. Component variances are
+// floored at 1e-6 of the data variance so that no component collapses onto
+// a single point, and a component that loses all its weight is dropped, so
+// the fitted Mixture may have fewer than k components. Data must be finite,
+// with more than k observations and at least two distinct values.
+func FitGaussianMixture(data []float64, k int, opts EMOptions) (MixtureFit, error) {
+	if k < 1 {
+		return MixtureFit{}, errs.New(ErrInvalidParameter, "FitGaussianMixture: k must be ≥ 1")
+	}
+	if len(data) <= k {
+		return MixtureFit{}, errs.New(ErrInvalidParameter, "FitGaussianMixture: need more observations than components")
+	}
+	for _, x := range data {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return MixtureFit{}, errs.New(ErrInvalidParameter, "FitGaussianMixture: data must be finite")
+		}
+	}
+	_, sd := meanAndSD(data)
+	if sd == 0 {
+		return MixtureFit{}, errs.New(ErrInvalidParameter, "FitGaussianMixture: data must have at least two distinct values")
+	}
+	points := make([][]float64, len(data))
+	for i, x := range data {
+		points[i] = []float64{x}
+	}
+	floor := 1e-6 * sd * sd
+
+	best, diag, err := runEM(len(data), k, 3*k-1, opts, func(rng *rand.Rand) emModel {
+		m := &gaussianMixture1D{x: data, floor: floor, w: make([]float64, k), mu: make([]float64, k), sd: make([]float64, k)}
+		m.maximize(kMeansPlusPlus(points, k, rng))
+		return m
+	})
+	if err != nil {
+		return MixtureFit{}, fmt.Errorf("FitGaussianMixture: %w", err)
+	}
+	m := best.(*gaussianMixture1D)
+	order := make([]int, k)
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return m.mu[order[a]] < m.mu[order[b]] })
+	var mix Mixture
+	for _, j := range order {
+		if m.w[j] > 0 {
+			mix.Weights = append(mix.Weights, m.w[j])
+			mix.Components = append(mix.Components, Normal{Mu: m.mu[j], Sigma: m.sd[j]})
+		}
+	}
+	return MixtureFit{Mixture: mix, EMDiagnostics: diag}, nil
+}
+
+// FitMultivariateGaussianMixture fits a k-component mixture of multivariate
+// normals to the rows of data, as FitGaussianMixture does in one dimension.
+// A ridge of 1e-6 times the average variable variance is added to each
+// covariance matrix to keep it positive definite. Rows must be finite, of
+// equal non-zero length, and more numerous than k.
+func FitMultivariateGaussianMixture(data [][]float64, k int, opts EMOptions) (MultivariateMixtureFit, error) {
+	if k < 1 {
+		return MultivariateMixtureFit{}, errs.New(ErrInvalidParameter, "FitMultivariateGaussianMixture: k must be ≥ 1")
+	}
+	if len(data) <= k {
+		return MultivariateMixtureFit{}, errs.New(ErrInvalidParameter, "FitMultivariateGaussianMixture: need more observations than components")
+	}
+	d := len(data[0])
+	if d == 0 {
+		return MultivariateMixtureFit{}, errs.New(ErrEmptyInput, "FitMultivariateGaussianMixture: observations must not be empty")
+	}
+	spread := 0.0
+	for j := 0; j < d; j++ {
+		col := make([]float64, len(data))
+		for i, row := range data {
+			if len(row) != d {
+				return MultivariateMixtureFit{}, errs.New(ErrLengthMismatch, "FitMultivariateGaussianMixture: observations must have equal length")
+			}
+			if math.IsNaN(row[j]) || math.IsInf(row[j], 0) {
+				return MultivariateMixtureFit{}, errs.New(ErrInvalidParameter, "FitMultivariateGaussianMixture: data must be finite")
+			}
+			col[i] = row[j]
+		}
+		_, sd := meanAndSD(col)
+		spread += sd * sd / float64(d)
+	}
+	if spread == 0 {
+		return MultivariateMixtureFit{}, errs.New(ErrInvalidParameter, "FitMultivariateGaussianMixture: data must have at least two distinct rows")
+	}
+
+	params := (k - 1) + k*d + k*d*(d+1)/2
+	best, diag, err := runEM(len(data), k, params, opts, func(rng *rand.Rand) emModel {
+		m := &gaussianMixtureMV{x: data, d: d, ridge: 1e-6 * spread, w: make([]float64, k), comps: make([]MultivariateNormal, k)}
+		m.maximize(kMeansPlusPlus(data, k, rng))
+		return m
+	})
+	if err != nil {
+		return MultivariateMixtureFit{}, fmt.Errorf("FitMultivariateGaussianMixture: %w", err)
+	}
+	m := best.(*gaussianMixtureMV)
+	var mix MultivariateMixture
+	for j, w := range m.w {
+		if w > 0 {
+			mix.Weights = append(mix.Weights, w)
+			mix.Components = append(mix.Components, m.comps[j])
+		}
+	}
+	return MultivariateMixtureFit{Mixture: mix, EMDiagnostics: diag}, nil
+}
+
+// SelectGaussianMixture fits mixtures with 1 to maxK components and returns
+// the successful fits sorted by BIC, best first, so the number of components
+// is chosen by fits[0].K. It returns an error only when no fit succeeds.
+func SelectGaussianMixture(data []float64, maxK int, opts EMOptions) ([]MixtureFit, error) {
+	var fits []MixtureFit
+	var firstErr error
+	for k := 1; k <= maxK; k++ {
+		f, err := FitGaussianMixture(data, k, opts)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		fits = append(fits, f)
+	}
+	if len(fits) == 0 {
+		if firstErr == nil {
+			firstErr = errs.New(ErrInvalidParameter, "SelectGaussianMixture: maxK must be ≥ 1")
+		}
+		return nil, firstErr
+	}
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].BIC < fits[j].BIC })
+	return fits, nil
+}
+
+// SelectMultivariateGaussianMixture is SelectGaussianMixture for the rows of
+// multivariate data.
+func SelectMultivariateGaussianMixture(data [][]float64, maxK int, opts EMOptions) ([]MultivariateMixtureFit, error) {
+	var fits []MultivariateMixtureFit
+	var firstErr error
+	for k := 1; k <= maxK; k++ {
+		f, err := FitMultivariateGaussianMixture(data, k, opts)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		fits = append(fits, f)
+	}
+	if len(fits) == 0 {
+		if firstErr == nil {
+			firstErr = errs.New(ErrInvalidParameter, "SelectMultivariateGaussianMixture: maxK must be ≥ 1")
+		}
+		return nil, firstErr
+	}
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].BIC < fits[j].BIC })
+	return fits, nil
+}
+
+// emModel is a mixture whose parameters EM updates in place.
+type emModel interface {
+	// logJoint sets out[i][j] = log wⱼ + log fⱼ(xᵢ).
+	logJoint(out [][]float64)
+	// maximize sets the parameters from responsibilities r[i][j].
+	maximize(r [][]float64)
+}
+
+// runEM runs EM from opts.Restarts initial models and returns the one with
+// the highest final log-likelihood, with its diagnostics; params is the
+// number of free parameters used for AIC and BIC.
+func runEM(n, k, params int, opts EMOptions, initial func(rng *rand.Rand) emModel) (emModel, EMDiagnostics, error) {
+	opts = opts.withDefaults()
+	r := make([][]float64, n)
+	for i := range r {
+		r[i] = make([]float64, k)
+	}
+
+	var best emModel
+	bestDiag := EMDiagnostics{LogLik: math.Inf(-1)}
+	for run := 0; run < opts.Restarts; run++ {
+		m := initial(opts.Rand)
+		diag := EMDiagnostics{K: k, N: n}
+		var ll float64
+		for it := 0; ; it++ {
+			// E-step: turn the joint log densities into responsibilities
+			m.logJoint(r)
+			ll = 0
+			for _, row := range r {
+				lse := logSumExp(row)
+				ll += lse
+				for j := range row {
+					row[j] = math.Exp(row[j] - lse)
+				}
+			}
+			if math.IsNaN(ll) || math.IsInf(ll, 0) {
+				break
+			}
+			diag.Trace = append(diag.Trace, ll)
+			if it > 0 && ll-diag.Trace[it-1] <= opts.Tol*math.Abs(ll) {
+				diag.Converged = true
+				break
+			}
+			if it == opts.MaxIter {
+				break
+			}
+			m.maximize(r)
+			diag.Iterations++
+		}
+		if ll > bestDiag.LogLik {
+			diag.LogLik = ll
+			best, bestDiag = m, diag
+		}
+	}
+	if best == nil {
+		return nil, EMDiagnostics{}, errors.New("EM did not reach a finite likelihood")
+	}
+	p := float64(params)
+	bestDiag.AIC = 2*p - 2*bestDiag.LogLik
+	bestDiag.BIC = p*math.Log(float64(n)) - 2*bestDiag.LogLik
+	return best, bestDiag, nil
+}
+
+// kMeansPlusPlus picks k seeds from the points, each with probability
+// proportional to its squared distance from the nearest seed so far, and
+// returns the hard assignment of every point to its nearest seed as 0/1
+// responsibilities.
+func kMeansPlusPlus(points [][]float64, k int, rng *rand.Rand) [][]float64 {
+	n := len(points)
+	dist2 := func(a, b []float64) float64 {
+		s := 0.0
+		for i := range a {
+			s += (a[i] - b[i]) * (a[i] - b[i])
+		}
+		return s
+	}
+	seeds := []int{int(int63n(rng, int64(n)))}
+	nearest := make([]float64, n)
+	for i := range nearest {
+		nearest[i] = dist2(points[i], points[seeds[0]])
+	}
+	for len(seeds) < k {
+		total := 0.0
+		for _, d := range nearest {
+			total += d
+		}
+		next := int(int63n(rng, int64(n)))
+		if total > 0 {
+			u := uniformOpen(rng) * total
+			for i, d := range nearest {
+				if u -= d; u <= 0 && d > 0 {
+					next = i
+					break
+				}
+			}
+		}
+		seeds = append(seeds, next)
+		for i := range nearest {
+			nearest[i] = math.Min(nearest[i], dist2(points[i], points[next]))
+		}
+	}
+
+	r := make([][]float64, n)
+	for i := range r {
+		r[i] = make([]float64, k)
+		bestJ, bestD := 0, math.Inf(1)
+		for j, s := range seeds {
+			if d := dist2(points[i], points[s]); d < bestD {
+				bestJ, bestD = j, d
+			}
+		}
+		r[i][bestJ] = 1
+	}
+	return r
+}
+
+// gaussianMixture1D is the EM state of a mixture of Normals.
+type gaussianMixture1D struct {
+	x         []float64
+	floor     float64 // minimum component variance
+	w, mu, sd []float64
+}
+
+func (m *gaussianMixture1D) logJoint(out [][]float64) {
+	const halfLog2Pi = 0.9189385332046727
+	for i, x := range m.x {
+		for j, w := range m.w {
+			if w == 0 {
+				out[i][j] = math.Inf(-1)
+				continue
+			}
+			z := (x - m.mu[j]) / m.sd[j]
+			out[i][j] = math.Log(w) - 0.5*z*z - math.Log(m.sd[j]) - halfLog2Pi
+		}
+	}
+}
+
+// maximize leaves a component that has lost all its responsibility with
+// weight 0, which removes it from the rest of the run.
+func (m *gaussianMixture1D) maximize(r [][]float64) {
+	n := float64(len(m.x))
+	for j := range m.w {
+		nk, sum := 0.0, 0.0
+		for i, x := range m.x {
+			nk += r[i][j]
+			sum += r[i][j] * x
+		}
+		if nk == 0 {
+			m.w[j] = 0
+			continue
+		}
+		mu := sum / nk
+		ss := 0.0
+		for i, x := range m.x {
+			ss += r[i][j] * (x - mu) * (x - mu)
+		}
+		m.w[j], m.mu[j], m.sd[j] = nk/n, mu, math.Sqrt(math.Max(ss/nk, m.floor))
+	}
+}
+
+// gaussianMixtureMV is the EM state of a mixture of multivariate normals.
+type gaussianMixtureMV struct {
+	x     [][]float64
+	d     int
+	ridge float64 // added to every covariance diagonal
+	w     []float64
+	comps []MultivariateNormal
+}
+
+func (m *gaussianMixtureMV) logJoint(out [][]float64) {
+	for i, x := range m.x {
+		for j, w := range m.w {
+			if w == 0 {
+				out[i][j] = math.Inf(-1)
+				continue
+			}
+			out[i][j] = math.Log(w) + m.comps[j].LogPDF(x)
+		}
+	}
+}
+
+func (m *gaussianMixtureMV) maximize(r [][]float64) {
+	n := float64(len(m.x))
+	for j := range m.w {
+		nk := 0.0
+		mu := make([]float64, m.d)
+		for i, x := range m.x {
+			nk += r[i][j]
+			for a := range mu {
+				mu[a] += r[i][j] * x[a]
+			}
+		}
+		if nk == 0 {
+			m.w[j] = 0
+			continue
+		}
+		for a := range mu {
+			mu[a] /= nk
+		}
+		cov := make([][]float64, m.d)
+		for a := range cov {
+			cov[a] = make([]float64, m.d)
+		}
+		for i, x := range m.x {
+			if r[i][j] == 0 {
+				continue
+			}
+			for a := 0; a < m.d; a++ {
+				da := r[i][j] * (x[a] - mu[a])
+				for b := 0; b <= a; b++ {
+					cov[a][b] += da * (x[b] - mu[b])
+				}
+			}
+		}
+		for a := 0; a < m.d; a++ {
+			for b := 0; b <= a; b++ {
+				cov[a][b] /= nk
+				cov[b][a] = cov[a][b]
+			}
+			cov[a][a] += m.ridge
+		}
+		comp, err := NewMultivariateNormal(mu, cov)
+		if err != nil {
+			m.w[j] = 0
+			continue
+		}
+		m.w[j], m.comps[j] = nk/n, comp
+	}
+	// renormalise in case a component was dropped above
+	total := 0.0
+	for _, w := range m.w {
+		total += w
+	}
+	for j := range m.w {
+		m.w[j] /= total
+	}
+}
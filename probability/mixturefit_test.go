@@ -0,0 +1,173 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitGaussianMixtureRecovers(t *testing.T) {
+	truth := bimodal()
+	data := Sample(truth, 4000, rand.New(rand.NewSource(21)))
+	fit, err := FitGaussianMixture(data, 2, EMOptions{Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fit.Converged || fit.Iterations == 0 || len(fit.Trace) != fit.Iterations+1 {
+		t.Errorf("diagnostics: converged=%v iterations=%d trace=%d", fit.Converged, fit.Iterations, len(fit.Trace))
+	}
+	for i := 1; i < len(fit.Trace); i++ {
+		if fit.Trace[i] < fit.Trace[i-1]-1e-9*math.Abs(fit.Trace[i-1]) {
+			t.Fatalf("log-likelihood decreased at iteration %d: %v → %v", i, fit.Trace[i-1], fit.Trace[i])
+		}
+	}
+	if fit.LogLik != fit.Trace[len(fit.Trace)-1] {
+		t.Errorf("LogLik = %v; want last trace value %v", fit.LogLik, fit.Trace[len(fit.Trace)-1])
+	}
+
+	m := fit.Mixture
+	if len(m.Components) != 2 {
+		t.Fatalf("got %d components; want 2", len(m.Components))
+	}
+	for i, want := range truth.Components {
+		got := m.Components[i].(Normal)
+		w := want.(Normal)
+		se := w.Sigma / math.Sqrt(truth.Weights[i]*4000)
+		if math.Abs(got.Mu-w.Mu) > 5*se || math.Abs(got.Sigma-w.Sigma) > 5*se {
+			t.Errorf("component %d = %v; want %v", i, got, w)
+		}
+		if math.Abs(m.Weights[i]-truth.Weights[i]) > 0.04 {
+			t.Errorf("weight %d = %v; want %v", i, m.Weights[i], truth.Weights[i])
+		}
+	}
+
+	// the log-likelihood reported matches the fitted mixture
+	ll := 0.0
+	for _, x := range data {
+		ll += m.LogPDF(x)
+	}
+	if math.Abs(ll-fit.LogLik) > 1e-8*math.Abs(ll) {
+		t.Errorf("LogLik = %v; recomputed %v", fit.LogLik, ll)
+	}
+	if p := 5.0; math.Abs(fit.BIC-(p*math.Log(4000)-2*ll)) > 1e-6 || math.Abs(fit.AIC-(2*p-2*ll)) > 1e-6 {
+		t.Errorf("AIC/BIC = %v/%v", fit.AIC, fit.BIC)
+	}
+}
+
+func TestFitGaussianMixtureSingleComponentIsMLE(t *testing.T) {
+	data := []float64{1.2, 3.4, 2.2, 0.7, 5.1, 2.9, 3.3}
+	fit, err := FitGaussianMixture(data, 1, EMOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	norm, _ := Fit(data, FamilyNormal)
+	got, want := fit.Mixture.Components[0].(Normal), norm.Dist.(Normal)
+	if math.Abs(got.Mu-want.Mu) > 1e-12 || math.Abs(got.Sigma-want.Sigma) > 1e-12 {
+		t.Errorf("k=1 fit = %v; want %v", got, want)
+	}
+	if math.Abs(fit.LogLik-norm.LogLik) > 1e-9 {
+		t.Errorf("LogLik = %v; want %v", fit.LogLik, norm.LogLik)
+	}
+}
+
+func TestSelectGaussianMixture(t *testing.T) {
+	data := Sample(bimodal(), 2000, rand.New(rand.NewSource(4)))
+	fits, err := SelectGaussianMixture(data, 4, EMOptions{Tol: 1e-8, Restarts: 2, Rand: rand.New(rand.NewSource(2))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fits) != 4 || fits[0].K != 2 {
+		t.Fatalf("BIC picked K=%d from %d fits; want 2", fits[0].K, len(fits))
+	}
+	for i := 1; i < len(fits); i++ {
+		if fits[i].BIC < fits[i-1].BIC {
+			t.Errorf("fits not sorted by BIC: %v then %v", fits[i-1].BIC, fits[i].BIC)
+		}
+	}
+
+	unimodal := Sample(Normal{Mu: 1, Sigma: 2}, 2000, rand.New(rand.NewSource(5)))
+	fits, err = SelectGaussianMixture(unimodal, 3, EMOptions{Tol: 1e-8, Restarts: 2, Rand: rand.New(rand.NewSource(2))})
+	if err != nil || fits[0].K != 1 {
+		t.Errorf("unimodal data: BIC picked K=%d (err %v); want 1", fits[0].K, err)
+	}
+}
+
+func TestFitMultivariateGaussianMixture(t *testing.T) {
+	a, _ := NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 0.3}, {0.3, 0.5}})
+	b, _ := NewMultivariateNormal([]float64{5, 3}, [][]float64{{0.8, -0.2}, {-0.2, 1.2}})
+	truth := MultivariateMixture{Weights: []float64{0.35, 0.65}, Components: []MultivariateNormal{a, b}}
+	rng := rand.New(rand.NewSource(8))
+	data := make([][]float64, 1500)
+	for i := range data {
+		data[i] = truth.Rand(rng)
+	}
+
+	fits, err := SelectMultivariateGaussianMixture(data, 3, EMOptions{Tol: 1e-8, Restarts: 2, Rand: rand.New(rand.NewSource(3))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fit := fits[0]
+	if fit.K != 2 || !fit.Converged {
+		t.Fatalf("BIC picked K=%d (converged %v); want 2", fit.K, fit.Converged)
+	}
+	// match the fitted components to the truth by the sign of the first mean
+	m := fit.Mixture
+	if m.Components[0].Mean()[0] > m.Components[1].Mean()[0] {
+		m.Components[0], m.Components[1] = m.Components[1], m.Components[0]
+		m.Weights[0], m.Weights[1] = m.Weights[1], m.Weights[0]
+	}
+	for i, want := range truth.Components {
+		got := m.Components[i]
+		for j := range want.Mean() {
+			if math.Abs(got.Mean()[j]-want.Mean()[j]) > 0.1 {
+				t.Errorf("component %d mean = %v; want %v", i, got.Mean(), want.Mean())
+			}
+			for l := range want.Mean() {
+				if math.Abs(got.Covariance()[j][l]-want.Covariance()[j][l]) > 0.15 {
+					t.Errorf("component %d covariance = %v; want %v", i, got.Covariance(), want.Covariance())
+				}
+			}
+		}
+		if math.Abs(m.Weights[i]-truth.Weights[i]) > 0.03 {
+			t.Errorf("weight %d = %v; want %v", i, m.Weights[i], truth.Weights[i])
+		}
+	}
+}
+
+func TestFitGaussianMixtureMaxIter(t *testing.T) {
+	data := Sample(bimodal(), 500, rand.New(rand.NewSource(6)))
+	fit, err := FitGaussianMixture(data, 3, EMOptions{MaxIter: 2, Restarts: 1, Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fit.Converged || fit.Iterations != 2 || len(fit.Trace) != 3 {
+		t.Errorf("converged=%v iterations=%d trace=%d; want false, 2, 3", fit.Converged, fit.Iterations, len(fit.Trace))
+	}
+}
+
+func TestFitGaussianMixtureErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+		k    int
+	}{
+		{"k zero", []float64{1, 2, 3}, 0},
+		{"too few points", []float64{1, 2}, 2},
+		{"constant", []float64{4, 4, 4, 4}, 2},
+		{"NaN", []float64{1, math.NaN(), 3}, 1},
+	}
+	for _, tt := range tests {
+		if _, err := FitGaussianMixture(tt.data, tt.k, EMOptions{}); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if _, err := SelectGaussianMixture([]float64{1, 2, 3}, 0, EMOptions{}); err == nil {
+		t.Error("SelectGaussianMixture with maxK 0 should fail")
+	}
+	if _, err := FitMultivariateGaussianMixture([][]float64{{1, 2}, {3}, {4, 5}}, 1, EMOptions{}); err == nil {
+		t.Error("ragged rows should fail")
+	}
+	if _, err := FitMultivariateGaussianMixture([][]float64{{1, 2}, {1, 2}, {1, 2}}, 1, EMOptions{}); err == nil {
+		t.Error("identical rows should fail")
+	}
+}
@@ -0,0 +1,127 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Multinomial is the distribution of the category counts in N independent
+// trials, each landing in category i with probability P[i]. N must be ≥ 0,
+// and P must be non-negative and sum to 1 (to within 1e-9).
+//
+// Multinomial is multivariate, so it does not implement Discrete; Marginal
+// returns the binomial distribution of a single count, which provides the
+// CDF and quantile of each category.
+type Multinomial struct {
+	N int
+	P []float64
+}
+
+func (m Multinomial) valid() bool {
+	if m.N < 0 || len(m.P) == 0 {
+		return false
+	}
+	sum := 0.0
+	for _, p := range m.P {
+		if !(p >= 0) {
+			return false
+		}
+		sum += p
+	}
+	return math.Abs(sum-1) <= 1e-9
+}
+
+// LogPMF returns log P(X = x). It is -Inf when the counts are negative or do
+// not sum to N, and NaN for invalid parameters or a length mismatch.
+func (m Multinomial) LogPMF(x []int) float64 {
+	if !m.valid() || len(x) != len(m.P) {
+		return math.NaN()
+	}
+	total := 0
+	lp := LogFactorial(m.N)
+	for i, k := range x {
+		if k < 0 {
+			return math.Inf(-1)
+		}
+		total += k
+		if k == 0 {
+			continue
+		}
+		lp += float64(k)*math.Log(m.P[i]) - LogFactorial(k)
+	}
+	if total != m.N {
+		return math.Inf(-1)
+	}
+	return lp
+}
+
+// PMF returns P(X = x) = N!/(x₁!…x_k!)·Π P[i]^x[i], evaluated in log space.
+func (m Multinomial) PMF(x []int) float64 {
+	return math.Exp(m.LogPMF(x))
+}
+
+// Marginal returns the binomial distribution of the count in category i.
+func (m Multinomial) Marginal(i int) Binomial {
+	if !m.valid() || i < 0 || i >= len(m.P) {
+		return Binomial{N: -1}
+	}
+	return Binomial{N: m.N, P: math.Min(m.P[i], 1)}
+}
+
+// Mean returns the expected counts N·P[i], or nil for invalid parameters.
+func (m Multinomial) Mean() []float64 {
+	if !m.valid() {
+		return nil
+	}
+	mean := make([]float64, len(m.P))
+	for i, p := range m.P {
+		mean[i] = float64(m.N) * p
+	}
+	return mean
+}
+
+// Covariance returns the covariance matrix with N·P[i]·(1-P[i]) on the
+// diagonal and -N·P[i]·P[j] elsewhere, or nil for invalid parameters.
+func (m Multinomial) Covariance() [][]float64 {
+	if !m.valid() {
+		return nil
+	}
+	n := float64(m.N)
+	cov := make([][]float64, len(m.P))
+	for i, pi := range m.P {
+		cov[i] = make([]float64, len(m.P))
+		for j, pj := range m.P {
+			if i == j {
+				cov[i][j] = n * pi * (1 - pi)
+			} else {
+				cov[i][j] = -n * pi * pj
+			}
+		}
+	}
+	return cov
+}
+
+// Rand draws a vector of counts as a sequence of conditional binomial
+// variates, or returns nil for invalid parameters.
+// A nil rng uses the global math/rand source.
+func (m Multinomial) Rand(rng *rand.Rand) []int {
+	if !m.valid() {
+		return nil
+	}
+	counts := make([]int, len(m.P))
+	remaining, mass := m.N, 1.0
+	for i, p := range m.P {
+		if remaining == 0 {
+			break
+		}
+		if i == len(m.P)-1 || p >= mass {
+			counts[i] = remaining
+			break
+		}
+		k := int(Binomial{N: remaining, P: p / mass}.Rand(rng))
+		counts[i] = k
+		remaining -= k
+		mass -= p
+	}
+	return counts
+}
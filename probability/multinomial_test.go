@@ -0,0 +1,79 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMultinomialPMF(t *testing.T) {
+	m := Multinomial{N: 10, P: []float64{0.2, 0.3, 0.5}}
+	want := 2520 * 0.04 * 0.027 * 0.03125 // 10!/(2!3!5!)·0.2²·0.3³·0.5⁵
+	if got := m.PMF([]int{2, 3, 5}); math.Abs(got-want) > 1e-14 {
+		t.Errorf("PMF = %v; want %v", got, want)
+	}
+
+	total := 0.0
+	for a := 0; a <= 10; a++ {
+		for b := 0; a+b <= 10; b++ {
+			total += m.PMF([]int{a, b, 10 - a - b})
+		}
+	}
+	if math.Abs(total-1) > 1e-12 {
+		t.Errorf("PMF sums to %v", total)
+	}
+
+	if m.PMF([]int{2, 3, 4}) != 0 || m.PMF([]int{-1, 6, 5}) != 0 {
+		t.Error("counts must be non-negative and sum to N")
+	}
+	if !math.IsNaN(m.PMF([]int{10})) || !math.IsNaN(Multinomial{N: 1, P: []float64{0.5, 0.6}}.PMF([]int{1, 0})) {
+		t.Error("expected NaN for a length mismatch or probabilities not summing to 1")
+	}
+
+	// large N stays finite in log space
+	big := Multinomial{N: 3000000, P: []float64{0.5, 0.5}}
+	if got := big.LogPMF([]int{1500000, 1500000}); math.IsInf(got, 0) || math.IsNaN(got) {
+		t.Errorf("LogPMF for N = 3e6 is %v", got)
+	}
+}
+
+func TestMultinomialMomentsAndMarginal(t *testing.T) {
+	m := Multinomial{N: 40, P: []float64{0.1, 0.6, 0.3}}
+	cov := m.Covariance()
+	if mean := m.Mean(); mean[1] != 24 {
+		t.Errorf("Mean = %v", mean)
+	}
+	if math.Abs(cov[0][0]-3.6) > 1e-12 || math.Abs(cov[0][2]+1.2) > 1e-12 {
+		t.Errorf("Covariance = %v", cov)
+	}
+	if got := m.Marginal(1); got != (Binomial{N: 40, P: 0.6}) {
+		t.Errorf("Marginal(1) = %v", got)
+	}
+
+	rng := rand.New(rand.NewSource(8))
+	const draws = 20000
+	sums := make([]float64, 3)
+	cross := 0.0
+	for i := 0; i < draws; i++ {
+		x := m.Rand(rng)
+		if x[0]+x[1]+x[2] != 40 {
+			t.Fatalf("counts %v do not sum to N", x)
+		}
+		for j, k := range x {
+			sums[j] += float64(k)
+		}
+		cross += float64(x[0]) * float64(x[2])
+	}
+	for j, mu := range m.Mean() {
+		if se := math.Sqrt(cov[j][j] / draws); math.Abs(sums[j]/draws-mu) > 5*se {
+			t.Errorf("sample mean of count %d = %v; want %v", j, sums[j]/draws, mu)
+		}
+	}
+	if c := cross/draws - sums[0]/draws*sums[2]/draws; math.Abs(c-cov[0][2]) > 0.2 {
+		t.Errorf("sample covariance = %v; want %v", c, cov[0][2])
+	}
+
+	if (Multinomial{N: 5, P: nil}).Rand(rng) != nil || (Multinomial{N: -1, P: []float64{1}}).Mean() != nil {
+		t.Error("expected nil for invalid parameters")
+	}
+}
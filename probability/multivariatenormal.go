@@ -0,0 +1,261 @@
+package probability
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+	"github.com/cyber-mountain-man/statistical-go/internal/linalg"
+)
+
+// MultivariateNormal is the d-dimensional normal distribution with mean
+// vector μ and symmetric positive-definite covariance matrix Σ.
+//
+// Unlike the univariate types it is built with NewMultivariateNormal, which
+// validates the parameters and caches the Cholesky factor Σ = L·Lᵀ used by
+// every density, distance and sampling call. It is multivariate, so it does
+// not implement Continuous.
+type MultivariateNormal struct {
+	mu     []float64
+	sigma  [][]float64
+	chol   [][]float64
+	logDet float64
+}
+
+// NewMultivariateNormal returns the multivariate normal with mean mu and
+// covariance sigma. It returns an error when the dimensions disagree or
+// sigma is not symmetric positive definite. The inputs are copied.
+func NewMultivariateNormal(mu []float64, sigma [][]float64) (MultivariateNormal, error) {
+	d := len(mu)
+	if d == 0 {
+		return MultivariateNormal{}, errs.New(ErrEmptyInput, "MultivariateNormal: mean must not be empty")
+	}
+	for _, m := range mu {
+		if math.IsNaN(m) || math.IsInf(m, 0) {
+			return MultivariateNormal{}, errs.New(ErrInvalidParameter, "MultivariateNormal: mean must be finite")
+		}
+	}
+	chol, err := choleskySPD(sigma, d)
+	if err != nil {
+		return MultivariateNormal{}, fmt.Errorf("MultivariateNormal: covariance %w", err)
+	}
+	return MultivariateNormal{
+		mu:     append([]float64(nil), mu...),
+		sigma:  copyMatrix(sigma),
+		chol:   chol,
+		logDet: linalg.LogDetCholesky(chol),
+	}, nil
+}
+
+// choleskySPD returns the Cholesky factor of a, after checking that a is
+// d×d and symmetric. The error text completes a sentence about the matrix.
+func choleskySPD(a [][]float64, d int) ([][]float64, error) {
+	if len(a) != d {
+		return nil, errs.New(ErrLengthMismatch, "must be d×d")
+	}
+	for i := range a {
+		if len(a[i]) != d {
+			return nil, errs.New(ErrLengthMismatch, "must be d×d")
+		}
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j < i; j++ {
+			tol := 1e-10 * math.Sqrt(math.Abs(a[i][i]*a[j][j]))
+			if !(math.Abs(a[i][j]-a[j][i]) <= tol) {
+				return nil, errs.New(ErrInvalidParameter, "must be symmetric")
+			}
+		}
+	}
+	chol, err := linalg.Cholesky(a)
+	if err != nil {
+		return nil, errs.New(ErrInvalidParameter, "must be positive definite")
+	}
+	return chol, nil
+}
+
+func copyMatrix(a [][]float64) [][]float64 {
+	out := make([][]float64, len(a))
+	for i := range a {
+		out[i] = append([]float64(nil), a[i]...)
+	}
+	return out
+}
+
+// Dim returns the dimension d.
+func (m MultivariateNormal) Dim() int { return len(m.mu) }
+
+// Mean returns a copy of the mean vector μ.
+func (m MultivariateNormal) Mean() []float64 { return append([]float64(nil), m.mu...) }
+
+// Covariance returns a copy of the covariance matrix Σ.
+func (m MultivariateNormal) Covariance() [][]float64 { return copyMatrix(m.sigma) }
+
+// whiten returns z = L⁻¹(x - μ), so that ‖z‖² is the squared Mahalanobis distance.
+func (m MultivariateNormal) whiten(x []float64) []float64 {
+	diff := make([]float64, len(x))
+	for i := range x {
+		diff[i] = x[i] - m.mu[i]
+	}
+	return linalg.SolveLower(m.chol, diff)
+}
+
+// Mahalanobis returns the Mahalanobis distance √((x-μ)ᵀ Σ⁻¹ (x-μ)) of x from
+// the mean. Returns NaN if len(x) differs from the dimension.
+func (m MultivariateNormal) Mahalanobis(x []float64) float64 {
+	if len(x) != m.Dim() || m.Dim() == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, z := range m.whiten(x) {
+		sum += z * z
+	}
+	return math.Sqrt(sum)
+}
+
+// LogPDF returns log f(x) = -½(d·log 2π + log det Σ + (x-μ)ᵀ Σ⁻¹ (x-μ)).
+// Returns NaN if len(x) differs from the dimension.
+func (m MultivariateNormal) LogPDF(x []float64) float64 {
+	d2 := m.Mahalanobis(x)
+	if math.IsNaN(d2) {
+		return math.NaN()
+	}
+	return -0.5 * (float64(m.Dim())*math.Log(2*math.Pi) + m.logDet + d2*d2)
+}
+
+// PDF returns the density at x.
+func (m MultivariateNormal) PDF(x []float64) float64 {
+	return math.Exp(m.LogPDF(x))
+}
+
+// Entropy returns ½·(d·(1 + log 2π) + log det Σ) in nats.
+func (m MultivariateNormal) Entropy() float64 {
+	if m.Dim() == 0 {
+		return math.NaN()
+	}
+	return 0.5 * (float64(m.Dim())*(1+math.Log(2*math.Pi)) + m.logDet)
+}
+
+// Marginal returns the distribution of the components idx, in that order.
+// It returns an error for an empty, out-of-range or repeated index.
+func (m MultivariateNormal) Marginal(idx []int) (MultivariateNormal, error) {
+	if err := m.checkIndices(idx); err != nil {
+		return MultivariateNormal{}, err
+	}
+	mu := make([]float64, len(idx))
+	sigma := make([][]float64, len(idx))
+	for a, i := range idx {
+		mu[a] = m.mu[i]
+		sigma[a] = make([]float64, len(idx))
+		for b, j := range idx {
+			sigma[a][b] = m.sigma[i][j]
+		}
+	}
+	return NewMultivariateNormal(mu, sigma)
+}
+
+// Conditional returns the distribution of the remaining components, in
+// increasing index order, given that component given[i] equals values[i]:
+//
+//	μ' = μ₂ + Σ₂₁ Σ₁₁⁻¹ (v - μ₁),   Σ' = Σ₂₂ - Σ₂₁ Σ₁₁⁻¹ Σ₁₂
+//
+// It returns an error for mismatched lengths, invalid indices, or when every
+// component is conditioned on.
+func (m MultivariateNormal) Conditional(given []int, values []float64) (MultivariateNormal, error) {
+	if len(given) != len(values) {
+		return MultivariateNormal{}, errs.New(ErrLengthMismatch, "MultivariateNormal: given and values must have the same length")
+	}
+	if err := m.checkIndices(given); err != nil {
+		return MultivariateNormal{}, err
+	}
+	isGiven := make([]bool, m.Dim())
+	for _, i := range given {
+		isGiven[i] = true
+	}
+	var rest []int
+	for i, g := range isGiven {
+		if !g {
+			rest = append(rest, i)
+		}
+	}
+	if len(rest) == 0 {
+		return MultivariateNormal{}, errs.New(ErrInvalidParameter, "MultivariateNormal: cannot condition on every component")
+	}
+
+	s11 := make([][]float64, len(given))
+	diff := make([]float64, len(given))
+	for a, i := range given {
+		s11[a] = make([]float64, len(given))
+		for b, j := range given {
+			s11[a][b] = m.sigma[i][j]
+		}
+		diff[a] = values[a] - m.mu[i]
+	}
+	l11, err := linalg.Cholesky(s11)
+	if err != nil {
+		return MultivariateNormal{}, errs.New(ErrInvalidParameter, "MultivariateNormal: covariance must be positive definite")
+	}
+
+	// w[r] = L₁₁⁻¹ Σ₁r for each remaining component r, so that
+	// Σ_r1 Σ₁₁⁻¹ Σ₁s = w[r]·w[s] and Σ_r1 Σ₁₁⁻¹ (v-μ₁) = w[r]·L₁₁⁻¹(v-μ₁).
+	w := make([][]float64, len(rest))
+	for a, r := range rest {
+		col := make([]float64, len(given))
+		for b, i := range given {
+			col[b] = m.sigma[i][r]
+		}
+		w[a] = linalg.SolveLower(l11, col)
+	}
+	z := linalg.SolveLower(l11, diff)
+
+	mu := make([]float64, len(rest))
+	sigma := make([][]float64, len(rest))
+	for a, r := range rest {
+		mu[a] = m.mu[r] + dot(w[a], z)
+		sigma[a] = make([]float64, len(rest))
+		for b, s := range rest {
+			sigma[a][b] = m.sigma[r][s] - dot(w[a], w[b])
+		}
+	}
+	return NewMultivariateNormal(mu, sigma)
+}
+
+func (m MultivariateNormal) checkIndices(idx []int) error {
+	if len(idx) == 0 {
+		return errs.New(ErrEmptyInput, "MultivariateNormal: no components selected")
+	}
+	seen := make([]bool, m.Dim())
+	for _, i := range idx {
+		if i < 0 || i >= m.Dim() || seen[i] {
+			return errs.New(ErrInvalidParameter, "MultivariateNormal: component index out of range or repeated")
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// Rand draws a correlated sample μ + L·z with z standard normal, using rng
+// (or the global source if rng is nil). Returns nil for a zero-value
+// MultivariateNormal.
+func (m MultivariateNormal) Rand(rng *rand.Rand) []float64 {
+	if m.Dim() == 0 {
+		return nil
+	}
+	z := make([]float64, m.Dim())
+	for i := range z {
+		z[i] = normFloat(rng)
+	}
+	x := linalg.MatVecMul(m.chol, z)
+	for i := range x {
+		x[i] += m.mu[i]
+	}
+	return x
+}
@@ -0,0 +1,187 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func newTestMVN(t *testing.T) MultivariateNormal {
+	t.Helper()
+	m, err := NewMultivariateNormal(
+		[]float64{1, -2, 0.5},
+		[][]float64{
+			{4, 1.2, -0.8},
+			{1.2, 2, 0.3},
+			{-0.8, 0.3, 1},
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return m
+}
+
+func TestMultivariateNormalBivariate(t *testing.T) {
+	// closed-form bivariate density with σ₁=2, σ₂=1, ρ=0.6
+	s1, s2, rho := 2.0, 1.0, 0.6
+	m, err := NewMultivariateNormal([]float64{1, 3}, [][]float64{
+		{s1 * s1, rho * s1 * s2},
+		{rho * s1 * s2, s2 * s2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x := []float64{2.5, 2.2}
+	u, v := (x[0]-1)/s1, (x[1]-3)/s2
+	q := (u*u - 2*rho*u*v + v*v) / (1 - rho*rho)
+	want := -math.Log(2*math.Pi*s1*s2*math.Sqrt(1-rho*rho)) - q/2
+
+	if got := m.LogPDF(x); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogPDF = %v; want %v", got, want)
+	}
+	if got := m.PDF(x); math.Abs(got-math.Exp(want)) > 1e-14 {
+		t.Errorf("PDF = %v; want %v", got, math.Exp(want))
+	}
+	if got := m.Mahalanobis(x); math.Abs(got-math.Sqrt(q)) > 1e-12 {
+		t.Errorf("Mahalanobis = %v; want %v", got, math.Sqrt(q))
+	}
+
+	// X₁ | X₂ = x₂ ~ N(μ₁ + ρσ₁/σ₂·(x₂-μ₂), σ₁²(1-ρ²))
+	c, err := m.Conditional([]int{1}, []float64{x[1]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := c.Mean()[0], 1+rho*s1/s2*(x[1]-3); math.Abs(got-want) > 1e-12 {
+		t.Errorf("conditional mean = %v; want %v", got, want)
+	}
+	if got, want := c.Covariance()[0][0], s1*s1*(1-rho*rho); math.Abs(got-want) > 1e-12 {
+		t.Errorf("conditional variance = %v; want %v", got, want)
+	}
+}
+
+func TestMultivariateNormalUnivariate(t *testing.T) {
+	m, err := NewMultivariateNormal([]float64{1}, [][]float64{{4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n := Normal{Mu: 1, Sigma: 2}
+	for _, x := range []float64{-3, 0.5, 1, 4} {
+		if got, want := m.LogPDF([]float64{x}), n.LogPDF(x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("LogPDF(%v) = %v; want %v", x, got, want)
+		}
+	}
+	if got, want := m.Entropy(), n.Entropy(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Entropy = %v; want %v", got, want)
+	}
+}
+
+func TestMultivariateNormalMarginalConditional(t *testing.T) {
+	m := newTestMVN(t)
+
+	marg, err := m.Marginal([]int{2, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantMu := []float64{0.5, 1}
+	wantSigma := [][]float64{{1, -0.8}, {-0.8, 4}}
+	for i := range wantMu {
+		if marg.Mean()[i] != wantMu[i] {
+			t.Errorf("marginal mean = %v; want %v", marg.Mean(), wantMu)
+		}
+		for j := range wantMu {
+			if marg.Covariance()[i][j] != wantSigma[i][j] {
+				t.Errorf("marginal covariance = %v; want %v", marg.Covariance(), wantSigma)
+			}
+		}
+	}
+
+	// f(x) = f(x₀, x₂)·f(x₁ | x₀, x₂)
+	x := []float64{0.3, -1.1, 1.4}
+	given, err := m.Marginal([]int{0, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cond, err := m.Conditional([]int{0, 2}, []float64{x[0], x[2]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := given.LogPDF([]float64{x[0], x[2]}) + cond.LogPDF([]float64{x[1]})
+	if want := m.LogPDF(x); math.Abs(got-want) > 1e-12 {
+		t.Errorf("marginal + conditional log density = %v; want %v", got, want)
+	}
+}
+
+func TestMultivariateNormalRand(t *testing.T) {
+	m := newTestMVN(t)
+	rng := rand.New(rand.NewSource(7))
+	const n = 200000
+	d := m.Dim()
+	sum := make([]float64, d)
+	cross := make([][]float64, d)
+	for i := range cross {
+		cross[i] = make([]float64, d)
+	}
+	for s := 0; s < n; s++ {
+		x := m.Rand(rng)
+		for i := 0; i < d; i++ {
+			sum[i] += x[i]
+			for j := 0; j < d; j++ {
+				cross[i][j] += x[i] * x[j]
+			}
+		}
+	}
+	mu, sigma := m.Mean(), m.Covariance()
+	for i := 0; i < d; i++ {
+		mean := sum[i] / n
+		if se := math.Sqrt(sigma[i][i] / n); math.Abs(mean-mu[i]) > 5*se {
+			t.Errorf("sample mean[%d] = %v; want %v", i, mean, mu[i])
+		}
+		for j := 0; j < d; j++ {
+			cov := cross[i][j]/n - sum[i]/n*sum[j]/n
+			if math.Abs(cov-sigma[i][j]) > 0.03*math.Sqrt(sigma[i][i]*sigma[j][j]) {
+				t.Errorf("sample cov[%d][%d] = %v; want %v", i, j, cov, sigma[i][j])
+			}
+		}
+	}
+}
+
+func TestMultivariateNormalInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		mu    []float64
+		sigma [][]float64
+	}{
+		{"empty", nil, nil},
+		{"dimension mismatch", []float64{0, 0}, [][]float64{{1}}},
+		{"ragged", []float64{0, 0}, [][]float64{{1, 0}, {0}}},
+		{"asymmetric", []float64{0, 0}, [][]float64{{1, 0.5}, {0.2, 1}}},
+		{"singular", []float64{0, 0}, [][]float64{{1, 1}, {1, 1}}},
+		{"indefinite", []float64{0, 0}, [][]float64{{1, 2}, {2, 1}}},
+		{"non-finite mean", []float64{math.NaN(), 0}, [][]float64{{1, 0}, {0, 1}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewMultivariateNormal(tt.mu, tt.sigma); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+
+	m := newTestMVN(t)
+	if got := m.LogPDF([]float64{1, 2}); !math.IsNaN(got) {
+		t.Errorf("LogPDF with wrong dimension = %v; want NaN", got)
+	}
+	if _, err := m.Marginal([]int{0, 0}); err == nil {
+		t.Error("Marginal with repeated index should fail")
+	}
+	if _, err := m.Marginal([]int{3}); err == nil {
+		t.Error("Marginal with out-of-range index should fail")
+	}
+	if _, err := m.Conditional([]int{0}, []float64{1, 2}); err == nil {
+		t.Error("Conditional with length mismatch should fail")
+	}
+	if _, err := m.Conditional([]int{0, 1, 2}, []float64{1, 2, 3}); err == nil {
+		t.Error("Conditional on every component should fail")
+	}
+	if (MultivariateNormal{}).Rand(nil) != nil {
+		t.Error("Rand on zero value should return nil")
+	}
+}
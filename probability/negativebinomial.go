@@ -0,0 +1,129 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
+)
+
+// NegativeBinomial is the distribution of the number of failures before the
+// R-th success in independent trials with success probability P. R may be
+// any positive real (the Pólya distribution); P must be in (0, 1].
+// Methods return NaN for invalid parameters.
+type NegativeBinomial struct {
+	R, P float64
+}
+
+func (nb NegativeBinomial) valid() bool {
+	return nb.R > 0 && !math.IsInf(nb.R, 1) && nb.P > 0 && nb.P <= 1
+}
+
+// PMF returns P(X = k) = Γ(k+R)/(Γ(R)·k!)·P^R·(1-P)^k.
+func (nb NegativeBinomial) PMF(k int) float64 {
+	return math.Exp(nb.LogPMF(k))
+}
+
+// LogPMF returns log P(X = k), evaluated as R/(R+k) times the binomial
+// probability of R successes in R+k trials so that large R and k keep
+// full precision.
+func (nb NegativeBinomial) LogPMF(k int) float64 {
+	if !nb.valid() {
+		return math.NaN()
+	}
+	if k < 0 {
+		return math.Inf(-1)
+	}
+	kf := float64(k)
+	return math.Log(nb.R/(nb.R+kf)) + binomialLogPMF(nb.R, nb.R+kf, nb.P, 1-nb.P)
+}
+
+// CDF returns P(X ≤ x) = I_P(R, ⌊x⌋+1).
+func (nb NegativeBinomial) CDF(x float64) float64 {
+	if !nb.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	if nb.P == 1 || math.IsInf(x, 1) {
+		return 1
+	}
+	return specfunc.BetaInc(nb.R, math.Floor(x)+1, nb.P)
+}
+
+// Survival returns P(X > x) = I_(1-P)(⌊x⌋+1, R).
+func (nb NegativeBinomial) Survival(x float64) float64 {
+	if !nb.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 1
+	}
+	if nb.P == 1 || math.IsInf(x, 1) {
+		return 0
+	}
+	return specfunc.BetaInc(math.Floor(x)+1, nb.R, 1-nb.P)
+}
+
+// Quantile returns the smallest k such that P(X ≤ k) ≥ q.
+func (nb NegativeBinomial) Quantile(q float64) float64 {
+	if !nb.valid() || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if nb.P == 1 {
+		return 0
+	}
+	if q == 1 {
+		return math.Inf(1)
+	}
+	return float64(discreteQuantile(q, 0, -1, func(k int) float64 { return nb.CDF(float64(k)) }))
+}
+
+// Mean returns R·(1-P) / P.
+func (nb NegativeBinomial) Mean() float64 {
+	if !nb.valid() {
+		return math.NaN()
+	}
+	return nb.R * (1 - nb.P) / nb.P
+}
+
+// Variance returns R·(1-P) / P².
+func (nb NegativeBinomial) Variance() float64 {
+	if !nb.valid() {
+		return math.NaN()
+	}
+	return nb.R * (1 - nb.P) / (nb.P * nb.P)
+}
+
+// Entropy returns -Σ P(X=k)·log P(X=k), summed until the tail beyond the
+// mean is negligible.
+func (nb NegativeBinomial) Entropy() float64 {
+	if !nb.valid() {
+		return math.NaN()
+	}
+	h := 0.0
+	limit := int(nb.Mean() + 40*math.Sqrt(nb.Variance()) + 40)
+	for k := 0; k <= limit; k++ {
+		if pk := nb.PMF(k); pk > 0 {
+			h -= pk * math.Log(pk)
+		}
+	}
+	return h
+}
+
+// Rand draws a random variate as a Poisson variate whose mean is itself
+// Gamma(R, P/(1-P)) distributed. A nil rng uses the global math/rand source.
+func (nb NegativeBinomial) Rand(rng *rand.Rand) float64 {
+	if !nb.valid() {
+		return math.NaN()
+	}
+	if nb.P == 1 {
+		return 0
+	}
+	lambda := standardGammaRand(nb.R, rng) * (1 - nb.P) / nb.P
+	if lambda == 0 {
+		return 0
+	}
+	return Poisson{Lambda: lambda}.Rand(rng)
+}
@@ -0,0 +1,52 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNegativeBinomialClosedForms(t *testing.T) {
+	d := NegativeBinomial{R: 3, P: 0.5}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PMF(0)", d.PMF(0), 0.125},
+		{"PMF(2)", d.PMF(2), 6.0 / 32},
+		{"CDF(2)", d.CDF(2), 0.5},
+		{"Survival(2)", d.Survival(2), 0.5},
+		{"Quantile(0.5)", d.Quantile(0.5), 2},
+		{"Quantile(0.51)", d.Quantile(0.51), 3},
+		{"Mean", d.Mean(), 3},
+		{"Variance", d.Variance(), 6},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// R = 1 is the geometric distribution
+	nb, g := NegativeBinomial{R: 1, P: 0.3}, Geometric{P: 0.3}
+	for k := 0; k < 30; k++ {
+		if math.Abs(nb.PMF(k)-g.PMF(k)) > 1e-15 || math.Abs(nb.CDF(float64(k))-g.CDF(float64(k))) > 1e-14 {
+			t.Errorf("NegativeBinomial{1, 0.3} differs from Geometric{0.3} at %d", k)
+		}
+	}
+	if math.Abs(nb.Entropy()-g.Entropy()) > 1e-12 {
+		t.Errorf("Entropy = %v; want %v", nb.Entropy(), g.Entropy())
+	}
+
+	// large R: the mode carries about 1/√(2π·variance) and nothing overflows
+	big := NegativeBinomial{R: 1e6, P: 0.5}
+	if got, want := big.PMF(1000000), 1/math.Sqrt(2*math.Pi*big.Variance()); math.Abs(got/want-1) > 1e-3 {
+		t.Errorf("PMF at the mean = %v; want ≈ %v", got, want)
+	}
+	if q := big.Quantile(0.5); math.Abs(q-1e6) > 2 {
+		t.Errorf("median = %v; want ≈ 1e6", q)
+	}
+
+	if !math.IsNaN(NegativeBinomial{R: -1, P: 0.5}.PMF(0)) || !math.IsInf(d.Quantile(1), 1) {
+		t.Error("unexpected boundary behaviour")
+	}
+}
@@ -2,26 +2,113 @@ package probability
 
 import (
 	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
 )
 
-// NormalPDF computes the probability density function for a normal distribution.
-// μ = mean, σ = standard deviation
-func NormalPDF(x, mean, stdDev float64) float64 {
-	if stdDev <= 0 {
+// Normal is the normal (Gaussian) distribution with mean Mu and standard
+// deviation Sigma. Sigma must be > 0; methods return NaN otherwise.
+type Normal struct {
+	Mu, Sigma float64
+}
+
+func (n Normal) valid() bool {
+	return n.Sigma > 0 && !math.IsInf(n.Sigma, 1) && !math.IsNaN(n.Mu)
+}
+
+// PDF returns the probability density at x.
+func (n Normal) PDF(x float64) float64 {
+	if !n.valid() {
 		return math.NaN()
 	}
-	expPart := math.Exp(-math.Pow(x-mean, 2) / (2 * math.Pow(stdDev, 2)))
-	coef := 1 / (stdDev * math.Sqrt(2*math.Pi))
-	return coef * expPart
+	z := (x - n.Mu) / n.Sigma
+	return math.Exp(-0.5*z*z) / (n.Sigma * math.Sqrt(2*math.Pi))
 }
 
-// NormalCDF computes the cumulative distribution function using the error function approximation.
-func NormalCDF(x, mean, stdDev float64) float64 {
-	if stdDev <= 0 {
+// LogPDF returns the logarithm of the density at x.
+func (n Normal) LogPDF(x float64) float64 {
+	if !n.valid() {
 		return math.NaN()
 	}
-	z := (x - mean) / (stdDev * math.Sqrt2)
-	return 0.5 * (1 + math.Erf(z))
+	z := (x - n.Mu) / n.Sigma
+	return -0.5*z*z - math.Log(n.Sigma) - 0.5*math.Log(2*math.Pi)
 }
 
+// CDF returns P(X ≤ x).
+func (n Normal) CDF(x float64) float64 {
+	if !n.valid() {
+		return math.NaN()
+	}
+	return 0.5 * math.Erfc(-(x-n.Mu)/(n.Sigma*math.Sqrt2))
+}
+
+// Survival returns P(X > x).
+func (n Normal) Survival(x float64) float64 {
+	if !n.valid() {
+		return math.NaN()
+	}
+	return 0.5 * math.Erfc((x-n.Mu)/(n.Sigma*math.Sqrt2))
+}
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+// Quantile(0) is -Inf and Quantile(1) is +Inf.
+func (n Normal) Quantile(p float64) float64 {
+	if !n.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return n.Mu + n.Sigma*specfunc.Ndtri(p)
+}
+
+// QuantileLog returns Quantile(exp(logp)) without forming p, so that tail
+// probabilities too small for a float64 still give finite quantiles.
+// QuantileLog(-Inf) is -Inf and QuantileLog(0) is +Inf.
+func (n Normal) QuantileLog(logp float64) float64 {
+	if !n.valid() || math.IsNaN(logp) || logp > 0 {
+		return math.NaN()
+	}
+	return n.Mu + n.Sigma*specfunc.NdtriExp(logp)
+}
 
+// Mean returns Mu.
+func (n Normal) Mean() float64 {
+	if !n.valid() {
+		return math.NaN()
+	}
+	return n.Mu
+}
+
+// Variance returns Sigma².
+func (n Normal) Variance() float64 {
+	if !n.valid() {
+		return math.NaN()
+	}
+	return n.Sigma * n.Sigma
+}
+
+// Entropy returns ½·log(2πe·Sigma²).
+func (n Normal) Entropy() float64 {
+	if !n.valid() {
+		return math.NaN()
+	}
+	return 0.5*math.Log(2*math.Pi*math.E) + math.Log(n.Sigma)
+}
+
+// Rand draws a random variate. A nil rng uses the global math/rand source.
+func (n Normal) Rand(rng *rand.Rand) float64 {
+	if !n.valid() {
+		return math.NaN()
+	}
+	return n.Mu + n.Sigma*normFloat(rng)
+}
+
+// NormalPDF computes the probability density function for a normal distribution.
+// μ = mean, σ = standard deviation
+func NormalPDF(x, mean, stdDev float64) float64 {
+	return Normal{Mu: mean, Sigma: stdDev}.PDF(x)
+}
+
+// NormalCDF computes the cumulative distribution function using the error function approximation.
+func NormalCDF(x, mean, stdDev float64) float64 {
+	return Normal{Mu: mean, Sigma: stdDev}.CDF(x)
+}
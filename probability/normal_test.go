@@ -78,7 +78,7 @@ func TestNormalInverseCDF_AlternateCases(t *testing.T) {
 		{0.5, 10.0, 2.0, 10.0, 1e-5},
 		{0.8413447, 5.0, 2.0, 7.0, 1e-5},
 
-		// 🧪 Edge cases to trigger all branches of standardNormalInverseCDF
+		// 🧪 Edge cases to trigger the tail branches of the quantile
 		{0.0001, 0.0, 1.0, -3.719, 0.05}, // far left tail
 		{0.9999, 0.0, 1.0, 3.719, 0.05},  // far right tail
 	}
@@ -98,8 +98,8 @@ func TestNormalInverseCDFPanic(t *testing.T) {
 		mean   float64
 		stddev float64
 	}{
-		{0.0, 0, 1},
-		{1.0, 0, 1},
+		{-0.1, 0, 1},
+		{1.1, 0, 1},
 		{0.5, 0, 0},
 	}
 
@@ -115,6 +115,53 @@ func TestNormalInverseCDFPanic(t *testing.T) {
 	}
 }
 
+func TestNormalInverseCDFEndpoints(t *testing.T) {
+	if got := NormalInverseCDF(0, 3, 2); !math.IsInf(got, -1) {
+		t.Errorf("NormalInverseCDF(0, 3, 2) = %v; want -Inf", got)
+	}
+	if got := NormalInverseCDF(1, 3, 2); !math.IsInf(got, 1) {
+		t.Errorf("NormalInverseCDF(1, 3, 2) = %v; want +Inf", got)
+	}
+}
+
+func TestNormalInverseCDFPrecision(t *testing.T) {
+	// Φ⁻¹(p) computed in 80-digit arithmetic
+	tests := []struct{ p, want float64 }{
+		{1e-300, -37.047096299361201},
+		{1e-20, -9.262340089798407},
+		{1e-10, -6.3613409024040566},
+		{0.025, -1.9599639845400543},
+		{0.975, 1.9599639845400543},
+	}
+	for _, tt := range tests {
+		got := NormalInverseCDF(tt.p, 0, 1)
+		if math.Abs(got-tt.want) > 1e-15*math.Abs(tt.want) {
+			t.Errorf("NormalInverseCDF(%v, 0, 1) = %.17g; want %.17g", tt.p, got, tt.want)
+		}
+		if tt.p < 0.5 {
+			if got := NormalInverseCDFLog(math.Log(tt.p), 0, 1); math.Abs(got-tt.want) > 1e-15*math.Abs(tt.want) {
+				t.Errorf("NormalInverseCDFLog(log %v, 0, 1) = %.17g; want %.17g", tt.p, got, tt.want)
+			}
+		}
+	}
+	// log p = -1e5 is far below the smallest float64
+	if got, want := NormalInverseCDFLog(-1e5, 1, 2), 1-2*447.19789367852508; math.Abs(got-want) > 1e-12 {
+		t.Errorf("NormalInverseCDFLog(-1e5, 1, 2) = %v; want %v", got, want)
+	}
+	if !math.IsInf(NormalInverseCDFLog(0, 0, 1), 1) || !math.IsInf(NormalInverseCDFLog(math.Inf(-1), 0, 1), -1) {
+		t.Error("NormalInverseCDFLog(0) and (-Inf) should be ±Inf")
+	}
+	if !math.IsNaN((Normal{Mu: 0, Sigma: 1}).QuantileLog(0.5)) {
+		t.Error("QuantileLog(logp > 0) should be NaN")
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for logp > 0")
+		}
+	}()
+	_ = NormalInverseCDFLog(0.1, 0, 1)
+}
+
 func TestNormalInverseCDFInvalidSigma(t *testing.T) {
     defer func() {
         if r := recover(); r == nil {
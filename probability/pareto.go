@@ -0,0 +1,109 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Pareto is the Pareto (type I) distribution with scale Xm, the minimum
+// possible value, and tail index Alpha. Both must be > 0; methods return
+// NaN otherwise.
+type Pareto struct {
+	Xm, Alpha float64
+}
+
+func (p Pareto) valid() bool {
+	return p.Xm > 0 && p.Alpha > 0 && !math.IsInf(p.Xm, 1) && !math.IsInf(p.Alpha, 1)
+}
+
+// PDF returns the probability density at x; it is 0 for x < Xm.
+func (p Pareto) PDF(x float64) float64 {
+	if !p.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < p.Xm {
+		return 0
+	}
+	return p.Alpha / x * math.Pow(p.Xm/x, p.Alpha)
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (p Pareto) LogPDF(x float64) float64 {
+	if !p.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < p.Xm {
+		return math.Inf(-1)
+	}
+	return math.Log(p.Alpha/x) + p.Alpha*math.Log(p.Xm/x)
+}
+
+// CDF returns P(X ≤ x) = 1 - (Xm/x)^Alpha.
+func (p Pareto) CDF(x float64) float64 {
+	if !p.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= p.Xm {
+		return 0
+	}
+	return -math.Expm1(p.Alpha * math.Log(p.Xm/x))
+}
+
+// Survival returns P(X > x) = (Xm/x)^Alpha.
+func (p Pareto) Survival(x float64) float64 {
+	if !p.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= p.Xm {
+		return 1
+	}
+	return math.Pow(p.Xm/x, p.Alpha)
+}
+
+// Quantile returns Xm·(1-q)^(-1/Alpha).
+func (p Pareto) Quantile(q float64) float64 {
+	if !p.valid() || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	return p.Xm * math.Exp(-math.Log1p(-q)/p.Alpha)
+}
+
+// Mean returns Alpha·Xm / (Alpha - 1), or +Inf when Alpha ≤ 1.
+func (p Pareto) Mean() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	if p.Alpha <= 1 {
+		return math.Inf(1)
+	}
+	return p.Alpha * p.Xm / (p.Alpha - 1)
+}
+
+// Variance returns Xm²·Alpha / ((Alpha-1)²·(Alpha-2)), or +Inf when Alpha ≤ 2.
+func (p Pareto) Variance() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	if p.Alpha <= 2 {
+		return math.Inf(1)
+	}
+	a1 := p.Alpha - 1
+	return p.Xm * p.Xm * p.Alpha / (a1 * a1 * (p.Alpha - 2))
+}
+
+// Entropy returns log(Xm/Alpha) + 1/Alpha + 1.
+func (p Pareto) Entropy() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	return math.Log(p.Xm/p.Alpha) + 1/p.Alpha + 1
+}
+
+// Rand draws a random variate as Xm·exp(E/Alpha) with E standard
+// exponential. A nil rng uses the global math/rand source.
+func (p Pareto) Rand(rng *rand.Rand) float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	return p.Xm * math.Exp(expFloat(rng)/p.Alpha)
+}
@@ -0,0 +1,38 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParetoClosedForms(t *testing.T) {
+	d := Pareto{Xm: 1, Alpha: 3}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(1)", d.PDF(1), 3},
+		{"PDF(2)", d.PDF(2), 3.0 / 16},
+		{"CDF(2)", d.CDF(2), 7.0 / 8},
+		{"Survival(2)", d.Survival(2), 1.0 / 8},
+		{"Quantile(0.875)", d.Quantile(0.875), 2},
+		{"Mean", d.Mean(), 1.5},
+		{"Variance", d.Variance(), 0.75},
+		{"Entropy", d.Entropy(), math.Log(1.0/3) + 1.0/3 + 1},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-14 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if !math.IsInf(Pareto{Xm: 1, Alpha: 1}.Mean(), 1) || !math.IsInf(Pareto{Xm: 1, Alpha: 2}.Variance(), 1) {
+		t.Error("heavy tails should give infinite moments")
+	}
+	if d.PDF(0.5) != 0 || d.CDF(0.5) != 0 || d.Survival(1) != 1 {
+		t.Error("Pareto should put no mass below Xm")
+	}
+	if !math.IsNaN(Pareto{Xm: 0, Alpha: 1}.PDF(2)) {
+		t.Error("expected NaN for invalid scale")
+	}
+}
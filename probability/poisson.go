@@ -2,15 +2,165 @@ package probability
 
 import (
 	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
 )
 
+// Poisson is the Poisson distribution with mean Lambda.
+// Lambda must be > 0; methods return NaN otherwise.
+type Poisson struct {
+	Lambda float64
+}
+
+func (p Poisson) valid() bool {
+	return p.Lambda > 0 && !math.IsInf(p.Lambda, 1)
+}
+
+// PMF returns P(X = k); it is 0 for k < 0.
+func (p Poisson) PMF(k int) float64 {
+	return math.Exp(p.LogPMF(k))
+}
+
+// LogPMF returns log P(X = k). Instead of k·log λ - λ - LogFactorial(k),
+// whose terms cancel for large λ, it uses the Stirling-based gamma prefix
+// λ^(k+1)·e^(-λ)/Γ(k+1), which keeps full precision near the mean.
+func (p Poisson) LogPMF(k int) float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	if k < 0 {
+		return math.Inf(-1)
+	}
+	return specfunc.LogGammaPrefix(float64(k)+1, p.Lambda) - math.Log(p.Lambda)
+}
+
+// CDF returns P(X ≤ x) = Q(⌊x⌋+1, Lambda), the regularized upper incomplete
+// gamma function, so the cost does not grow with Lambda.
+func (p Poisson) CDF(x float64) float64 {
+	if !p.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	return specfunc.GammaIncUpper(math.Floor(x)+1, p.Lambda)
+}
+
+// Survival returns P(X > x) = P(⌊x⌋+1, Lambda).
+func (p Poisson) Survival(x float64) float64 {
+	if !p.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 1
+	}
+	return specfunc.GammaIncLower(math.Floor(x)+1, p.Lambda)
+}
+
+// Quantile returns the smallest k such that P(X ≤ k) ≥ q.
+func (p Poisson) Quantile(q float64) float64 {
+	if !p.valid() || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if q == 1 {
+		return math.Inf(1)
+	}
+	return float64(discreteQuantile(q, 0, -1, func(k int) float64 { return p.CDF(float64(k)) }))
+}
+
+// Mean returns Lambda.
+func (p Poisson) Mean() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	return p.Lambda
+}
+
+// Variance returns Lambda.
+func (p Poisson) Variance() float64 {
+	return p.Mean()
+}
+
+// Entropy returns -Σ P(X=k)·log P(X=k), summed over the part of the
+// support where the probabilities are not negligible.
+func (p Poisson) Entropy() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	width := 40*math.Sqrt(p.Lambda) + 40
+	from := int(math.Max(0, p.Lambda-width))
+	to := int(p.Lambda + width)
+	h := 0.0
+	for k := from; k <= to; k++ {
+		if lp := p.LogPMF(k); !math.IsInf(lp, -1) {
+			h -= math.Exp(lp) * lp
+		}
+	}
+	return h
+}
+
+// Rand draws a random variate. Means below 10 use Knuth's multiplication
+// method; larger ones use Hörmann's transformed rejection (PTRS).
+// A nil rng uses the global math/rand source.
+func (p Poisson) Rand(rng *rand.Rand) float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	if p.Lambda < 10 {
+		limit := math.Exp(-p.Lambda)
+		k, prod := 0, uniformOpen(rng)
+		for prod > limit {
+			k++
+			prod *= uniformOpen(rng)
+		}
+		return float64(k)
+	}
+	return float64(poissonPTRS(p.Lambda, rng))
+}
+
+// poissonPTRS implements W. Hörmann, "The transformed rejection method for
+// generating Poisson random variables" (1993), valid for λ ≥ 10.
+func poissonPTRS(lambda float64, rng *rand.Rand) int {
+	logLambda := math.Log(lambda)
+	b := 0.931 + 2.53*math.Sqrt(lambda)
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := uniformOpen(rng) - 0.5
+		v := uniformOpen(rng)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLambda-lg {
+			return int(k)
+		}
+	}
+}
+
 // PoissonPMF returns the probability of observing k events
 // given the average rate λ using the Poisson distribution.
 func PoissonPMF(k int, lambda float64) float64 {
 	if k < 0 || lambda <= 0 {
 		panic("PoissonPMF: k must be >= 0 and lambda must be > 0")
 	}
-	return math.Pow(lambda, float64(k)) * math.Exp(-lambda) / float64(factorial(k))
+	return Poisson{Lambda: lambda}.PMF(k)
+}
+
+// LogPoissonPMF returns log P(X = k) for X ~ Poisson(λ). It stays finite
+// where PoissonPMF underflows to 0.
+func LogPoissonPMF(k int, lambda float64) float64 {
+	if k < 0 || lambda <= 0 {
+		panic("LogPoissonPMF: k must be >= 0 and lambda must be > 0")
+	}
+	return Poisson{Lambda: lambda}.LogPMF(k)
 }
 
 // PoissonCDF returns the cumulative probability of observing
@@ -19,24 +169,18 @@ func PoissonCDF(k int, lambda float64) float64 {
 	if k < 0 || lambda <= 0 {
 		panic("PoissonCDF: k must be >= 0 and lambda must be > 0")
 	}
-	var sum float64
-	for i := 0; i <= k; i++ {
-		sum += PoissonPMF(i, lambda)
-	}
-	return sum
+	return Poisson{Lambda: lambda}.CDF(float64(k))
 }
 
-// Helper: integer factorial
-func factorial(n int) float64 {
-	if n < 0 {
-		panic("factorial: n must be non-negative")
+// PoissonQuantile returns the smallest k such that P(X ≤ k) ≥ p for a
+// Poisson distribution with mean λ, the convention BinomialQuantile uses.
+// No finite k reaches p = 1, so PoissonQuantile(1, λ) returns math.MaxInt.
+func PoissonQuantile(p, lambda float64) int {
+	if math.IsNaN(p) || p < 0 || p > 1 || lambda <= 0 {
+		panic("PoissonQuantile: p must be in [0,1] and lambda must be > 0")
 	}
-	result := 1.0
-	for i := 2; i <= n; i++ {
-		result *= float64(i)
-		if math.IsInf(result, 0) {
-			panic("factorial: result overflowed float64")
-		}
+	if p == 1 {
+		return math.MaxInt
 	}
-	return result
+	return int(Poisson{Lambda: lambda}.Quantile(p))
 }
@@ -43,6 +43,33 @@ func TestPoissonCDF(t *testing.T) {
 	}
 }
 
+func TestPoissonLargeLambda(t *testing.T) {
+	// references computed with 50-digit arithmetic
+	tests := []struct {
+		k    int
+		want float64
+	}{
+		{1000000, 0.50026596148628365},
+		{995000, 2.8148203838965314e-7},
+	}
+	for _, tt := range tests {
+		if got := PoissonCDF(tt.k, 1e6); math.Abs(got-tt.want) > 1e-12*tt.want {
+			t.Errorf("PoissonCDF(%d, 1e6) = %.17g; want %.17g", tt.k, got, tt.want)
+		}
+	}
+
+	const want = -386302.53438733475
+	if got := LogPoissonPMF(2000000, 1e6); math.Abs(got-want) > 1e-14*math.Abs(want) {
+		t.Errorf("LogPoissonPMF = %.17g; want %.17g", got, want)
+	}
+	if got := PoissonPMF(2000000, 1e6); got != 0 {
+		t.Errorf("PoissonPMF = %v; want underflow to 0", got)
+	}
+	if got, want := LogPoissonPMF(3, 2.5), math.Log(PoissonPMF(3, 2.5)); math.Abs(got-want) > 1e-14 {
+		t.Errorf("LogPoissonPMF(3, 2.5) = %v; want %v", got, want)
+	}
+}
+
 func TestPoissonPanic(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -62,38 +89,55 @@ func TestPoissonPMFPanicOnNegativeLambda(t *testing.T) {
 	_ = PoissonPMF(2, -1.0)
 }
 
-func TestFactorialPanicOnNegativeInput(t *testing.T) {
+func TestPoissonCDFPanicOnNegativeK(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
-			t.Error("Expected panic for negative input to factorial")
+			t.Errorf("Expected panic for negative k in PoissonCDF")
 		}
 	}()
-	_ = factorial(-1)
+	_ = PoissonCDF(-1, 2.0)
 }
 
-func TestFactorialOverflowPanic(t *testing.T) {
+func TestPoissonCDFPanicOnNonPositiveLambda(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
-			t.Error("Expected panic for large input to factorial due to overflow risk")
+			t.Errorf("Expected panic for non-positive lambda in PoissonCDF")
 		}
 	}()
-	_ = factorial(200) // Should overflow float64 range
+	_ = PoissonCDF(3, 0.0)
 }
 
-func TestPoissonCDFPanicOnNegativeK(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Expected panic for negative k in PoissonCDF")
+func TestPoissonQuantile(t *testing.T) {
+	tests := []struct {
+		p, lambda float64
+		want      int
+	}{
+		{0, 3, 0},
+		{0.04, 3, 0},
+		{0.5, 3, 3},
+		{0.9, 3, 5},
+		{PoissonCDF(2, 3), 3, 2}, // an exact CDF value stops at that k
+		{0.5, 1000, 1000},
+	}
+	for _, tt := range tests {
+		if got := PoissonQuantile(tt.p, tt.lambda); got != tt.want {
+			t.Errorf("PoissonQuantile(%v, %v) = %d; want %d", tt.p, tt.lambda, got, tt.want)
 		}
-	}()
-	_ = PoissonCDF(-1, 2.0)
-}
-
-func TestPoissonCDFPanicOnNonPositiveLambda(t *testing.T) {
+	}
+	// smallest k with P(X ≤ k) ≥ p
+	for _, p := range []float64{0.01, 0.3, 0.77, 0.999999} {
+		k := PoissonQuantile(p, 7.5)
+		if PoissonCDF(k, 7.5) < p || (k > 0 && PoissonCDF(k-1, 7.5) >= p) {
+			t.Errorf("PoissonQuantile(%v, 7.5) = %d is not the smallest k", p, k)
+		}
+	}
+	if PoissonQuantile(1, 3) != math.MaxInt {
+		t.Error("PoissonQuantile(1, 3) should be math.MaxInt")
+	}
 	defer func() {
 		if r := recover(); r == nil {
-			t.Errorf("Expected panic for non-positive lambda in PoissonCDF")
+			t.Error("Expected panic for non-positive lambda in PoissonQuantile")
 		}
 	}()
-	_ = PoissonCDF(3, 0.0)
+	_ = PoissonQuantile(0.5, 0)
 }
@@ -0,0 +1,140 @@
+package probability
+
+import "math"
+
+// NumericQuantile returns x with cdf(x) = p for any continuous,
+// nondecreasing cdf supported on [lo, hi], where either end may be
+// infinite. It brackets the root, stepping outward from a finite end (or
+// from 0) by doubling, and then refines it with Brent's method to about
+// 2 ulps. p = 0 returns lo and p = 1 returns hi. Where the cdf is flat at
+// level p any point of the flat stretch may be returned. Returns NaN if p
+// is outside [0, 1], lo ≥ hi, or cdf returns NaN.
+//
+// Use it for distributions with a CDF but no closed-form inverse. Accuracy
+// in the upper tail is bounded by how precisely cdf(x) resolves 1 - p.
+func NumericQuantile(cdf func(x float64) float64, p, lo, hi float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 || !(lo < hi) {
+		return math.NaN()
+	}
+	switch p {
+	case 0:
+		return lo
+	case 1:
+		return hi
+	}
+	f := func(x float64) float64 { return cdf(x) - p }
+
+	// invariant: f(a) < 0 ≤ f(b), with an infinite end standing for
+	// the limits 0 and 1 of the cdf
+	a, b := lo, hi
+	fa, fb := -p, 1-p
+	if !math.IsInf(a, 0) {
+		if fa = f(a); math.IsNaN(fa) {
+			return math.NaN()
+		} else if fa >= 0 {
+			return a
+		}
+	}
+	if !math.IsInf(b, 0) {
+		if fb = f(b); math.IsNaN(fb) {
+			return math.NaN()
+		} else if fb < 0 {
+			return b
+		}
+	}
+	if math.IsInf(a, 0) && math.IsInf(b, 0) {
+		if fx := f(0); math.IsNaN(fx) {
+			return math.NaN()
+		} else if fx < 0 {
+			a, fa = 0, fx
+		} else {
+			b, fb = 0, fx
+		}
+	}
+	for step := 1.0; math.IsInf(b, 0); step *= 2 {
+		x := a + step
+		fx := f(x)
+		switch {
+		case math.IsNaN(fx):
+			return math.NaN()
+		case math.IsInf(x, 0):
+			return x
+		case fx >= 0:
+			b, fb = x, fx
+		default:
+			a, fa = x, fx
+		}
+	}
+	for step := 1.0; math.IsInf(a, 0); step *= 2 {
+		x := b - step
+		fx := f(x)
+		switch {
+		case math.IsNaN(fx):
+			return math.NaN()
+		case math.IsInf(x, 0):
+			return x
+		case fx < 0:
+			a, fa = x, fx
+		default:
+			b, fb = x, fx
+		}
+	}
+	return brent(f, a, b, fa, fb)
+}
+
+// brent finds a root of f in [a, b], where fa = f(a) and fb = f(b) differ
+// in sign, by Brent's method: inverse quadratic or secant steps while they
+// shrink the bracket fast enough, bisection otherwise.
+func brent(f func(float64) float64, a, b, fa, fb float64) float64 {
+	const eps = 2.220446049250313e-16
+	c, fc := b, fb
+	var d, e float64
+	for i := 0; i < 1000; i++ {
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := 2*eps*math.Abs(b) + 1e-300
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol || fb == 0 {
+			return b
+		}
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			s := fb / fa
+			var p, q float64
+			if a == c {
+				// secant
+				p, q = 2*m*s, 1-s
+			} else {
+				// inverse quadratic interpolation
+				q, r := fa/fc, fb/fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, m)
+		}
+		fb = f(b)
+	}
+	return b
+}
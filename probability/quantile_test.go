@@ -0,0 +1,57 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumericQuantileMatchesClosedForms(t *testing.T) {
+	tests := []struct {
+		name   string
+		dist   Continuous
+		lo, hi float64
+	}{
+		{"normal", Normal{Mu: 3, Sigma: 2}, math.Inf(-1), math.Inf(1)},
+		{"far normal", Normal{Mu: -1e6, Sigma: 0.5}, math.Inf(-1), math.Inf(1)},
+		{"gamma", Gamma{Alpha: 2.5, Beta: 0.1}, 0, math.Inf(1)},
+		{"beta", Beta{Alpha: 0.5, Beta: 3}, 0, 1},
+		{"cauchy", Cauchy{X0: 1, Gamma: 4}, math.Inf(-1), math.Inf(1)},
+		{"weibull", Weibull{K: 1.5, Lambda: 2}, 0, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		for _, p := range []float64{1e-12, 0.001, 0.2, 0.5, 0.9, 0.999} {
+			want := tt.dist.Quantile(p)
+			got := NumericQuantile(tt.dist.CDF, p, tt.lo, tt.hi)
+			if math.Abs(got-want) > 1e-12*math.Abs(want) {
+				t.Errorf("%s: NumericQuantile(%v) = %v; want %v", tt.name, p, got, want)
+			}
+		}
+		if got := NumericQuantile(tt.dist.CDF, 0, tt.lo, tt.hi); got != tt.lo {
+			t.Errorf("%s: NumericQuantile(0) = %v; want %v", tt.name, got, tt.lo)
+		}
+		if got := NumericQuantile(tt.dist.CDF, 1, tt.lo, tt.hi); got != tt.hi {
+			t.Errorf("%s: NumericQuantile(1) = %v; want %v", tt.name, got, tt.hi)
+		}
+	}
+}
+
+func TestNumericQuantileEdgeCases(t *testing.T) {
+	// a CDF that is already at p on the lower boundary
+	u := Uniform{A: 2, B: 5}
+	if got := NumericQuantile(u.CDF, 1e-300, 2, 5); math.Abs(got-2) > 1e-15 {
+		t.Errorf("uniform NumericQuantile(1e-300) = %v; want 2", got)
+	}
+	// a flat stretch at level ½ between two separated components
+	m := Mixture{Weights: []float64{0.5, 0.5}, Components: []Continuous{Uniform{A: 0, B: 1}, Uniform{A: 3, B: 4}}}
+	if got := NumericQuantile(m.CDF, 0.5, math.Inf(-1), math.Inf(1)); got < 1 || got > 3 {
+		t.Errorf("NumericQuantile on the flat stretch = %v; want a point of [1, 3]", got)
+	}
+	if got := NumericQuantile(m.CDF, 0.75, math.Inf(-1), math.Inf(1)); math.Abs(got-3.5) > 1e-15 {
+		t.Errorf("NumericQuantile(0.75) = %v; want 3.5", got)
+	}
+	nan := func(float64) float64 { return math.NaN() }
+	if !math.IsNaN(NumericQuantile(nan, 0.5, 0, 1)) || !math.IsNaN(NumericQuantile(u.CDF, 1.5, 2, 5)) ||
+		!math.IsNaN(NumericQuantile(u.CDF, 0.5, 5, 2)) {
+		t.Error("invalid inputs should return NaN")
+	}
+}
@@ -0,0 +1,60 @@
+package probability
+
+import "math/rand"
+
+// uniformOpen returns a uniform variate in the open interval (0, 1).
+// It draws from rng, or from the global math/rand source when rng is nil.
+func uniformOpen(rng *rand.Rand) float64 {
+	for {
+		var u float64
+		if rng == nil {
+			u = rand.Float64()
+		} else {
+			u = rng.Float64()
+		}
+		if u > 0 {
+			return u
+		}
+	}
+}
+
+// normFloat returns a standard normal variate from rng, or from the global
+// math/rand source when rng is nil.
+func normFloat(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.NormFloat64()
+	}
+	return rng.NormFloat64()
+}
+
+// expFloat returns a standard exponential variate from rng, or from the
+// global math/rand source when rng is nil.
+func expFloat(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.ExpFloat64()
+	}
+	return rng.ExpFloat64()
+}
+
+// int63n returns a uniform integer in [0, n) from rng, or from the global
+// math/rand source when rng is nil.
+func int63n(rng *rand.Rand, n int64) int64 {
+	if rng == nil {
+		return rand.Int63n(n)
+	}
+	return rng.Int63n(n)
+}
+
+// Sample draws n independent variates from d. Passing the same seeded rng,
+// e.g. rand.New(rand.NewSource(42)), reproduces the same sample; a nil rng
+// uses the global math/rand source.
+func Sample(d Distribution, n int, rng *rand.Rand) []float64 {
+	if n <= 0 {
+		return nil
+	}
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = d.Rand(rng)
+	}
+	return out
+}
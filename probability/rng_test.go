@@ -0,0 +1,184 @@
+package probability
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var samplerCases = []Distribution{
+	Normal{Mu: -2, Sigma: 3},
+	Exponential{Lambda: 2},
+	Uniform{A: 1, B: 4},
+	StudentT{Nu: 12},
+	FDist{D1: 5, D2: 30},
+	ChiSquared{K: 4},
+	Gamma{Alpha: 0.4, Beta: 2},
+	Gamma{Alpha: 7, Beta: 1},
+	LogNormal{Mu: 0.5, Sigma: 0.4},
+	Weibull{K: 1.5, Lambda: 2},
+	Beta{Alpha: 2, Beta: 3},
+	Beta{Alpha: 0.3, Beta: 0.7},
+	Cauchy{X0: 1, Gamma: 3}, // no moments; checked by goodness of fit only
+	Laplace{Mu: -1, B: 2},
+	Pareto{Xm: 2, Alpha: 9},
+	Logistic{Mu: 3, S: 0.5},
+	Binomial{N: 20, P: 0.3},   // inversion
+	Binomial{N: 5000, P: 0.2}, // BTPE
+	Binomial{N: 400, P: 0.85}, // BTPE on the mirrored side
+	Poisson{Lambda: 3.5},      // multiplication method
+	Poisson{Lambda: 250},      // PTRS
+	Geometric{P: 0.2},
+	NegativeBinomial{R: 3.5, P: 0.3},
+	Hypergeometric{Total: 500, Successes: 120, Draws: 60},
+	DiscreteUniform{A: -4, B: 9},
+	Truncated(Normal{Mu: 0, Sigma: 1}, -1, 2),  // rejection
+	Truncated(Normal{Mu: 0, Sigma: 1}, 2.5, 4), // inversion
+	Truncated(Poisson{Lambda: 6}, 3, 8),
+	LocationScale(Gamma{Alpha: 3, Beta: 1}, 10, 0.5),
+	Mixture{Weights: []float64{0.25, 0.75}, Components: []Continuous{Normal{Mu: -3, Sigma: 1}, Exponential{Lambda: 0.5}}},
+}
+
+// TestSampleMoments checks that the sample mean and variance agree with
+// Mean() and Variance() to within five standard errors.
+func TestSampleMoments(t *testing.T) {
+	const n = 200000
+	for i, d := range samplerCases {
+		t.Run(fmt.Sprintf("%T%v", d, d), func(t *testing.T) {
+			xs := Sample(d, n, rand.New(rand.NewSource(int64(100+i))))
+			mean := 0.0
+			for _, x := range xs {
+				mean += x
+			}
+			mean /= n
+			m2, m4 := 0.0, 0.0
+			for _, x := range xs {
+				d2 := (x - mean) * (x - mean)
+				m2 += d2
+				m4 += d2 * d2
+			}
+			m2 /= n
+			m4 /= n
+
+			if se := math.Sqrt(d.Variance() / n); math.Abs(mean-d.Mean()) > 5*se {
+				t.Errorf("sample mean %v; want %v ± %v", mean, d.Mean(), 5*se)
+			}
+			if se := math.Sqrt((m4 - m2*m2) / n); math.Abs(m2-d.Variance()) > 5*se {
+				t.Errorf("sample variance %v; want %v ± %v", m2, d.Variance(), 5*se)
+			}
+		})
+	}
+}
+
+// TestSampleGoodnessOfFit compares the whole sampled distribution against
+// the CDF: a Kolmogorov–Smirnov bound for continuous laws and a chi-square
+// test over the PMF for discrete ones.
+func TestSampleGoodnessOfFit(t *testing.T) {
+	const n = 50000
+	for i, d := range samplerCases {
+		t.Run(fmt.Sprintf("%T%v", d, d), func(t *testing.T) {
+			xs := Sample(d, n, rand.New(rand.NewSource(int64(200+i))))
+			disc, ok := d.(Discrete)
+			if !ok {
+				sort.Float64s(xs)
+				dmax := 0.0
+				for j, x := range xs {
+					f := d.CDF(x)
+					dmax = math.Max(dmax, math.Max(float64(j+1)/n-f, f-float64(j)/n))
+				}
+				// 1.95 is the 0.1% critical value of √n·D
+				if stat := math.Sqrt(n) * dmax; stat > 1.95 {
+					t.Errorf("KS statistic √n·D = %v", stat)
+				}
+				return
+			}
+
+			counts := map[int]int{}
+			for _, x := range xs {
+				if x != math.Floor(x) {
+					t.Fatalf("non-integer variate %v", x)
+				}
+				counts[int(x)]++
+			}
+			// pool cells with small expected counts into the tails
+			lo := int(d.Quantile(0.001))
+			hi := int(d.Quantile(0.999))
+			chi2, cells := 0.0, 0
+			add := func(obs int, p float64) {
+				e := n * p
+				if e == 0 {
+					if obs > 0 {
+						t.Errorf("%d draws in a cell with zero probability", obs)
+					}
+					return
+				}
+				chi2 += (float64(obs) - e) * (float64(obs) - e) / e
+				cells++
+			}
+			below, above := 0, 0
+			for k, c := range counts {
+				if k < lo {
+					below += c
+				} else if k > hi {
+					above += c
+				}
+			}
+			add(below, d.CDF(float64(lo-1)))
+			for k := lo; k <= hi; k++ {
+				add(counts[k], disc.PMF(k))
+			}
+			add(above, d.Survival(float64(hi)))
+			if p := (ChiSquared{K: float64(cells - 1)}).Survival(chi2); !(p >= 1e-4) {
+				t.Errorf("chi-square = %v on %d cells, p = %v", chi2, cells, p)
+			}
+		})
+	}
+}
+
+func TestSampleReproducible(t *testing.T) {
+	for _, d := range samplerCases {
+		a := Sample(d, 50, rand.New(rand.NewSource(1)))
+		b := Sample(d, 50, rand.New(rand.NewSource(1)))
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("%T%v: draw %d differs between identical seeds", d, d, i)
+			}
+		}
+	}
+	if Sample(Normal{Mu: 0, Sigma: 1}, 0, nil) != nil {
+		t.Error("Sample with n = 0 should return nil")
+	}
+	if got := len(Sample(Normal{Mu: 0, Sigma: 1}, 3, nil)); got != 3 {
+		t.Errorf("Sample with nil rng returned %d variates", got)
+	}
+}
+
+func TestRandInvalidParameters(t *testing.T) {
+	invalid := []Distribution{
+		Normal{Sigma: -1}, Exponential{}, Uniform{A: 1, B: 0}, StudentT{}, FDist{D1: 1},
+		ChiSquared{}, Gamma{Alpha: 1}, LogNormal{}, Weibull{K: 1},
+		Beta{Alpha: 1}, Cauchy{}, Laplace{}, Pareto{Xm: 1}, Logistic{},
+		Geometric{P: 1.5}, NegativeBinomial{R: 1}, Hypergeometric{Total: -1}, DiscreteUniform{A: 1, B: 0}, Binomial{N: 3, P: -0.1}, Poisson{Lambda: -1},
+	}
+	rng := rand.New(rand.NewSource(3))
+	for _, d := range invalid {
+		if x := d.Rand(rng); !math.IsNaN(x) {
+			t.Errorf("%T%v.Rand() = %v; want NaN", d, d, x)
+		}
+	}
+}
+
+func TestRandDegenerate(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	if x := (Binomial{N: 10, P: 0}).Rand(rng); x != 0 {
+		t.Errorf("Binomial{10, 0}.Rand() = %v", x)
+	}
+	if x := (Binomial{N: 10, P: 1}).Rand(rng); x != 10 {
+		t.Errorf("Binomial{10, 1}.Rand() = %v", x)
+	}
+	if x := (StudentT{Nu: math.Inf(1)}).Rand(rng); math.IsNaN(x) || math.IsInf(x, 0) {
+		t.Errorf("StudentT{Inf}.Rand() = %v", x)
+	}
+}
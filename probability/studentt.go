@@ -0,0 +1,153 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/specfunc"
+)
+
+// StudentT is Student's t distribution with Nu degrees of freedom.
+// Nu must be > 0; Nu = +Inf gives the standard normal distribution.
+// Methods return NaN when Nu is invalid.
+type StudentT struct {
+	Nu float64
+}
+
+func (t StudentT) valid() bool {
+	return t.Nu > 0 // also rejects NaN
+}
+
+// PDF returns the probability density at x.
+func (t StudentT) PDF(x float64) float64 {
+	return math.Exp(t.LogPDF(x))
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (t StudentT) LogPDF(x float64) float64 {
+	if !t.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if math.IsInf(t.Nu, 1) {
+		return Normal{Mu: 0, Sigma: 1}.LogPDF(x)
+	}
+	nu := t.Nu
+	lg1, _ := math.Lgamma((nu + 1) / 2)
+	lg2, _ := math.Lgamma(nu / 2)
+	return lg1 - lg2 - 0.5*math.Log(nu*math.Pi) - (nu+1)/2*math.Log1p(x*x/nu)
+}
+
+// CDF returns P(T ≤ x).
+func (t StudentT) CDF(x float64) float64 {
+	if x < 0 {
+		return t.Survival(-x)
+	}
+	return 1 - t.Survival(x)
+}
+
+// Survival returns the upper-tail probability P(T > x). It is computed
+// directly from the incomplete beta function, so it stays accurate far
+// into the tail where 1 - CDF(x) would round to zero.
+func (t StudentT) Survival(x float64) float64 {
+	if !t.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if math.IsInf(t.Nu, 1) {
+		return 0.5 * math.Erfc(x/math.Sqrt2)
+	}
+	if x < 0 {
+		return 1 - t.Survival(-x)
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	nu := t.Nu
+	x2 := x * x
+	// Near the centre use P(|T| < x) = I_{x²/(ν+x²)}(1/2, ν/2); once that
+	// mass is large, switch to the tail form P(|T| > x) = I_{ν/(ν+x²)}(ν/2, 1/2)
+	// to avoid cancellation.
+	if central := specfunc.BetaInc(0.5, nu/2, x2/(nu+x2)); central < 0.5 {
+		return 0.5 - 0.5*central
+	}
+	return 0.5 * specfunc.BetaInc(nu/2, 0.5, nu/(nu+x2))
+}
+
+// Quantile returns the value x such that P(T ≤ x) = p.
+// Quantile(0) is -Inf and Quantile(1) is +Inf.
+func (t StudentT) Quantile(p float64) float64 {
+	if !t.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	switch p {
+	case 0:
+		return math.Inf(-1)
+	case 1:
+		return math.Inf(1)
+	case 0.5:
+		return 0
+	}
+	if math.IsInf(t.Nu, 1) {
+		return specfunc.Ndtri(p)
+	}
+
+	// Work with the lower tail q < 0.5 and restore the sign at the end.
+	q, sign := p, -1.0
+	if p > 0.5 {
+		q, sign = 1-p, 1.0
+	}
+	nu := t.Nu
+	if 2*q < 0.5 {
+		x := specfunc.BetaIncInv(nu/2, 0.5, 2*q)
+		return sign * math.Sqrt(nu*(1-x)/x)
+	}
+	y := specfunc.BetaIncInv(0.5, nu/2, 1-2*q)
+	return sign * math.Sqrt(nu*y/(1-y))
+}
+
+// Mean returns the mean, which is 0 for Nu > 1 and undefined (NaN) otherwise.
+func (t StudentT) Mean() float64 {
+	if !t.valid() || t.Nu <= 1 {
+		return math.NaN()
+	}
+	return 0
+}
+
+// Variance returns Nu / (Nu - 2) for Nu > 2, +Inf for 1 < Nu ≤ 2 and NaN otherwise.
+func (t StudentT) Variance() float64 {
+	switch {
+	case !t.valid() || t.Nu <= 1:
+		return math.NaN()
+	case t.Nu <= 2:
+		return math.Inf(1)
+	case math.IsInf(t.Nu, 1):
+		return 1
+	}
+	return t.Nu / (t.Nu - 2)
+}
+
+// Entropy returns the differential entropy
+// (ν+1)/2·[ψ((ν+1)/2) - ψ(ν/2)] + log(√ν·B(ν/2, 1/2)).
+func (t StudentT) Entropy() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	if math.IsInf(t.Nu, 1) {
+		return Normal{Mu: 0, Sigma: 1}.Entropy()
+	}
+	nu := t.Nu
+	return (nu+1)/2*(specfunc.Digamma((nu+1)/2)-specfunc.Digamma(nu/2)) + 0.5*math.Log(nu) + specfunc.LogBeta(nu/2, 0.5)
+}
+
+// Rand draws a random variate as Z/√(V/ν) with Z standard normal and
+// V ~ χ²(ν). A nil rng uses the global math/rand source.
+func (t StudentT) Rand(rng *rand.Rand) float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	z := normFloat(rng)
+	if math.IsInf(t.Nu, 1) {
+		return z
+	}
+	v := 2 * standardGammaRand(t.Nu/2, rng)
+	return z / math.Sqrt(v/t.Nu)
+}
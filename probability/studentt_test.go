@@ -0,0 +1,157 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Upper critical values t_{p,ν} from standard published t-tables.
+func TestStudentTQuantileTable(t *testing.T) {
+	tests := []struct {
+		nu, p, expected float64
+	}{
+		{1, 0.95, 6.314},
+		{1, 0.975, 12.706},
+		{1, 0.995, 63.657},
+		{2, 0.95, 2.920},
+		{2, 0.975, 4.303},
+		{2, 0.995, 9.925},
+		{3, 0.975, 3.182},
+		{4, 0.975, 2.776},
+		{5, 0.95, 2.015},
+		{5, 0.975, 2.571},
+		{5, 0.995, 4.032},
+		{10, 0.95, 1.812},
+		{10, 0.975, 2.228},
+		{10, 0.995, 3.169},
+		{20, 0.975, 2.086},
+		{30, 0.95, 1.697},
+		{30, 0.975, 2.042},
+		{30, 0.995, 2.750},
+		{60, 0.975, 2.000},
+		{100, 0.95, 1.660},
+		{100, 0.975, 1.984},
+		{100, 0.995, 2.626},
+		{120, 0.975, 1.980},
+		{1000, 0.95, 1.646},
+		{1000, 0.975, 1.962},
+		{1000, 0.995, 2.581},
+	}
+
+	for _, tt := range tests {
+		d := StudentT{Nu: tt.nu}
+		got := d.Quantile(tt.p)
+		if math.Abs(got-tt.expected) > 5e-4 {
+			t.Errorf("StudentT{%v}.Quantile(%v) = %.5f; want %.3f", tt.nu, tt.p, got, tt.expected)
+		}
+		// symmetry: the lower quantile is the negated upper one
+		if lower := d.Quantile(1 - tt.p); math.Abs(lower+got) > 1e-9 {
+			t.Errorf("StudentT{%v}.Quantile(%v) = %.6f; want %.6f", tt.nu, 1-tt.p, lower, -got)
+		}
+		// the CDF at the tabulated value recovers the probability
+		if p := d.CDF(tt.expected); math.Abs(p-tt.p) > 2e-4 {
+			t.Errorf("StudentT{%v}.CDF(%v) = %.6f; want %.4f", tt.nu, tt.expected, p, tt.p)
+		}
+	}
+}
+
+func TestStudentTClosedForms(t *testing.T) {
+	// ν = 1 is the standard Cauchy; ν = 2 has an elementary CDF.
+	for _, x := range []float64{-50, -3, -1, -0.2, 0, 0.5, 1, 2.5, 10, 1e4} {
+		got := StudentT{Nu: 1}.CDF(x)
+		want := 0.5 + math.Atan(x)/math.Pi
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("StudentT{1}.CDF(%v) = %.15f; want %.15f", x, got, want)
+		}
+
+		got = StudentT{Nu: 2}.CDF(x)
+		want = 0.5 + x/(2*math.Sqrt(2+x*x))
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("StudentT{2}.CDF(%v) = %.15f; want %.15f", x, got, want)
+		}
+
+		got = StudentT{Nu: 1}.PDF(x)
+		want = 1 / (math.Pi * (1 + x*x))
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("StudentT{1}.PDF(%v) = %.15f; want %.15f", x, got, want)
+		}
+	}
+}
+
+func TestStudentTSurvivalTail(t *testing.T) {
+	// Deep-tail values where 1 - CDF would underflow to zero.
+	tests := []struct {
+		nu, x, expected float64
+	}{
+		{10, 50, 1.23715516465134e-13},
+		{30, 50, 9.3577088296115e-31},
+		{1000, 10, 8.33535147929653e-23},
+	}
+	for _, tt := range tests {
+		got := StudentT{Nu: tt.nu}.Survival(tt.x)
+		if math.Abs(got-tt.expected) > 1e-10*tt.expected {
+			t.Errorf("StudentT{%v}.Survival(%v) = %g; want %g", tt.nu, tt.x, got, tt.expected)
+		}
+	}
+}
+
+func TestStudentTInfiniteNu(t *testing.T) {
+	d := StudentT{Nu: math.Inf(1)}
+	if got, want := d.CDF(1.96), NormalCDF(1.96, 0, 1); math.Abs(got-want) > 1e-12 {
+		t.Errorf("StudentT{Inf}.CDF(1.96) = %v; want %v", got, want)
+	}
+	if got := d.Variance(); got != 1 {
+		t.Errorf("StudentT{Inf}.Variance() = %v; want 1", got)
+	}
+}
+
+func TestStudentTMoments(t *testing.T) {
+	if got := (StudentT{Nu: 5}).Variance(); math.Abs(got-5.0/3) > 1e-12 {
+		t.Errorf("StudentT{5}.Variance() = %v; want %v", got, 5.0/3)
+	}
+	if got := (StudentT{Nu: 2}).Variance(); !math.IsInf(got, 1) {
+		t.Errorf("StudentT{2}.Variance() = %v; want +Inf", got)
+	}
+	if got := (StudentT{Nu: 1}).Mean(); !math.IsNaN(got) {
+		t.Errorf("StudentT{1}.Mean() = %v; want NaN", got)
+	}
+	if got := (StudentT{Nu: 3}).Mean(); got != 0 {
+		t.Errorf("StudentT{3}.Mean() = %v; want 0", got)
+	}
+}
+
+func TestStudentTInvalid(t *testing.T) {
+	for _, nu := range []float64{0, -1, math.NaN()} {
+		d := StudentT{Nu: nu}
+		if !math.IsNaN(d.PDF(0)) || !math.IsNaN(d.CDF(0)) || !math.IsNaN(d.Quantile(0.5)) {
+			t.Errorf("StudentT{%v} should return NaN for invalid degrees of freedom", nu)
+		}
+	}
+	if got := (StudentT{Nu: 5}).Quantile(1.5); !math.IsNaN(got) {
+		t.Errorf("Quantile(1.5) = %v; want NaN", got)
+	}
+	if got := (StudentT{Nu: 5}).Quantile(0); !math.IsInf(got, -1) {
+		t.Errorf("Quantile(0) = %v; want -Inf", got)
+	}
+}
+
+func TestStudentTRand(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := StudentT{Nu: 6}
+	const n = 20000
+	sum, sumSq := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		x := d.Rand(rng)
+		sum += x
+		sumSq += x * x
+	}
+	mean := sum / n
+	variance := sumSq/n - mean*mean
+	if math.Abs(mean) > 0.05 {
+		t.Errorf("sample mean = %.4f; want ≈ 0", mean)
+	}
+	if math.Abs(variance-d.Variance()) > 0.1 {
+		t.Errorf("sample variance = %.4f; want ≈ %.4f", variance, d.Variance())
+	}
+}
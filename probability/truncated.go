@@ -0,0 +1,372 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Truncated returns dist conditioned on lo ≤ X ≤ hi. Either bound may be
+// infinite. The result implements Continuous when dist does and Discrete
+// when dist does, so a truncated Normal can be asserted back to Continuous
+// for its PDF:
+//
+//	tn := probability.Truncated(probability.Normal{Mu: 0, Sigma: 1}, -1, 2).(probability.Continuous)
+//
+// Probabilities are renormalised by Z = P(lo ≤ X ≤ hi), which is computed
+// from whichever of CDF and Survival is accurate at each bound, so the tails
+// stay usable even when Z is far below machine epsilon. Every method
+// returns NaN when lo > hi (or lo = hi for a continuous dist), either bound
+// is NaN, or the interval has zero probability.
+func Truncated(dist Distribution, lo, hi float64) Distribution {
+	t := newTruncated(dist, lo, hi)
+	switch dist.(type) {
+	case Continuous:
+		return truncatedContinuous{t}
+	case Discrete:
+		return truncatedDiscrete{t}
+	}
+	return t
+}
+
+// truncated holds the tail probabilities of the base distribution at the
+// bounds: flo = P(X < lo), slo = P(X ≥ lo), fhi = P(X ≤ hi), shi = P(X > hi).
+type truncated struct {
+	dist               Distribution
+	lo, hi             float64
+	discrete           bool
+	flo, slo, fhi, shi float64
+	z                  float64
+}
+
+type truncatedContinuous struct{ truncated }
+
+type truncatedDiscrete struct{ truncated }
+
+func newTruncated(dist Distribution, lo, hi float64) truncated {
+	t := truncated{dist: dist, lo: lo, hi: hi, z: math.NaN()}
+	_, t.discrete = dist.(Discrete)
+	if dist == nil || math.IsNaN(lo) || math.IsNaN(hi) || lo > hi || (lo == hi && !t.discrete) {
+		return t
+	}
+	if t.discrete {
+		// P(X < lo) = P(X ≤ ⌈lo⌉ - 1) on the integers
+		below := math.Ceil(lo) - 1
+		t.flo, t.slo = dist.CDF(below), dist.Survival(below)
+	} else {
+		t.flo, t.slo = dist.CDF(lo), dist.Survival(lo)
+	}
+	t.fhi, t.shi = dist.CDF(hi), dist.Survival(hi)
+	switch {
+	case t.flo >= 0.5:
+		t.z = t.slo - t.shi
+	case t.shi >= 0.5:
+		t.z = t.fhi - t.flo
+	default:
+		t.z = 1 - t.flo - t.shi
+	}
+	if !(t.z > 0) {
+		t.z = math.NaN()
+	}
+	return t
+}
+
+func (t truncated) valid() bool { return t.z > 0 }
+
+// CDF returns P(X ≤ x | lo ≤ X ≤ hi).
+func (t truncated) CDF(x float64) float64 {
+	switch {
+	case !t.valid() || math.IsNaN(x):
+		return math.NaN()
+	case x < t.lo:
+		return 0
+	case x >= t.hi:
+		return 1
+	}
+	var p float64
+	if f := t.dist.CDF(x); f < 0.5 {
+		p = (f - t.flo) / t.z
+	} else {
+		p = (t.slo - t.dist.Survival(x)) / t.z
+	}
+	return math.Max(0, math.Min(1, p))
+}
+
+// Survival returns P(X > x | lo ≤ X ≤ hi).
+func (t truncated) Survival(x float64) float64 {
+	switch {
+	case !t.valid() || math.IsNaN(x):
+		return math.NaN()
+	case x < t.lo:
+		return 1
+	case x >= t.hi:
+		return 0
+	}
+	var q float64
+	if s := t.dist.Survival(x); s < 0.5 {
+		q = (s - t.shi) / t.z
+	} else {
+		q = (t.fhi - t.dist.CDF(x)) / t.z
+	}
+	return math.Max(0, math.Min(1, q))
+}
+
+// Quantile maps p into the base distribution's quantile function, then
+// refines the result by bisection on the truncated CDF when Z is too small
+// for that mapping to be accurate or p is within rounding of 1. Discrete
+// quantiles are found directly by bisection on the truncated CDF.
+func (t truncated) Quantile(p float64) float64 {
+	if !t.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	lo := math.Max(t.lo, t.dist.Quantile(0))
+	hi := math.Min(t.hi, t.dist.Quantile(1))
+	switch p {
+	case 0:
+		if t.discrete {
+			return math.Ceil(lo)
+		}
+		return lo
+	case 1:
+		if t.discrete {
+			return math.Floor(hi)
+		}
+		return hi
+	}
+	if t.discrete {
+		end := -1
+		if !math.IsInf(hi, 1) {
+			end = int(hi)
+		}
+		return float64(discreteQuantile(p, int(math.Ceil(lo)), end, func(k int) float64 { return t.CDF(float64(k)) }))
+	}
+
+	var x float64
+	if q := t.flo + p*t.z; q <= 0.5 {
+		x = t.dist.Quantile(q)
+	} else {
+		x = t.dist.Quantile(1 - (t.shi + (1-p)*t.z))
+	}
+	// the mapping loses precision when Z is tiny, and returns ±Inf when the
+	// target rounds to 0 or 1
+	if t.z < 1e-3 || math.IsInf(x, 0) {
+		x = math.Max(lo, math.Min(hi, x))
+		return t.bisectQuantile(p, x, lo, hi)
+	}
+	return math.Max(lo, math.Min(hi, x))
+}
+
+// bisectQuantile solves CDF(x) = p on [lo, hi], starting from the bracket
+// that guess splits off and expanding an infinite end by doubling.
+func (t truncated) bisectQuantile(p, guess, lo, hi float64) float64 {
+	a, b := lo, hi
+	if guess > a && guess < b {
+		if t.CDF(guess) < p {
+			a = guess
+		} else {
+			b = guess
+		}
+	}
+	for step := 1.0; math.IsInf(b, 1); step *= 2 {
+		if x := a + step; t.CDF(x) >= p {
+			b = x
+		} else {
+			a = x
+		}
+	}
+	for step := 1.0; math.IsInf(a, -1); step *= 2 {
+		if x := b - step; t.CDF(x) < p {
+			a = x
+		} else {
+			b = x
+		}
+	}
+	for i := 0; i < 200; i++ {
+		mid := a + (b-a)/2
+		if mid <= a || mid >= b {
+			break
+		}
+		if t.CDF(mid) < p {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+	return b
+}
+
+// Mean returns E[X | lo ≤ X ≤ hi], integrated numerically over the
+// quantile function. When an unbounded side is kept and the base mean is
+// not finite, the base mean is returned.
+func (t truncated) Mean() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	if m := t.dist.Mean(); t.unbounded() && (math.IsNaN(m) || math.IsInf(m, 0)) {
+		return m
+	}
+	return integrateUnit(t.Quantile)
+}
+
+// Variance returns Var(X | lo ≤ X ≤ hi), integrated numerically as for Mean.
+func (t truncated) Variance() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	if v := t.dist.Variance(); t.unbounded() && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		return v
+	}
+	m := t.Mean()
+	return integrateUnit(func(u float64) float64 {
+		d := t.Quantile(u) - m
+		return d * d
+	})
+}
+
+func (t truncated) unbounded() bool {
+	return math.IsInf(t.lo, -1) || math.IsInf(t.hi, 1)
+}
+
+// Entropy is not defined without a density or mass function and returns NaN.
+func (t truncated) Entropy() float64 { return math.NaN() }
+
+// Rand draws from the base distribution and rejects draws outside
+// [lo, hi] while at least a quarter of them are accepted; narrower
+// truncations use inversion, Quantile(U).
+func (t truncated) Rand(rng *rand.Rand) float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	if t.z >= 0.25 {
+		for {
+			if x := t.dist.Rand(rng); x >= t.lo && x <= t.hi {
+				return x
+			}
+		}
+	}
+	return t.Quantile(uniformOpen(rng))
+}
+
+// PDF returns f(x) / Z on [lo, hi] and 0 elsewhere.
+func (t truncatedContinuous) PDF(x float64) float64 {
+	return math.Exp(t.LogPDF(x))
+}
+
+// LogPDF returns log f(x) - log Z on [lo, hi] and -Inf elsewhere.
+func (t truncatedContinuous) LogPDF(x float64) float64 {
+	if !t.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < t.lo || x > t.hi {
+		return math.Inf(-1)
+	}
+	return t.dist.(Continuous).LogPDF(x) - math.Log(t.z)
+}
+
+// Entropy returns -E[log PDF(X)], integrated numerically over the
+// quantile function.
+func (t truncatedContinuous) Entropy() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	return -integrateUnit(func(u float64) float64 { return t.LogPDF(t.Quantile(u)) })
+}
+
+// PMF returns P(X = k) / Z for lo ≤ k ≤ hi and 0 elsewhere.
+func (t truncatedDiscrete) PMF(k int) float64 {
+	return math.Exp(t.LogPMF(k))
+}
+
+// LogPMF returns log P(X = k) - log Z for lo ≤ k ≤ hi and -Inf elsewhere.
+func (t truncatedDiscrete) LogPMF(k int) float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	if kf := float64(k); kf < t.lo || kf > t.hi {
+		return math.Inf(-1)
+	}
+	return t.dist.(Discrete).LogPMF(k) - math.Log(t.z)
+}
+
+// sum returns Σ g(k)·PMF(k) over every k that carries more than 1e-20 of
+// the truncated probability, on either side.
+func (t truncatedDiscrete) sum(g func(k int, pk float64) float64) float64 {
+	k := int(t.Quantile(1e-20))
+	total := 0.0
+	for ; float64(k) <= t.hi; k++ {
+		total += g(k, t.PMF(k))
+		if t.Survival(float64(k)) < 1e-20 {
+			break
+		}
+	}
+	return total
+}
+
+// Mean returns E[X | lo ≤ X ≤ hi], summed over the support.
+func (t truncatedDiscrete) Mean() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	return t.sum(func(k int, pk float64) float64 { return float64(k) * pk })
+}
+
+// Variance returns Var(X | lo ≤ X ≤ hi), summed over the support.
+func (t truncatedDiscrete) Variance() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	m := t.Mean()
+	return t.sum(func(k int, pk float64) float64 { return (float64(k) - m) * (float64(k) - m) * pk })
+}
+
+// Entropy returns -Σ PMF(k)·log PMF(k) in nats.
+func (t truncatedDiscrete) Entropy() float64 {
+	if !t.valid() {
+		return math.NaN()
+	}
+	return t.sum(func(k int, pk float64) float64 {
+		if pk == 0 {
+			return 0
+		}
+		return -pk * math.Log(pk)
+	})
+}
+
+// integrateUnit approximates ∫₀¹ f(u) du by tanh-sinh quadrature, halving
+// the step until successive estimates agree to about 1e-10. The nodes
+// cluster at the ends, so integrable endpoint singularities such as those
+// of an unbounded quantile function are handled well.
+func integrateUnit(f func(u float64) float64) float64 {
+	const tMax = 3.5
+	// node returns the abscissa u(s) = (1 + tanh(π/2·sinh s))/2 and its weight du/ds
+	node := func(s float64) (u, w float64) {
+		e := math.Pi / 2 * math.Sinh(s)
+		ch := math.Cosh(e)
+		return 1 / (1 + math.Exp(-2*e)), math.Pi / 4 * math.Cosh(s) / (ch * ch)
+	}
+	add := func(s float64) float64 {
+		u, w := node(s)
+		if w == 0 || u <= 0 || u >= 1 {
+			return 0
+		}
+		return w * f(u)
+	}
+
+	h := 0.5
+	sum := add(0)
+	for s := h; s <= tMax; s += h {
+		sum += add(s) + add(-s)
+	}
+	estimate := h * sum
+	for level := 0; level < 8; level++ {
+		h /= 2
+		for s := h; s <= tMax; s += 2 * h {
+			sum += add(s) + add(-s)
+		}
+		next := h * sum
+		if math.Abs(next-estimate) <= 1e-10*math.Abs(next) {
+			return next
+		}
+		estimate = next
+	}
+	return estimate
+}
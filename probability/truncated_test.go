@@ -0,0 +1,135 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTruncatedNormalMoments(t *testing.T) {
+	phi := func(x float64) float64 { return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi) }
+	upper := func(x float64) float64 { return 0.5 * math.Erfc(x/math.Sqrt2) }
+	tests := []struct{ mu, sigma, a, b float64 }{
+		{0, 1, -1, 2},
+		{2, 3, 2, math.Inf(1)},
+		{0, 1, 9, 10}, // Z ≈ 1.1e-19
+		{0, 1, math.Inf(-1), -12},
+	}
+	for _, tt := range tests {
+		d := Truncated(Normal{Mu: tt.mu, Sigma: tt.sigma}, tt.a, tt.b)
+		al, be := (tt.a-tt.mu)/tt.sigma, (tt.b-tt.mu)/tt.sigma
+		z := upper(al) - upper(be)
+		r := (phi(al) - phi(be)) / z
+		wantMean := tt.mu + tt.sigma*r
+		// αφ(α) and βφ(β) vanish at infinite bounds
+		tail := 0.0
+		if !math.IsInf(al, 0) {
+			tail += al * phi(al)
+		}
+		if !math.IsInf(be, 0) {
+			tail -= be * phi(be)
+		}
+		wantVar := tt.sigma * tt.sigma * (1 + tail/z - r*r)
+
+		if got := d.Mean(); math.Abs(got-wantMean) > 1e-8*math.Max(1, math.Abs(wantMean)) {
+			t.Errorf("[%v, %v] Mean() = %.12g; want %.12g", tt.a, tt.b, got, wantMean)
+		}
+		if got := d.Variance(); math.Abs(got-wantVar) > 1e-7*wantVar {
+			t.Errorf("[%v, %v] Variance() = %.12g; want %.12g", tt.a, tt.b, got, wantVar)
+		}
+		if got, want := d.(Continuous).PDF((tt.a+tt.b)/2), phi(((tt.a+tt.b)/2-tt.mu)/tt.sigma)/(tt.sigma*z); !math.IsInf(tt.a, 0) && !math.IsInf(tt.b, 0) && math.Abs(got-want) > 1e-12*want {
+			t.Errorf("[%v, %v] PDF(mid) = %v; want %v", tt.a, tt.b, got, want)
+		}
+	}
+}
+
+func TestTruncatedTailQuantile(t *testing.T) {
+	// the upper 1e-19 tail of the normal is still resolved to full precision
+	d := Truncated(Normal{Mu: 0, Sigma: 1}, 9, math.Inf(1))
+	for _, p := range []float64{1e-6, 0.5, 0.999999} {
+		x := d.Quantile(p)
+		if x < 9 || math.IsInf(x, 0) {
+			t.Fatalf("Quantile(%v) = %v; want a finite value above 9", p, x)
+		}
+		if got := d.CDF(x); math.Abs(got-p) > 1e-9 {
+			t.Errorf("CDF(Quantile(%v)) = %v", p, got)
+		}
+	}
+	if got := d.Quantile(0); got != 9 {
+		t.Errorf("Quantile(0) = %v; want 9", got)
+	}
+}
+
+func TestTruncatedExponentialIsShifted(t *testing.T) {
+	// memorylessness: Exp(λ) truncated to [2, ∞) is Exp(λ) shifted by 2
+	tr := Truncated(Exponential{Lambda: 1.5}, 2, math.Inf(1)).(Continuous)
+	sh := LocationScale(Exponential{Lambda: 1.5}, 2, 1).(Continuous)
+	for _, x := range []float64{1, 2, 2.3, 4, 9} {
+		if math.Abs(tr.CDF(x)-sh.CDF(x)) > 1e-12 || math.Abs(tr.PDF(x)-sh.PDF(x)) > 1e-12 {
+			t.Errorf("x=%v: truncated CDF/PDF %v/%v; shifted %v/%v", x, tr.CDF(x), tr.PDF(x), sh.CDF(x), sh.PDF(x))
+		}
+	}
+	if got := tr.Mean(); math.Abs(got-(2+1/1.5)) > 1e-9 {
+		t.Errorf("Mean() = %v; want %v", got, 2+1/1.5)
+	}
+}
+
+func TestTruncatedHeavyTail(t *testing.T) {
+	c := Cauchy{X0: 0, Gamma: 1}
+	if got := Truncated(c, 0, math.Inf(1)).Mean(); !math.IsNaN(got) {
+		t.Errorf("one-sided truncated Cauchy Mean() = %v; want NaN", got)
+	}
+	d := Truncated(c, -1, 1)
+	if got := d.Mean(); math.Abs(got) > 1e-10 {
+		t.Errorf("Cauchy on [-1, 1] Mean() = %v; want 0", got)
+	}
+	if got, want := d.Variance(), 4/math.Pi-1; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Cauchy on [-1, 1] Variance() = %v; want %v", got, want)
+	}
+}
+
+func TestTruncatedDiscrete(t *testing.T) {
+	d := Truncated(Poisson{Lambda: 3}, 3, 3).(Discrete)
+	if d.PMF(3) != 1 || d.PMF(2) != 0 || d.Mean() != 3 || d.Variance() != 0 {
+		t.Errorf("single-point truncation: PMF(3)=%v Mean=%v Variance=%v", d.PMF(3), d.Mean(), d.Variance())
+	}
+	// a fractional lower bound rounds up to the next integer
+	g := Truncated(Geometric{P: 0.4}, 1.5, math.Inf(1)).(Discrete)
+	if g.PMF(1) != 0 || g.Quantile(0) != 2 {
+		t.Errorf("PMF(1) = %v, Quantile(0) = %v; want 0 and 2", g.PMF(1), g.Quantile(0))
+	}
+	if got, want := g.Mean(), 2+(Geometric{P: 0.4}).Mean(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Mean() = %v; want %v", got, want)
+	}
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 1000; i++ {
+		if x := g.Rand(rng); x < 2 || x != math.Trunc(x) {
+			t.Fatalf("Rand() = %v outside the truncated support", x)
+		}
+	}
+}
+
+func TestTruncatedInterfaces(t *testing.T) {
+	if _, ok := Truncated(Normal{Mu: 0, Sigma: 1}, 0, 1).(Discrete); ok {
+		t.Error("truncated Normal should not be Discrete")
+	}
+	if _, ok := Truncated(Binomial{N: 10, P: 0.5}, 2, 5).(Discrete); !ok {
+		t.Error("truncated Binomial should be Discrete")
+	}
+}
+
+func TestTruncatedInvalid(t *testing.T) {
+	for _, d := range []Distribution{
+		Truncated(Normal{Mu: 0, Sigma: 1}, 2, 1),
+		Truncated(Normal{Mu: 0, Sigma: 1}, 1, 1),
+		Truncated(Normal{Mu: 0, Sigma: 1}, math.NaN(), 1),
+		Truncated(Normal{Mu: 0, Sigma: 1}, 50, 60), // no representable mass
+		Truncated(Poisson{Lambda: 2}, 3.2, 3.8),    // no integer inside
+		Truncated(Normal{Mu: 0, Sigma: -1}, 0, 1),
+		Truncated(nil, 0, 1),
+	} {
+		if !math.IsNaN(d.CDF(0.5)) || !math.IsNaN(d.Mean()) || !math.IsNaN(d.Quantile(0.5)) || !math.IsNaN(d.Rand(nil)) {
+			t.Errorf("%v should return NaN", d)
+		}
+	}
+}
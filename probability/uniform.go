@@ -1,15 +1,118 @@
 package probability
 
+import (
+	"math"
+	"math/rand"
+)
+
+// Uniform is the continuous uniform distribution on [A, B].
+// A must be less than B and both finite; methods return NaN otherwise.
+type Uniform struct {
+	A, B float64
+}
+
+func (u Uniform) valid() bool {
+	return u.A < u.B && !math.IsInf(u.A, 0) && !math.IsInf(u.B, 0)
+}
+
+// PDF returns the probability density at x.
+func (u Uniform) PDF(x float64) float64 {
+	if !u.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < u.A || x > u.B {
+		return 0
+	}
+	return 1 / (u.B - u.A)
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (u Uniform) LogPDF(x float64) float64 {
+	if !u.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < u.A || x > u.B {
+		return math.Inf(-1)
+	}
+	return -math.Log(u.B - u.A)
+}
+
+// CDF returns P(X ≤ x).
+func (u Uniform) CDF(x float64) float64 {
+	if !u.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < u.A:
+		return 0
+	case x > u.B:
+		return 1
+	}
+	return (x - u.A) / (u.B - u.A)
+}
+
+// Survival returns P(X > x).
+func (u Uniform) Survival(x float64) float64 {
+	if !u.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < u.A:
+		return 1
+	case x > u.B:
+		return 0
+	}
+	return (u.B - x) / (u.B - u.A)
+}
+
+// Quantile returns the value x such that P(X ≤ x) = p.
+func (u Uniform) Quantile(p float64) float64 {
+	if !u.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return u.A + p*(u.B-u.A)
+}
+
+// Mean returns (A + B) / 2.
+func (u Uniform) Mean() float64 {
+	if !u.valid() {
+		return math.NaN()
+	}
+	return (u.A + u.B) / 2
+}
+
+// Variance returns (B - A)² / 12.
+func (u Uniform) Variance() float64 {
+	if !u.valid() {
+		return math.NaN()
+	}
+	w := u.B - u.A
+	return w * w / 12
+}
+
+// Entropy returns log(B - A).
+func (u Uniform) Entropy() float64 {
+	if !u.valid() {
+		return math.NaN()
+	}
+	return math.Log(u.B - u.A)
+}
+
+// Rand draws a random variate. A nil rng uses the global math/rand source.
+func (u Uniform) Rand(rng *rand.Rand) float64 {
+	if !u.valid() {
+		return math.NaN()
+	}
+	return u.A + (u.B-u.A)*uniformOpen(rng)
+}
+
 // UniformPDF returns the probability density for a continuous uniform distribution
 // between a and b evaluated at x.
 func UniformPDF(x, a, b float64) float64 {
 	if a >= b {
 		panic("Invalid bounds: a must be less than b")
 	}
-	if x < a || x > b {
-		return 0.0
-	}
-	return 1.0 / (b - a)
+	return Uniform{A: a, B: b}.PDF(x)
 }
 
 // UniformCDF returns the cumulative distribution function value
@@ -18,10 +121,17 @@ func UniformCDF(x, a, b float64) float64 {
 	if a >= b {
 		panic("Invalid bounds: a must be less than b")
 	}
-	if x < a {
-		return 0.0
-	} else if x > b {
-		return 1.0
+	return Uniform{A: a, B: b}.CDF(x)
+}
+
+// UniformQuantile returns the x such that P(X ≤ x) = p for a continuous
+// uniform distribution between a and b, a + p·(b - a).
+func UniformQuantile(p, a, b float64) float64 {
+	if a >= b {
+		panic("Invalid bounds: a must be less than b")
+	}
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		panic("UniformQuantile: p must be in [0,1]")
 	}
-	return (x - a) / (b - a)
+	return Uniform{A: a, B: b}.Quantile(p)
 }
@@ -62,3 +62,24 @@ func TestUniformCDF(t *testing.T) {
 		}()
 	}
 }
+
+func TestUniformQuantile(t *testing.T) {
+	tests := []struct{ p, a, b, want float64 }{
+		{0, -1, 3, -1}, {0.25, -1, 3, 0}, {0.5, 2, 4, 3}, {1, 2, 4, 4},
+	}
+	for _, tt := range tests {
+		if got := UniformQuantile(tt.p, tt.a, tt.b); got != tt.want {
+			t.Errorf("UniformQuantile(%v, %v, %v) = %v; want %v", tt.p, tt.a, tt.b, got, tt.want)
+		}
+	}
+	for _, in := range [][3]float64{{0.5, 2, 2}, {-0.5, 0, 1}, {math.NaN(), 0, 1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for UniformQuantile(%v, %v, %v)", in[0], in[1], in[2])
+				}
+			}()
+			_ = UniformQuantile(in[0], in[1], in[2])
+		}()
+	}
+}
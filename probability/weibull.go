@@ -0,0 +1,118 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+)
+
+// eulerGamma is the Euler–Mascheroni constant γ = -ψ(1).
+const eulerGamma = 0.57721566490153286060651209008240243
+
+// Weibull is the Weibull distribution with shape K and scale Lambda.
+// Both must be > 0; methods return NaN otherwise.
+type Weibull struct {
+	K, Lambda float64
+}
+
+func (w Weibull) valid() bool {
+	return w.K > 0 && w.Lambda > 0 && !math.IsInf(w.K, 1) && !math.IsInf(w.Lambda, 1)
+}
+
+// PDF returns the probability density at x; it is 0 for x < 0.
+func (w Weibull) PDF(x float64) float64 {
+	if !w.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || math.IsInf(x, 1) {
+		return 0
+	}
+	z := x / w.Lambda
+	return w.K / w.Lambda * math.Pow(z, w.K-1) * math.Exp(-math.Pow(z, w.K))
+}
+
+// LogPDF returns the logarithm of the density at x.
+func (w Weibull) LogPDF(x float64) float64 {
+	if !w.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		switch {
+		case w.K < 1:
+			return math.Inf(1)
+		case w.K == 1:
+			return -math.Log(w.Lambda)
+		default:
+			return math.Inf(-1)
+		}
+	}
+	z := x / w.Lambda
+	return math.Log(w.K/w.Lambda) + (w.K-1)*math.Log(z) - math.Pow(z, w.K)
+}
+
+// CDF returns P(X ≤ x) = 1 - exp(-(x/Lambda)^K).
+func (w Weibull) CDF(x float64) float64 {
+	if !w.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-math.Pow(x/w.Lambda, w.K))
+}
+
+// Survival returns P(X > x) = exp(-(x/Lambda)^K).
+func (w Weibull) Survival(x float64) float64 {
+	if !w.valid() || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	return math.Exp(-math.Pow(x/w.Lambda, w.K))
+}
+
+// Quantile returns Lambda·(-log(1-p))^(1/K).
+func (w Weibull) Quantile(p float64) float64 {
+	if !w.valid() || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return w.Lambda * math.Pow(-math.Log1p(-p), 1/w.K)
+}
+
+// Mean returns Lambda·Γ(1 + 1/K).
+func (w Weibull) Mean() float64 {
+	if !w.valid() {
+		return math.NaN()
+	}
+	return w.Lambda * math.Gamma(1+1/w.K)
+}
+
+// Variance returns Lambda²·(Γ(1 + 2/K) - Γ(1 + 1/K)²).
+func (w Weibull) Variance() float64 {
+	if !w.valid() {
+		return math.NaN()
+	}
+	g1 := math.Gamma(1 + 1/w.K)
+	return w.Lambda * w.Lambda * (math.Gamma(1+2/w.K) - g1*g1)
+}
+
+// Entropy returns γ·(1 - 1/K) + log(Lambda/K) + 1, γ being the
+// Euler–Mascheroni constant.
+func (w Weibull) Entropy() float64 {
+	if !w.valid() {
+		return math.NaN()
+	}
+	return eulerGamma*(1-1/w.K) + math.Log(w.Lambda/w.K) + 1
+}
+
+// Rand draws a random variate as Lambda·E^(1/K) with E standard
+// exponential. A nil rng uses the global math/rand source.
+func (w Weibull) Rand(rng *rand.Rand) float64 {
+	if !w.valid() {
+		return math.NaN()
+	}
+	return w.Lambda * math.Pow(expFloat(rng), 1/w.K)
+}
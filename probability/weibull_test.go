@@ -0,0 +1,45 @@
+package probability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeibullClosedForms(t *testing.T) {
+	d := Weibull{K: 2, Lambda: 1}
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PDF(1)", d.PDF(1), 2 * math.Exp(-1)},
+		{"CDF(1)", d.CDF(1), 1 - math.Exp(-1)},
+		{"Survival(2)", d.Survival(2), math.Exp(-4)},
+		{"Quantile(1-1/e)", d.Quantile(1 - math.Exp(-1)), 1},
+		{"Mean", d.Mean(), math.Sqrt(math.Pi) / 2},
+		{"Variance", d.Variance(), 1 - math.Pi/4},
+		{"Entropy", d.Entropy(), eulerGamma/2 - math.Log(2) + 1},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-12 {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// shape 1 is the exponential distribution with rate 1/Lambda
+	w, e := Weibull{K: 1, Lambda: 4}, Exponential{Lambda: 0.25}
+	for _, x := range []float64{0, 0.5, 3, 10} {
+		if math.Abs(w.PDF(x)-e.PDF(x)) > 1e-15 || math.Abs(w.CDF(x)-e.CDF(x)) > 1e-15 {
+			t.Errorf("Weibull{1, 4} differs from Exponential{0.25} at %v", x)
+		}
+	}
+	if math.Abs(w.Entropy()-e.Entropy()) > 1e-14 {
+		t.Errorf("Entropy = %v; want %v", w.Entropy(), e.Entropy())
+	}
+
+	if !math.IsInf(Weibull{K: 0.5, Lambda: 1}.PDF(0), 1) || d.PDF(0) != 0 || d.PDF(-1) != 0 {
+		t.Error("unexpected density at the origin")
+	}
+	if !math.IsNaN(Weibull{K: 0, Lambda: 1}.CDF(1)) || !math.IsNaN(d.Quantile(2)) {
+		t.Error("expected NaN for invalid input")
+	}
+}
@@ -0,0 +1,299 @@
+package probability
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+	"github.com/cyber-mountain-man/statistical-go/internal/linalg"
+)
+
+// Wishart is the distribution of Σᵢ xᵢxᵢᵀ for ν independent draws
+// xᵢ ~ N(0, V), generalised to real ν > p - 1 for a p×p symmetric
+// positive-definite scale matrix V. It is the conjugate prior of a
+// multivariate normal precision matrix.
+//
+// Like MultivariateNormal it is built with a constructor that validates the
+// parameters and caches the Cholesky factor of V.
+type Wishart struct {
+	nu     float64
+	scale  [][]float64
+	chol   [][]float64
+	logDet float64
+}
+
+// NewWishart returns the Wishart distribution with nu degrees of freedom and
+// scale matrix scale. It returns an error unless scale is symmetric positive
+// definite and nu > p - 1. The scale matrix is copied.
+func NewWishart(nu float64, scale [][]float64) (Wishart, error) {
+	p := len(scale)
+	if p == 0 {
+		return Wishart{}, errs.New(ErrEmptyInput, "Wishart: scale must not be empty")
+	}
+	if !(nu > float64(p-1)) || math.IsInf(nu, 1) {
+		return Wishart{}, errs.New(ErrInvalidParameter, "Wishart: degrees of freedom must exceed p - 1")
+	}
+	chol, err := choleskySPD(scale, p)
+	if err != nil {
+		return Wishart{}, fmt.Errorf("Wishart: scale %w", err)
+	}
+	return Wishart{nu: nu, scale: copyMatrix(scale), chol: chol, logDet: linalg.LogDetCholesky(chol)}, nil
+}
+
+// Dim returns the dimension p of the matrices.
+func (w Wishart) Dim() int { return len(w.scale) }
+
+// DF returns the degrees of freedom ν.
+func (w Wishart) DF() float64 { return w.nu }
+
+// Scale returns a copy of the scale matrix V.
+func (w Wishart) Scale() [][]float64 { return copyMatrix(w.scale) }
+
+// Mean returns ν·V, or nil for a zero-value Wishart.
+func (w Wishart) Mean() [][]float64 {
+	if w.Dim() == 0 {
+		return nil
+	}
+	mean := copyMatrix(w.scale)
+	for i := range mean {
+		for j := range mean[i] {
+			mean[i][j] *= w.nu
+		}
+	}
+	return mean
+}
+
+// LogPDF returns
+//
+//	log f(X) = ½(ν-p-1)·log|X| - ½tr(V⁻¹X) - ½νp·log 2 - ½ν·log|V| - log Γₚ(ν/2).
+//
+// It is -Inf when X is not symmetric positive definite and NaN for a zero-value
+// Wishart or when X is not p×p.
+func (w Wishart) LogPDF(X [][]float64) float64 {
+	p := w.Dim()
+	if p == 0 || !isSquare(X, p) {
+		return math.NaN()
+	}
+	cx, err := choleskySPD(X, p)
+	if err != nil {
+		return math.Inf(-1)
+	}
+	pf := float64(p)
+	return 0.5*(w.nu-pf-1)*linalg.LogDetCholesky(cx) - 0.5*traceSolve(w.chol, X) -
+		0.5*w.nu*pf*math.Ln2 - 0.5*w.nu*w.logDet - logMultiGamma(p, w.nu/2)
+}
+
+// PDF returns the density at X, evaluated in log space.
+func (w Wishart) PDF(X [][]float64) float64 {
+	return math.Exp(w.LogPDF(X))
+}
+
+// Rand draws a matrix with the Bartlett decomposition L·A·Aᵀ·Lᵀ, where L is
+// the Cholesky factor of V and A is lower triangular with χ²(ν-i) diagonal
+// and standard normal off-diagonal entries. A nil rng uses the global
+// math/rand source. Returns nil for a zero-value Wishart.
+func (w Wishart) Rand(rng *rand.Rand) [][]float64 {
+	if w.Dim() == 0 {
+		return nil
+	}
+	return bartlettRand(w.nu, w.chol, rng)
+}
+
+// InverseWishart is the distribution of X⁻¹ when X is Wishart with ν degrees
+// of freedom and scale Ψ⁻¹, for real ν > p - 1 and a p×p symmetric
+// positive-definite scale matrix Ψ. It is the conjugate prior of a
+// multivariate normal covariance matrix; see Posterior.
+type InverseWishart struct {
+	nu      float64
+	scale   [][]float64
+	logDet  float64
+	invChol [][]float64 // Cholesky factor of Ψ⁻¹, for sampling
+}
+
+// NewInverseWishart returns the inverse Wishart distribution with nu degrees
+// of freedom and scale matrix scale. It returns an error unless scale is
+// symmetric positive definite and nu > p - 1. The scale matrix is copied.
+func NewInverseWishart(nu float64, scale [][]float64) (InverseWishart, error) {
+	p := len(scale)
+	if p == 0 {
+		return InverseWishart{}, errs.New(ErrEmptyInput, "InverseWishart: scale must not be empty")
+	}
+	if !(nu > float64(p-1)) || math.IsInf(nu, 1) {
+		return InverseWishart{}, errs.New(ErrInvalidParameter, "InverseWishart: degrees of freedom must exceed p - 1")
+	}
+	chol, err := choleskySPD(scale, p)
+	if err != nil {
+		return InverseWishart{}, fmt.Errorf("InverseWishart: scale %w", err)
+	}
+	inv := choleskyInverse(chol)
+	invChol, err := linalg.Cholesky(inv)
+	if err != nil {
+		return InverseWishart{}, errs.New(ErrSingularMatrix, "InverseWishart: scale is too ill-conditioned to invert")
+	}
+	return InverseWishart{nu: nu, scale: copyMatrix(scale), logDet: linalg.LogDetCholesky(chol), invChol: invChol}, nil
+}
+
+// Dim returns the dimension p of the matrices.
+func (w InverseWishart) Dim() int { return len(w.scale) }
+
+// DF returns the degrees of freedom ν.
+func (w InverseWishart) DF() float64 { return w.nu }
+
+// Scale returns a copy of the scale matrix Ψ.
+func (w InverseWishart) Scale() [][]float64 { return copyMatrix(w.scale) }
+
+// Mean returns Ψ / (ν - p - 1), or nil when ν ≤ p + 1 and the mean does not
+// exist.
+func (w InverseWishart) Mean() [][]float64 {
+	p := float64(w.Dim())
+	if w.Dim() == 0 || !(w.nu > p+1) {
+		return nil
+	}
+	mean := copyMatrix(w.scale)
+	for i := range mean {
+		for j := range mean[i] {
+			mean[i][j] /= w.nu - p - 1
+		}
+	}
+	return mean
+}
+
+// LogPDF returns
+//
+//	log f(X) = ½ν·log|Ψ| - ½νp·log 2 - log Γₚ(ν/2) - ½(ν+p+1)·log|X| - ½tr(ΨX⁻¹).
+//
+// It is -Inf when X is not symmetric positive definite and NaN for a
+// zero-value InverseWishart or when X is not p×p.
+func (w InverseWishart) LogPDF(X [][]float64) float64 {
+	p := w.Dim()
+	if p == 0 || !isSquare(X, p) {
+		return math.NaN()
+	}
+	cx, err := choleskySPD(X, p)
+	if err != nil {
+		return math.Inf(-1)
+	}
+	pf := float64(p)
+	return 0.5*w.nu*w.logDet - 0.5*w.nu*pf*math.Ln2 - logMultiGamma(p, w.nu/2) -
+		0.5*(w.nu+pf+1)*linalg.LogDetCholesky(cx) - 0.5*traceSolve(cx, w.scale)
+}
+
+// PDF returns the density at X, evaluated in log space.
+func (w InverseWishart) PDF(X [][]float64) float64 {
+	return math.Exp(w.LogPDF(X))
+}
+
+// Rand draws a Wishart(ν, Ψ⁻¹) matrix by the Bartlett decomposition and
+// returns its inverse. A nil rng uses the global math/rand source. Returns
+// nil for a zero-value InverseWishart.
+func (w InverseWishart) Rand(rng *rand.Rand) [][]float64 {
+	if w.Dim() == 0 {
+		return nil
+	}
+	chol, err := linalg.Cholesky(bartlettRand(w.nu, w.invChol, rng))
+	if err != nil {
+		return nil
+	}
+	return choleskyInverse(chol)
+}
+
+// Posterior returns the InverseWishart(ν + n, Ψ + Σ(xᵢ-μ)(xᵢ-μ)ᵀ) posterior
+// of a normal covariance matrix after observing the n rows of data, which
+// are drawn from a multivariate normal with known mean mu. It returns an
+// error if data is empty or any row or mu has the wrong length.
+func (w InverseWishart) Posterior(data [][]float64, mu []float64) (InverseWishart, error) {
+	p := w.Dim()
+	if p == 0 || len(data) == 0 {
+		return InverseWishart{}, errs.New(ErrEmptyInput, "InverseWishart: data must not be empty")
+	}
+	if len(mu) != p {
+		return InverseWishart{}, errs.New(ErrLengthMismatch, "InverseWishart: mean must have length p")
+	}
+	scale := copyMatrix(w.scale)
+	for _, x := range data {
+		if len(x) != p {
+			return InverseWishart{}, errs.New(ErrLengthMismatch, "InverseWishart: each observation must have length p")
+		}
+		for i := 0; i < p; i++ {
+			for j := 0; j < p; j++ {
+				scale[i][j] += (x[i] - mu[i]) * (x[j] - mu[j])
+			}
+		}
+	}
+	return NewInverseWishart(w.nu+float64(len(data)), scale)
+}
+
+// bartlettRand returns (L·A)(L·A)ᵀ for the Bartlett factor A described at
+// Wishart.Rand.
+func bartlettRand(nu float64, L [][]float64, rng *rand.Rand) [][]float64 {
+	p := len(L)
+	A := make([][]float64, p)
+	for i := range A {
+		A[i] = make([]float64, p)
+		A[i][i] = math.Sqrt(2 * standardGammaRand((nu-float64(i))/2, rng))
+		for j := 0; j < i; j++ {
+			A[i][j] = normFloat(rng)
+		}
+	}
+	M := linalg.MatMul(L, A)
+	return linalg.MatMul(M, linalg.Transpose(M))
+}
+
+// choleskyInverse returns A⁻¹ given the Cholesky factor L of A, made exactly
+// symmetric.
+func choleskyInverse(L [][]float64) [][]float64 {
+	p := len(L)
+	inv := make([][]float64, p)
+	for i := range inv {
+		inv[i] = make([]float64, p)
+	}
+	e := make([]float64, p)
+	for j := 0; j < p; j++ {
+		e[j] = 1
+		col := linalg.CholeskySolve(L, e)
+		e[j] = 0
+		for i := 0; i <= j; i++ {
+			inv[i][j] = col[i]
+			inv[j][i] = col[i]
+		}
+	}
+	return inv
+}
+
+// traceSolve returns tr(A⁻¹B) given the Cholesky factor L of A.
+func traceSolve(L, B [][]float64) float64 {
+	p := len(L)
+	col := make([]float64, p)
+	tr := 0.0
+	for j := 0; j < p; j++ {
+		for i := 0; i < p; i++ {
+			col[i] = B[i][j]
+		}
+		tr += linalg.CholeskySolve(L, col)[j]
+	}
+	return tr
+}
+
+// logMultiGamma returns the log multivariate gamma function
+// log Γₚ(a) = p(p-1)/4·log π + Σⱼ log Γ(a + (1-j)/2), j = 1…p.
+func logMultiGamma(p int, a float64) float64 {
+	sum := float64(p*(p-1)) / 4 * math.Log(math.Pi)
+	for j := 1; j <= p; j++ {
+		lg, _ := math.Lgamma(a + float64(1-j)/2)
+		sum += lg
+	}
+	return sum
+}
+
+func isSquare(X [][]float64, p int) bool {
+	if len(X) != p {
+		return false
+	}
+	for _, row := range X {
+		if len(row) != p {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+package probability
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// det2 and inv2 are closed-form 2×2 helpers for the reference densities.
+func det2(a [][]float64) float64 { return a[0][0]*a[1][1] - a[0][1]*a[1][0] }
+
+func inv2(a [][]float64) [][]float64 {
+	d := det2(a)
+	return [][]float64{{a[1][1] / d, -a[0][1] / d}, {-a[1][0] / d, a[0][0] / d}}
+}
+
+func trace2(a, b [][]float64) float64 {
+	return a[0][0]*b[0][0] + a[0][1]*b[1][0] + a[1][0]*b[0][1] + a[1][1]*b[1][1]
+}
+
+func logGamma2(a float64) float64 {
+	l1, _ := math.Lgamma(a)
+	l2, _ := math.Lgamma(a - 0.5)
+	return 0.5*math.Log(math.Pi) + l1 + l2
+}
+
+func TestWishartLogPDF(t *testing.T) {
+	V := [][]float64{{2, 0.5}, {0.5, 1}}
+	X := [][]float64{{5, 1.2}, {1.2, 3}}
+	nu := 4.5
+	w, err := NewWishart(nu, V)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := 0.5*(nu-3)*math.Log(det2(X)) - 0.5*trace2(inv2(V), X) -
+		nu*math.Ln2 - 0.5*nu*math.Log(det2(V)) - logGamma2(nu/2)
+	if got := w.LogPDF(X); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogPDF = %v; want %v", got, want)
+	}
+
+	// with p = 1, Wishart(ν, v) is Gamma(ν/2, rate 1/(2v))
+	w1, _ := NewWishart(3, [][]float64{{1.5}})
+	g := Gamma{Alpha: 1.5, Beta: 1 / 3.0}
+	for _, x := range []float64{0.2, 2, 7} {
+		if got, want := w1.LogPDF([][]float64{{x}}), g.LogPDF(x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("1-D LogPDF(%v) = %v; want %v", x, got, want)
+		}
+	}
+	if got := w.LogPDF([][]float64{{1, 2}, {2, 1}}); !math.IsInf(got, -1) {
+		t.Errorf("LogPDF of indefinite matrix = %v; want -Inf", got)
+	}
+	if got := w.LogPDF([][]float64{{1}}); !math.IsNaN(got) {
+		t.Errorf("LogPDF with wrong dimension = %v; want NaN", got)
+	}
+}
+
+func TestInverseWishartLogPDF(t *testing.T) {
+	Psi := [][]float64{{2, 0.5}, {0.5, 1}}
+	X := [][]float64{{0.8, 0.1}, {0.1, 0.4}}
+	nu := 5.0
+	w, err := NewInverseWishart(nu, Psi)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := 0.5*nu*math.Log(det2(Psi)) - nu*math.Ln2 - logGamma2(nu/2) -
+		0.5*(nu+3)*math.Log(det2(X)) - 0.5*trace2(Psi, inv2(X))
+	if got := w.LogPDF(X); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogPDF = %v; want %v", got, want)
+	}
+
+	// with p = 1, InverseWishart(ν, ψ) is inverse-gamma(ν/2, ψ/2)
+	w1, _ := NewInverseWishart(3, [][]float64{{1.5}})
+	a, b := 1.5, 0.75
+	for _, x := range []float64{0.2, 2, 7} {
+		lg, _ := math.Lgamma(a)
+		want := a*math.Log(b) - lg - (a+1)*math.Log(x) - b/x
+		if got := w1.LogPDF([][]float64{{x}}); math.Abs(got-want) > 1e-12 {
+			t.Errorf("1-D LogPDF(%v) = %v; want %v", x, got, want)
+		}
+	}
+}
+
+// sampleMatrixMean averages n draws of a random matrix.
+func sampleMatrixMean(n int, draw func() [][]float64) [][]float64 {
+	var mean [][]float64
+	for s := 0; s < n; s++ {
+		x := draw()
+		if mean == nil {
+			mean = make([][]float64, len(x))
+			for i := range mean {
+				mean[i] = make([]float64, len(x))
+			}
+		}
+		for i := range x {
+			for j := range x[i] {
+				mean[i][j] += x[i][j] / float64(n)
+			}
+		}
+	}
+	return mean
+}
+
+func TestWishartRand(t *testing.T) {
+	scale := [][]float64{{2, 0.5, -0.3}, {0.5, 1, 0.2}, {-0.3, 0.2, 0.7}}
+	rng := rand.New(rand.NewSource(3))
+	const n = 100000
+
+	w, _ := NewWishart(6.5, scale)
+	got, want := sampleMatrixMean(n, func() [][]float64 { return w.Rand(rng) }), w.Mean()
+	for i := range want {
+		for j := range want[i] {
+			// Var(Wᵢⱼ) = ν(Vᵢⱼ² + VᵢᵢVⱼⱼ)
+			se := math.Sqrt(6.5 * (scale[i][j]*scale[i][j] + scale[i][i]*scale[j][j]) / n)
+			if math.Abs(got[i][j]-want[i][j]) > 5*se {
+				t.Errorf("Wishart sample mean[%d][%d] = %v; want %v", i, j, got[i][j], want[i][j])
+			}
+		}
+	}
+
+	iw, _ := NewInverseWishart(12, scale)
+	got, want = sampleMatrixMean(n, func() [][]float64 { return iw.Rand(rng) }), iw.Mean()
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(got[i][j]-want[i][j]) > 0.02*math.Sqrt(want[i][i]*want[j][j]) {
+				t.Errorf("InverseWishart sample mean[%d][%d] = %v; want %v", i, j, got[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestInverseWishartPosterior(t *testing.T) {
+	prior, _ := NewInverseWishart(3, [][]float64{{1, 0}, {0, 1}})
+	data := [][]float64{{1, 2}, {-1, 0}, {0, 1}}
+	post, err := prior.Posterior(data, []float64{0, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// deviations (1,1), (-1,-1), (0,0) add [[2,2],[2,2]]
+	want := [][]float64{{3, 2}, {2, 3}}
+	if post.DF() != 6 {
+		t.Errorf("DF = %v; want 6", post.DF())
+	}
+	for i := range want {
+		for j := range want[i] {
+			if post.Scale()[i][j] != want[i][j] {
+				t.Errorf("Scale = %v; want %v", post.Scale(), want)
+			}
+		}
+	}
+	if _, err := prior.Posterior(nil, []float64{0, 0}); err == nil {
+		t.Error("Posterior with no data should fail")
+	}
+	if _, err := prior.Posterior(data, []float64{0}); err == nil {
+		t.Error("Posterior with wrong mean length should fail")
+	}
+}
+
+func TestWishartInvalid(t *testing.T) {
+	I := [][]float64{{1, 0}, {0, 1}}
+	if _, err := NewWishart(0.9, I); err == nil {
+		t.Error("NewWishart with ν ≤ p-1 should fail")
+	}
+	if _, err := NewWishart(3, [][]float64{{1, 2}, {2, 1}}); err == nil {
+		t.Error("NewWishart with indefinite scale should fail")
+	}
+	if _, err := NewInverseWishart(3, nil); err == nil {
+		t.Error("NewInverseWishart with empty scale should fail")
+	}
+	if _, err := NewInverseWishart(math.NaN(), I); err == nil {
+		t.Error("NewInverseWishart with NaN ν should fail")
+	}
+	iw, _ := NewInverseWishart(2.5, I)
+	if iw.Mean() != nil {
+		t.Error("InverseWishart mean with ν ≤ p+1 should be nil")
+	}
+	if (Wishart{}).Rand(nil) != nil || (InverseWishart{}).Rand(nil) != nil {
+		t.Error("Rand on zero value should return nil")
+	}
+}
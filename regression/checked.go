@@ -0,0 +1,119 @@
+package regression
+
+import (
+	"fmt"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// The functions in this file are error-returning variants of the simple
+// regression helpers, which return 0 for empty or mismatched input. Each
+// reports those cases as an error matching ErrEmptyInput or
+// ErrLengthMismatch, and a fit that is undefined because every x is equal
+// as ErrSingularMatrix.
+
+// checkXY reports empty or mismatched x and y.
+func checkXY(name string, x, y []float64) error {
+	if len(x) != len(y) {
+		return errs.New(ErrLengthMismatch, fmt.Sprintf("%s: x and y have lengths %d and %d", name, len(x), len(y)))
+	}
+	if len(x) == 0 {
+		return errs.New(ErrEmptyInput, name+": x and y must not be empty")
+	}
+	return nil
+}
+
+// checkSpread reports x values that are all equal, for which the normal
+// equations of the least-squares line are singular.
+func checkSpread(name string, x []float64) error {
+	for _, v := range x {
+		if v != x[0] {
+			return nil
+		}
+	}
+	return errs.New(ErrSingularMatrix, name+": x values are all equal")
+}
+
+// SimpleLinearRegressionE is SimpleLinearRegression, returning an error for
+// empty or mismatched slices or x values that are all equal.
+func SimpleLinearRegressionE(x, y []float64) (slope, intercept float64, err error) {
+	if err := checkXY("SimpleLinearRegression", x, y); err != nil {
+		return 0, 0, err
+	}
+	if err := checkSpread("SimpleLinearRegression", x); err != nil {
+		return 0, 0, err
+	}
+	slope = CalculateSlope(x, y)
+	return slope, CalculateIntercept(x, y, slope), nil
+}
+
+// CalculateSlopeE is CalculateSlope, returning an error for empty or
+// mismatched slices or x values that are all equal.
+func CalculateSlopeE(x, y []float64) (float64, error) {
+	if err := checkXY("CalculateSlope", x, y); err != nil {
+		return 0, err
+	}
+	if err := checkSpread("CalculateSlope", x); err != nil {
+		return 0, err
+	}
+	return CalculateSlope(x, y), nil
+}
+
+// CalculateInterceptE is CalculateIntercept, returning an error for empty
+// or mismatched slices.
+func CalculateInterceptE(x, y []float64, slope float64) (float64, error) {
+	if err := checkXY("CalculateIntercept", x, y); err != nil {
+		return 0, err
+	}
+	return CalculateIntercept(x, y, slope), nil
+}
+
+// MeanSquaredErrorE is MeanSquaredError, returning an error for empty or
+// mismatched slices.
+func MeanSquaredErrorE(yTrue, yPred []float64) (float64, error) {
+	if err := checkXY("MeanSquaredError", yTrue, yPred); err != nil {
+		return 0, err
+	}
+	return MeanSquaredError(yTrue, yPred), nil
+}
+
+// RootMeanSquaredErrorE is RootMeanSquaredError, returning an error for
+// empty or mismatched slices.
+func RootMeanSquaredErrorE(yTrue, yPred []float64) (float64, error) {
+	if err := checkXY("RootMeanSquaredError", yTrue, yPred); err != nil {
+		return 0, err
+	}
+	return RootMeanSquaredError(yTrue, yPred), nil
+}
+
+// EvaluateLinearRegressionE is EvaluateLinearRegression, returning the
+// errors of SimpleLinearRegressionE.
+func EvaluateLinearRegressionE(x, y []float64) (float64, error) {
+	if _, _, err := SimpleLinearRegressionE(x, y); err != nil {
+		return 0, err
+	}
+	return EvaluateLinearRegression(x, y), nil
+}
+
+// RSquaredE is RSquared, returning an error for empty or mismatched slices
+// or y values that are all equal, for which R² is undefined.
+func RSquaredE(x, y []float64, slope, intercept float64) (float64, error) {
+	if err := checkXY("RSquared", x, y); err != nil {
+		return 0, err
+	}
+	for _, v := range y {
+		if v != y[0] {
+			return RSquared(x, y, slope, intercept), nil
+		}
+	}
+	return 0, errs.New(ErrInvalidParameter, "RSquared: y values are all equal")
+}
+
+// PredictMultipleE is PredictMultiple, returning an error instead of
+// panicking unless beta holds an intercept and one coefficient per feature.
+func PredictMultipleE(x []float64, beta []float64) (float64, error) {
+	if len(beta) != len(x)+1 {
+		return 0, errs.New(ErrLengthMismatch, fmt.Sprintf("PredictMultiple: %d features need %d coefficients, got %d", len(x), len(x)+1, len(beta)))
+	}
+	return PredictMultiple(x, beta), nil
+}
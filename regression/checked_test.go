@@ -0,0 +1,53 @@
+package regression
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckedVariants(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2.2, 4.1, 6.3, 7.9, 10.2}
+	slope, intercept, err := SimpleLinearRegressionE(x, y)
+	wantSlope, wantIntercept := SimpleLinearRegression(x, y)
+	if err != nil || slope != wantSlope || intercept != wantIntercept {
+		t.Errorf("SimpleLinearRegressionE = %v, %v, %v", slope, intercept, err)
+	}
+	if got, err := RSquaredE(x, y, slope, intercept); err != nil || got != RSquared(x, y, slope, intercept) {
+		t.Errorf("RSquaredE = %v, %v", got, err)
+	}
+	if got, err := EvaluateLinearRegressionE(x, y); err != nil || got != EvaluateLinearRegression(x, y) {
+		t.Errorf("EvaluateLinearRegressionE = %v, %v", got, err)
+	}
+	if got, err := PredictMultipleE([]float64{2, 3}, []float64{1, 0.5, -1}); err != nil || got != -1 {
+		t.Errorf("PredictMultipleE = %v, %v; want -1", got, err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"mismatch", second(CalculateSlopeE(x, y[:3])), ErrLengthMismatch},
+		{"empty", second(MeanSquaredErrorE(nil, nil)), ErrEmptyInput},
+		{"constant x", second(CalculateSlopeE([]float64{2, 2, 2}, []float64{1, 2, 3})), ErrSingularMatrix},
+		{"constant y", second(RSquaredE(x, []float64{1, 1, 1, 1, 1}, 0, 1)), ErrInvalidParameter},
+		{"short beta", second(PredictMultipleE([]float64{1, 2}, []float64{1})), ErrLengthMismatch},
+		{"intercept mismatch", second(CalculateInterceptE(x, nil, 1)), ErrLengthMismatch},
+		{"RMSE mismatch", second(RootMeanSquaredErrorE(x, nil)), ErrLengthMismatch},
+		{"evaluate constant x", second(EvaluateLinearRegressionE([]float64{1}, []float64{3})), ErrSingularMatrix},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.err, tt.want)
+		}
+	}
+	if _, err := MultipleLinearRegression([][]float64{{1, 2}, {3}}, []float64{1, 2}); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("ragged X: err = %v", err)
+	}
+	if _, err := MultipleLinearRegression([][]float64{{1}, {1}, {1}}, []float64{1, 2, 3}); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("collinear X: err = %v", err)
+	}
+}
+
+func second(_ float64, err error) error { return err }
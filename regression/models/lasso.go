@@ -1,16 +1,11 @@
 package models
 
-import (
-	"errors"
-)
-
 // LassoRegression performs Lasso regression using coordinate descent.
 func LassoRegression(X [][]float64, y []float64, lambda float64, maxIter int) ([]float64, error) {
-	n := len(X)
-	if n == 0 || len(y) != n {
-		return nil, errors.New("invalid input dimensions")
+	if err := checkDesign("LassoRegression", X, y, lambda); err != nil {
+		return nil, err
 	}
-
+	n := len(X)
 	p := len(X[0])
 
 	// Center X and y
@@ -1,16 +1,16 @@
 package models
 
 import (
-	"errors"
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
 )
 
 // RidgeRegression performs ridge regression with L2 regularization.
 // It centers X and y before applying the closed-form ridge regression.
 func RidgeRegression(X [][]float64, y []float64, lambda float64) ([]float64, error) {
-	n := len(X)
-	if n == 0 || len(y) != n {
-		return nil, errors.New("invalid input dimensions")
+	if err := checkDesign("RidgeRegression", X, y, lambda); err != nil {
+		return nil, err
 	}
+	n := len(X)
 
 	m := len(X[0]) // number of features
 
@@ -81,6 +81,26 @@ func RidgeRegression(X [][]float64, y []float64, lambda float64) ([]float64, err
 	return betaCentered, nil
 }
 
+// checkDesign validates the inputs shared by the penalised regressions: a
+// non-empty rectangular X with one target per row and a penalty λ ≥ 0.
+func checkDesign(name string, X [][]float64, y []float64, lambda float64) error {
+	if len(X) == 0 {
+		return errs.New(ErrEmptyInput, name+": X must not be empty")
+	}
+	if len(y) != len(X) {
+		return errs.New(ErrLengthMismatch, name+": X and y must have the same number of rows")
+	}
+	for _, row := range X {
+		if len(row) != len(X[0]) {
+			return errs.New(ErrLengthMismatch, name+": rows of X must have the same length")
+		}
+	}
+	if !(lambda >= 0) {
+		return errs.New(ErrInvalidParameter, name+": lambda must be ≥ 0")
+	}
+	return nil
+}
+
 func transpose(A [][]float64) [][]float64 {
 	rows := len(A)
 	cols := len(A[0])
@@ -137,7 +157,7 @@ func matInverse(A [][]float64) ([][]float64, error) {
 	for i := 0; i < n; i++ {
 		pivot := aug[i][i]
 		if pivot == 0 {
-			return nil, errors.New("matrix is singular")
+			return nil, errs.New(ErrSingularMatrix, "matrix is singular")
 		}
 		for j := 0; j < 2*n; j++ {
 			aug[i][j] /= pivot
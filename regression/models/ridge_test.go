@@ -1,6 +1,10 @@
 package models
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 // TestRidgeRegression checks that RidgeRegression returns coefficients close to actual calculated values.
 func TestRidgeRegression(t *testing.T) {
@@ -72,3 +76,27 @@ func TestRidgeRegression_ZeroLambda(t *testing.T) {
 	}
 }
 
+
+func TestRegularizedRegressionErrorKinds(t *testing.T) {
+	X := [][]float64{{1, 2}, {2, 1}, {3, 5}}
+	y := []float64{1, 2, 3}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"ridge empty", second(RidgeRegression(nil, nil, 0.1)), ErrEmptyInput},
+		{"ridge rows", second(RidgeRegression(X, y[:2], 0.1)), ErrLengthMismatch},
+		{"ridge ragged", second(RidgeRegression([][]float64{{1, 2}, {3}, {4, 5}}, y, 0.1)), ErrLengthMismatch},
+		{"ridge lambda", second(RidgeRegression(X, y, -1)), ErrInvalidParameter},
+		{"lasso lambda", second(LassoRegression(X, y, math.NaN(), 10)), ErrInvalidParameter},
+		{"lasso rows", second(LassoRegression(X, nil, 0.1, 10)), ErrLengthMismatch},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func second(_ []float64, err error) error { return err }
@@ -1,7 +1,8 @@
 package regression
 
 import (
-	"errors"
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+	"github.com/cyber-mountain-man/statistical-go/internal/linalg"
 )
 
 // MultipleLinearRegression performs linear regression with multiple features.
@@ -10,8 +11,16 @@ import (
 // It returns the beta coefficients (including intercept).
 func MultipleLinearRegression(X [][]float64, y []float64) ([]float64, error) {
 	n := len(X)
-	if n == 0 || len(y) != n {
-		return nil, errors.New("invalid input dimensions")
+	if n == 0 {
+		return nil, errs.New(ErrEmptyInput, "MultipleLinearRegression: X must not be empty")
+	}
+	if len(y) != n {
+		return nil, errs.New(ErrLengthMismatch, "MultipleLinearRegression: X and y must have the same number of rows")
+	}
+	for _, row := range X {
+		if len(row) != len(X[0]) {
+			return nil, errs.New(ErrLengthMismatch, "MultipleLinearRegression: rows of X must have the same length")
+		}
 	}
 
 	// Add intercept term (column of 1s)
@@ -43,93 +52,11 @@ func PredictMultiple(x []float64, beta []float64) float64 {
 
 // --- Matrix Utilities (standard library only) ---
 
-// transpose returns the transpose of matrix A
-func transpose(A [][]float64) [][]float64 {
-	rows := len(A)
-	cols := len(A[0])
-	result := make([][]float64, cols)
-	for i := range result {
-		result[i] = make([]float64, rows)
-		for j := 0; j < rows; j++ {
-			result[i][j] = A[j][i]
-		}
-	}
-	return result
-}
-
-// matMul performs matrix multiplication A * B
-func matMul(A, B [][]float64) [][]float64 {
-	m := len(A)
-	n := len(B[0])
-	p := len(B)
-	result := make([][]float64, m)
-	for i := range result {
-		result[i] = make([]float64, n)
-		for j := 0; j < n; j++ {
-			sum := 0.0
-			for k := 0; k < p; k++ {
-				sum += A[i][k] * B[k][j]
-			}
-			result[i][j] = sum
-		}
-	}
-	return result
-}
-
-// matVecMul performs matrix-vector multiplication A * x
-func matVecMul(A [][]float64, x []float64) []float64 {
-	m := len(A)
-	n := len(x)
-	result := make([]float64, m)
-	for i := 0; i < m; i++ {
-		sum := 0.0
-		for j := 0; j < n; j++ {
-			sum += A[i][j] * x[j]
-		}
-		result[i] = sum
-	}
-	return result
-}
-
-// matInverse computes the inverse of a square matrix A using Gauss-Jordan elimination.
-func matInverse(A [][]float64) ([][]float64, error) {
-	n := len(A)
-	// Create augmented matrix [A | I]
-	aug := make([][]float64, n)
-	for i := range aug {
-		aug[i] = make([]float64, 2*n)
-		for j := 0; j < n; j++ {
-			aug[i][j] = A[i][j]
-		}
-		aug[i][n+i] = 1
-	}
-
-	// Perform Gauss-Jordan elimination
-	for i := 0; i < n; i++ {
-		pivot := aug[i][i]
-		if pivot == 0 {
-			return nil, errors.New("matrix is singular")
-		}
-		for j := 0; j < 2*n; j++ {
-			aug[i][j] /= pivot
-		}
-		for k := 0; k < n; k++ {
-			if k != i {
-				factor := aug[k][i]
-				for j := 0; j < 2*n; j++ {
-					aug[k][j] -= factor * aug[i][j]
-				}
-			}
-		}
-	}
-
-	// Extract the inverse from augmented matrix
-	inv := make([][]float64, n)
-	for i := range inv {
-		inv[i] = make([]float64, n)
-		for j := 0; j < n; j++ {
-			inv[i][j] = aug[i][j+n]
-		}
-	}
-	return inv, nil
-}
+// The matrix helpers live in internal/linalg so that the probability
+// package can share them.
+var (
+	transpose  = linalg.Transpose
+	matMul     = linalg.MatMul
+	matVecMul  = linalg.MatVecMul
+	matInverse = linalg.Inverse
+)
@@ -0,0 +1,71 @@
+package specfunc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBetaInc(t *testing.T) {
+	// references from the binomial sums for integer a and b, evaluated to
+	// 60 digits
+	tests := []struct {
+		a, b, x, want, comp float64
+	}{
+		{2, 3, 0.4, 5.24800000000000044e-01, 4.75199999999999956e-01},
+		{5, 5, 0.5, 0.5, 0.5},
+		{1, 30, 0.001, 2.95690327369142539e-02, 9.70430967263085753e-01},
+		{20, 3, 0.99, 9.98664370806218837e-01, 1.33562919378110993e-03},
+		{40, 60, 0.1, 1.95744481994480186e-15, 9.99999999999998002e-01},
+		{3, 200, 0.2, 1, 3.50634065570442994e-17},
+	}
+	for _, tt := range tests {
+		if got := BetaInc(tt.a, tt.b, tt.x); relErr(got, tt.want) > 1e-13 {
+			t.Errorf("BetaInc(%v, %v, %v) = %v; want %v", tt.a, tt.b, tt.x, got, tt.want)
+		}
+		// the complement through the symmetry I_x(a, b) = 1 - I_(1-x)(b, a)
+		if got := BetaIncPair(tt.b, tt.a, 1-tt.x, tt.x); relErr(got, tt.comp) > 1e-13 {
+			t.Errorf("BetaIncPair(%v, %v, 1-%v) = %v; want %v", tt.b, tt.a, tt.x, got, tt.comp)
+		}
+	}
+	// I_x(a, 1) = x^a
+	if got, want := BetaInc(2.5, 1, 0.3), math.Pow(0.3, 2.5); relErr(got, want) > 1e-14 {
+		t.Errorf("BetaInc(2.5, 1, 0.3) = %v; want %v", got, want)
+	}
+	for _, bad := range [][3]float64{{0, 1, 0.5}, {1, -1, 0.5}, {1, 1, -0.1}, {1, 1, 1.1}, {1, 1, math.NaN()}} {
+		if !math.IsNaN(BetaInc(bad[0], bad[1], bad[2])) {
+			t.Errorf("BetaInc(%v, %v, %v) should be NaN", bad[0], bad[1], bad[2])
+		}
+	}
+}
+
+func TestBetaIncInv(t *testing.T) {
+	for _, ab := range [][2]float64{{0.1, 0.1}, {0.5, 3}, {2, 5}, {30, 0.7}, {500, 800}} {
+		for _, p := range []float64{1e-200, 1e-10, 0.01, 0.5, 0.9, 1 - 1e-10} {
+			x := BetaIncInv(ab[0], ab[1], p)
+			if x == 0 || x == 1 {
+				// the quantile is closer to 0 or 1 than a float64 can express
+				continue
+			}
+			if got := BetaInc(ab[0], ab[1], x); relErr(got, p) > 1e-10 {
+				t.Errorf("BetaInc(%v, %v, BetaIncInv(%v)) = %v", ab[0], ab[1], p, got)
+			}
+		}
+	}
+	if BetaIncInv(2, 3, 0) != 0 || BetaIncInv(2, 3, 1) != 1 || !math.IsNaN(BetaIncInv(2, 3, 2)) {
+		t.Error("BetaIncInv endpoints are wrong")
+	}
+}
+
+func TestLogBeta(t *testing.T) {
+	// B(2, 3) = 1/12 and B(½, ½) = π
+	if got, want := LogBeta(2, 3), -math.Log(12); relErr(got, want) > 1e-15 {
+		t.Errorf("LogBeta(2, 3) = %v; want %v", got, want)
+	}
+	if got, want := LogBeta(0.5, 0.5), math.Log(math.Pi); relErr(got, want) > 1e-15 {
+		t.Errorf("LogBeta(0.5, 0.5) = %v; want %v", got, want)
+	}
+	// the prefix of the binomial PMF: C(4, 2)·0.3²·0.7² = 5·LogBetaPrefix(3, 3, …)
+	if got, want := LogBetaPrefix(3, 3, 0.3, 0.7)-math.Log(0.3*0.7*5), math.Log(6*0.09*0.49); relErr(got, want) > 1e-14 {
+		t.Errorf("LogBetaPrefix(3, 3, 0.3, 0.7) = %v; want %v", got+math.Log(0.3*0.7*5), want+math.Log(0.3*0.7*5))
+	}
+}
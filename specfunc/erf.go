@@ -0,0 +1,134 @@
+package specfunc
+
+import "math"
+
+// Erfinv returns the inverse error function, the x with erf(x) = y, for
+// -1 ≤ y ≤ 1. Erfinv(±1) = ±Inf. Returns NaN outside [-1, 1].
+func Erfinv(y float64) float64 {
+	switch {
+	case math.IsNaN(y) || y < -1 || y > 1:
+		return math.NaN()
+	case y == -1 || y == 1:
+		return math.Inf(int(y))
+	}
+	// erfinv(y) = Φ⁻¹((1 + y) / 2) / √2; 1 - |y| is exact wherever the tail
+	// branch of ppnd uses it
+	return ppnd(y/2, math.Log((1-math.Abs(y))/2)) / math.Sqrt2
+}
+
+// Erfcinv returns the inverse complementary error function, the x with
+// erfc(x) = y, for 0 ≤ y ≤ 2. Unlike math.Erfcinv, which evaluates
+// Erfinv(1 - y), it keeps full relative precision for y near 0 (large x),
+// down to the smallest subnormal. Erfcinv(0) = +Inf and Erfcinv(2) = -Inf.
+// Returns NaN outside [0, 2].
+func Erfcinv(y float64) float64 {
+	switch {
+	case math.IsNaN(y) || y < 0 || y > 2:
+		return math.NaN()
+	case y == 0:
+		return math.Inf(1)
+	case y == 2:
+		return math.Inf(-1)
+	}
+	// erfcinv(y) = Φ⁻¹(1 - y/2) / √2 with tail probability min(y, 2-y) / 2
+	return ppnd((1-y)/2, math.Log(math.Min(y, 2-y)/2)) / math.Sqrt2
+}
+
+// Ndtri returns the standard normal quantile Φ⁻¹(p), the x with Φ(x) = p,
+// to full double precision by Wichura's algorithm AS 241. Ndtri(0) = -Inf
+// and Ndtri(1) = +Inf. Returns NaN outside [0, 1].
+func Ndtri(p float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	}
+	return ppnd(p-0.5, math.Log(math.Min(p, 1-p)))
+}
+
+// NdtriExp returns Φ⁻¹(exp(logp)), the standard normal quantile of a
+// probability given by its logarithm. It reaches far beyond the smallest
+// float64 probability: NdtriExp(-1e5) ≈ -447.2. NdtriExp(-Inf) = -Inf and
+// NdtriExp(0) = +Inf. Returns NaN for logp > 0.
+func NdtriExp(logp float64) float64 {
+	switch {
+	case math.IsNaN(logp) || logp > 0:
+		return math.NaN()
+	case math.IsInf(logp, -1):
+		return math.Inf(-1)
+	case logp == 0:
+		return math.Inf(1)
+	}
+	if logp < -math.Ln2 {
+		// p < ½: the lower tail is logp itself
+		return ppnd(math.Exp(logp)-0.5, logp)
+	}
+	// p ≥ ½: the upper tail 1 - p = -expm1(logp) without cancellation
+	return ppnd(math.Exp(logp)-0.5, math.Log(-math.Expm1(logp)))
+}
+
+// ppnd returns the standard normal quantile Φ⁻¹(p) by Wichura's algorithm
+// AS 241 (PPND16), accurate to about 1e-16. It takes q = p - ½ for the
+// central region and logTail = log min(p, 1-p) for the tails, so callers
+// can supply whichever they know without cancellation or underflow.
+func ppnd(q, logTail float64) float64 {
+	if math.Abs(q) <= 0.425 {
+		r := 0.180625 - q*q
+		return q * (((((((2.5090809287301226727e+3*r+3.3430575583588128105e+4)*r+
+			6.7265770927008700853e+4)*r+4.5921953931549871457e+4)*r+
+			1.3731693765509461125e+4)*r+1.9715909503065514427e+3)*r+
+			1.3314166789178437745e+2)*r + 3.3871328727963666080e0) /
+			(((((((5.2264952788528545610e+3*r+2.8729085735721942674e+4)*r+
+				3.9307895800092710610e+4)*r+2.1213794301586595867e+4)*r+
+				5.3941960214247511077e+3)*r+6.8718700749205790830e+2)*r+
+				4.2313330701600911252e+1)*r + 1)
+	}
+	r := math.Sqrt(-logTail)
+	var x float64
+	if r <= 5 {
+		r -= 1.6
+		x = (((((((7.74545014278341407640e-4*r+2.27238449892691845833e-2)*r+
+			2.41780725177450611770e-1)*r+1.27045825245236838258e0)*r+
+			3.64784832476320460504e0)*r+5.76949722146069140550e0)*r+
+			4.63033784615654529590e0)*r + 1.42343711074968357734e0) /
+			(((((((1.05075007164441684324e-9*r+5.47593808499534494600e-4)*r+
+				1.51986665636164571966e-2)*r+1.48103976427480074590e-1)*r+
+				6.89767334985100004550e-1)*r+1.67638483018380384940e0)*r+
+				2.05319162663775882187e0)*r + 1)
+	} else {
+		r -= 5
+		x = (((((((2.01033439929228813265e-7*r+2.71155556874348757815e-5)*r+
+			1.24266094738807843860e-3)*r+2.65321895265761230930e-2)*r+
+			2.96560571828504891230e-1)*r+1.78482653991729133580e0)*r+
+			5.46378491116411436990e0)*r + 6.65790464350110377720e0) /
+			(((((((2.04426310338993978564e-15*r+1.42151175831644588870e-7)*r+
+				1.84631831751005468180e-5)*r+7.86869131145613259100e-4)*r+
+				1.48753612908506148525e-2)*r+1.36929880922735805310e-1)*r+
+				5.99832206555887937690e-1)*r + 1)
+	}
+	if logTail < -690 {
+		// beyond p ≈ 1e-300 the rational fit loses digits; polish with
+		// Newton steps on log Φ(-x) = logTail
+		for i := 0; i < 3; i++ {
+			m := millsRatio(x)
+			x += (-0.5*x*x - 0.5*math.Log(2*math.Pi) + math.Log(m) - logTail) * m
+		}
+	}
+	if q < 0 {
+		return -x
+	}
+	return x
+}
+
+// millsRatio returns Φ(-x) / φ(x) for large x > 0 by its continued
+// fraction 1 / (x + 1/(x + 2/(x + 3/(x + ...)))).
+func millsRatio(x float64) float64 {
+	f := x
+	for k := 30; k > 0; k-- {
+		f = x + float64(k)/f
+	}
+	return 1 / f
+}
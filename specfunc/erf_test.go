@@ -0,0 +1,96 @@
+package specfunc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestErfinv(t *testing.T) {
+	// beyond |x| ≈ 2 erf(x) rounds too close to ±1 to invert; see Erfcinv
+	for _, x := range []float64{-2, -0.7, -1e-3, 1e-30, 0.2, 0.9, 1.5} {
+		if got := Erfinv(math.Erf(x)); relErr(got, x) > 1e-14 {
+			t.Errorf("Erfinv(erf(%v)) = %v", x, got)
+		}
+	}
+	// odd symmetry, tiny arguments and endpoints
+	if Erfinv(-0.3) != -Erfinv(0.3) || relErr(Erfinv(1e-300), 1e-300*math.Sqrt(math.Pi)/2) > 1e-15 {
+		t.Error("Erfinv lost symmetry or precision near 0")
+	}
+	if !math.IsInf(Erfinv(1), 1) || !math.IsInf(Erfinv(-1), -1) || !math.IsNaN(Erfinv(1.5)) {
+		t.Error("Erfinv endpoints are wrong")
+	}
+}
+
+func TestErfcinv(t *testing.T) {
+	for _, y := range []float64{5e-324, 1e-300, 1e-100, 1e-20, 1e-5, 0.01, 0.3, 1, 1.4, 1.99, 2 - 1e-12} {
+		x := Erfcinv(y)
+		// a relative error ε in x moves erfc by about 2x²·ε
+		tol := 4e-16 * math.Max(1, 2*x*x) * 4
+		if got := math.Erfc(x) / y; math.Abs(got-1) > tol && y > 1e-300 {
+			t.Errorf("erfc(Erfcinv(%v)) / y = %v", y, got)
+		}
+	}
+	// math.Erfcinv goes through 1 - y and overflows; Erfcinv does not
+	if got := Erfcinv(1e-300); math.IsInf(got, 0) || math.Abs(got-26.209469960516117) > 1e-13 {
+		t.Errorf("Erfcinv(1e-300) = %v; want 26.209469960516117", got)
+	}
+	if Erfcinv(1) != 0 || relErr(Erfcinv(0.4), -Erfcinv(1.6)) > 1e-15 {
+		t.Error("Erfcinv lost its symmetry about 1")
+	}
+	if !math.IsInf(Erfcinv(0), 1) || !math.IsInf(Erfcinv(2), -1) || !math.IsNaN(Erfcinv(-0.1)) {
+		t.Error("Erfcinv endpoints are wrong")
+	}
+}
+
+func TestNdtri(t *testing.T) {
+	// Φ⁻¹(p) computed in 80-digit arithmetic
+	tests := []struct{ p, want float64 }{
+		{1e-300, -37.047096299361201},
+		{1e-100, -21.273453560965326},
+		{1e-20, -9.262340089798407},
+		{1e-10, -6.3613409024040566},
+		{0.001, -3.0902323061678136},
+		{0.025, -1.9599639845400543},
+		{0.3, -0.52440051270804078},
+	}
+	for _, tt := range tests {
+		if got := Ndtri(tt.p); relErr(got, tt.want) > 1e-15 {
+			t.Errorf("Ndtri(%v) = %v; want %v", tt.p, got, tt.want)
+		}
+		if got := NdtriExp(math.Log(tt.p)); relErr(got, tt.want) > 1e-15 {
+			t.Errorf("NdtriExp(log %v) = %v; want %v", tt.p, got, tt.want)
+		}
+		if tt.p >= 1e-10 {
+			// 1 - p is rounded, so compare against the quantile of 1 - p as stored
+			if got, want := Ndtri(1-tt.p), -Ndtri(1-(1-tt.p)); relErr(got, want) > 1e-15 {
+				t.Errorf("Ndtri(1 - %v) = %v; want %v", tt.p, got, want)
+			}
+		}
+	}
+	if Ndtri(0.5) != 0 || !math.IsInf(Ndtri(0), -1) || !math.IsInf(Ndtri(1), 1) || !math.IsNaN(Ndtri(1.1)) {
+		t.Error("Ndtri endpoints are wrong")
+	}
+}
+
+func TestNdtriExp(t *testing.T) {
+	// probabilities far below the smallest float64
+	tests := []struct{ logp, want float64 }{
+		{-1000, -44.6157477319694},
+		{-1e5, -447.19789367852508},
+	}
+	for _, tt := range tests {
+		if got := NdtriExp(tt.logp); relErr(got, tt.want) > 1e-15 {
+			t.Errorf("NdtriExp(%v) = %v; want %v", tt.logp, got, tt.want)
+		}
+	}
+	// the upper half goes through -expm1(logp) and keeps 1 - p exactly
+	if got, want := NdtriExp(-1e-20), -Ndtri(1e-20); relErr(got, want) > 1e-15 {
+		t.Errorf("NdtriExp(-1e-20) = %v; want %v", got, want)
+	}
+	if got := NdtriExp(math.Log(0.975)); relErr(got, 1.959963984540054) > 1e-15 {
+		t.Errorf("NdtriExp(log 0.975) = %v", got)
+	}
+	if !math.IsInf(NdtriExp(math.Inf(-1)), -1) || !math.IsInf(NdtriExp(0), 1) || !math.IsNaN(NdtriExp(0.1)) {
+		t.Error("NdtriExp endpoints are wrong")
+	}
+}
@@ -0,0 +1,99 @@
+package specfunc
+
+import (
+	"math"
+	"testing"
+)
+
+const eulerGamma = 0.57721566490153286060651209008240243
+
+func relErr(got, want float64) float64 {
+	if got == want {
+		return 0
+	}
+	return math.Abs(got-want) / math.Abs(want)
+}
+
+func TestGammaInc(t *testing.T) {
+	// references from the finite sums Q(n, x) = e^(-x)·Σ x^k/k!, k < n,
+	// evaluated to 60 digits
+	tests := []struct {
+		a, x, p, q float64
+	}{
+		{3, 0.5, 1.43876779669706873e-02, 9.85612322033029287e-01},
+		{3, 2, 3.23323583816936544e-01, 6.76676416183063512e-01},
+		{10, 30, 9.99992878249137185e-01, 7.12175086281557668e-06},
+		{3, 700, 1, 2.42253238647831969e-299},
+		{1, 1e-10, 9.99999999950000070e-11, 9.99999999899999992e-01},
+		{50, 20, 1.24589260797193798e-08, 9.99999987541073887e-01},
+	}
+	for _, tt := range tests {
+		if got := GammaIncLower(tt.a, tt.x); relErr(got, tt.p) > 1e-14 {
+			t.Errorf("GammaIncLower(%v, %v) = %v; want %v", tt.a, tt.x, got, tt.p)
+		}
+		if got := GammaIncUpper(tt.a, tt.x); relErr(got, tt.q) > 1e-13 {
+			t.Errorf("GammaIncUpper(%v, %v) = %v; want %v", tt.a, tt.x, got, tt.q)
+		}
+	}
+	// P(½, x) = erf(√x)
+	for _, x := range []float64{1e-6, 0.3, 2, 9} {
+		if got, want := GammaIncLower(0.5, x), math.Erf(math.Sqrt(x)); relErr(got, want) > 1e-14 {
+			t.Errorf("GammaIncLower(0.5, %v) = %v; want %v", x, got, want)
+		}
+		if got, want := GammaIncUpper(0.5, x), math.Erfc(math.Sqrt(x)); relErr(got, want) > 1e-13 {
+			t.Errorf("GammaIncUpper(0.5, %v) = %v; want %v", x, got, want)
+		}
+	}
+	for _, bad := range [][2]float64{{0, 1}, {-1, 1}, {1, -1}, {math.NaN(), 1}, {1, math.NaN()}} {
+		if !math.IsNaN(GammaIncLower(bad[0], bad[1])) || !math.IsNaN(GammaIncUpper(bad[0], bad[1])) {
+			t.Errorf("GammaInc(%v, %v) should be NaN", bad[0], bad[1])
+		}
+	}
+}
+
+func TestGammaIncInverse(t *testing.T) {
+	for _, a := range []float64{0.05, 0.5, 1, 3.7, 40, 1e4} {
+		for _, p := range []float64{1e-300, 1e-20, 1e-5, 0.3, 0.5, 0.9, 1 - 1e-9} {
+			// skip lower quantiles (p·Γ(a+1))^(1/a) that underflow to 0
+			x := GammaIncLowerInv(a, p)
+			if got := GammaIncLower(a, x); x > 0 && relErr(got, p) > 1e-12 {
+				t.Errorf("GammaIncLower(%v, GammaIncLowerInv(%v, %v)) = %v", a, a, p, got)
+			}
+			x = GammaIncUpperInv(a, p)
+			if got := GammaIncUpper(a, x); relErr(got, p) > 1e-12 {
+				t.Errorf("GammaIncUpper(%v, GammaIncUpperInv(%v, %v)) = %v", a, a, p, got)
+			}
+		}
+	}
+	if GammaIncLowerInv(2, 0) != 0 || !math.IsInf(GammaIncLowerInv(2, 1), 1) ||
+		GammaIncUpperInv(2, 1) != 0 || !math.IsInf(GammaIncUpperInv(2, 0), 1) {
+		t.Error("inverse incomplete gamma endpoints are wrong")
+	}
+	if !math.IsNaN(GammaIncLowerInv(2, 1.5)) || !math.IsNaN(GammaIncUpperInv(-1, 0.5)) {
+		t.Error("invalid arguments should give NaN")
+	}
+}
+
+func TestDigammaTrigamma(t *testing.T) {
+	tests := []struct{ x, psi, psi1 float64 }{
+		{1, -eulerGamma, math.Pi * math.Pi / 6},
+		{0.5, -eulerGamma - 2*math.Ln2, math.Pi * math.Pi / 2},
+		{4, 1 + 0.5 + 1.0/3 - eulerGamma, math.Pi*math.Pi/6 - 1 - 0.25 - 1.0/9},
+		{-0.5, 2 - eulerGamma - 2*math.Ln2, math.Pi*math.Pi/2 + 4},
+	}
+	for _, tt := range tests {
+		if got := Digamma(tt.x); relErr(got, tt.psi) > 1e-14 {
+			t.Errorf("Digamma(%v) = %v; want %v", tt.x, got, tt.psi)
+		}
+		if got := Trigamma(tt.x); relErr(got, tt.psi1) > 1e-14 {
+			t.Errorf("Trigamma(%v) = %v; want %v", tt.x, got, tt.psi1)
+		}
+	}
+	// ψ(x) ~ log x - 1/2x for large x
+	if got, want := Digamma(1e8), math.Log(1e8)-0.5e-8; relErr(got, want) > 1e-15 {
+		t.Errorf("Digamma(1e8) = %v; want %v", got, want)
+	}
+	if !math.IsNaN(Digamma(0)) || !math.IsNaN(Trigamma(-2)) {
+		t.Error("poles should give NaN")
+	}
+}
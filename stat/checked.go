@@ -0,0 +1,236 @@
+package stat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// The functions in this file are error-returning variants of the
+// descriptive statistics. The plain functions return 0 for empty, too short
+// or mismatched input, which a caller cannot tell from a genuine 0. Each
+// variant below reports those cases as an error matching ErrEmptyInput,
+// ErrLengthMismatch or ErrInvalidParameter instead, and otherwise returns
+// exactly what the plain function does.
+
+// checkLen reports ErrEmptyInput when data has fewer than min values.
+func checkLen(name string, data []float64, min int) error {
+	switch {
+	case len(data) >= min:
+		return nil
+	case min == 1:
+		return errs.New(ErrEmptyInput, name+": data must not be empty")
+	}
+	return errs.New(ErrEmptyInput, fmt.Sprintf("%s: need at least %d values, got %d", name, min, len(data)))
+}
+
+// checkPair reports ErrLengthMismatch when x and y differ in length, then
+// applies checkLen.
+func checkPair(name string, x, y []float64, min int) error {
+	if len(x) != len(y) {
+		return errs.New(ErrLengthMismatch, fmt.Sprintf("%s: x and y have lengths %d and %d", name, len(x), len(y)))
+	}
+	return checkLen(name, x, min)
+}
+
+// noSpread is the error for statistics that divide by a zero standard
+// deviation.
+func noSpread(name string) error {
+	return errs.New(ErrInvalidParameter, name+": data has zero standard deviation")
+}
+
+// MeanE is Mean, returning an error for empty data.
+func MeanE(data []float64) (float64, error) {
+	if err := checkLen("Mean", data, 1); err != nil {
+		return 0, err
+	}
+	return Mean(data), nil
+}
+
+// VarianceE is Variance, returning an error for fewer than two values.
+func VarianceE(data []float64) (float64, error) {
+	if err := checkLen("Variance", data, 2); err != nil {
+		return 0, err
+	}
+	return Variance(data), nil
+}
+
+// StdDevE is StdDev, returning an error for fewer than two values.
+func StdDevE(data []float64) (float64, error) {
+	if err := checkLen("StdDev", data, 2); err != nil {
+		return 0, err
+	}
+	return StdDev(data), nil
+}
+
+// MedianE is Median, returning an error for empty data.
+func MedianE(data []float64) (float64, error) {
+	if err := checkLen("Median", data, 1); err != nil {
+		return 0, err
+	}
+	return Median(data), nil
+}
+
+// ModeE is Mode, returning an error for empty data.
+func ModeE(data []float64) (float64, error) {
+	if err := checkLen("Mode", data, 1); err != nil {
+		return 0, err
+	}
+	return Mode(data), nil
+}
+
+// RangeE is Range, returning an error for empty data.
+func RangeE(data []float64) (float64, error) {
+	if err := checkLen("Range", data, 1); err != nil {
+		return 0, err
+	}
+	return Range(data), nil
+}
+
+// MinE is Min, returning an error for empty data.
+func MinE(data []float64) (float64, error) {
+	if err := checkLen("Min", data, 1); err != nil {
+		return 0, err
+	}
+	return Min(data), nil
+}
+
+// MaxE is Max, returning an error for empty data.
+func MaxE(data []float64) (float64, error) {
+	if err := checkLen("Max", data, 1); err != nil {
+		return 0, err
+	}
+	return Max(data), nil
+}
+
+// QuartilesE is Quartiles, returning an error for empty data.
+func QuartilesE(data []float64) (q1, q2, q3 float64, err error) {
+	if err := checkLen("Quartiles", data, 1); err != nil {
+		return 0, 0, 0, err
+	}
+	q1, q2, q3 = Quartiles(data)
+	return q1, q2, q3, nil
+}
+
+// ZScoreE is ZScore, returning an error for fewer than two values or data
+// with zero standard deviation.
+func ZScoreE(x float64, data []float64) (float64, error) {
+	if err := checkLen("ZScore", data, 2); err != nil {
+		return 0, err
+	}
+	if StdDev(data) == 0 {
+		return 0, noSpread("ZScore")
+	}
+	return ZScore(x, data), nil
+}
+
+// CovarianceE is Covariance, returning an error for slices of different
+// lengths or fewer than two pairs.
+func CovarianceE(x, y []float64) (float64, error) {
+	if err := checkPair("Covariance", x, y, 2); err != nil {
+		return 0, err
+	}
+	return Covariance(x, y), nil
+}
+
+// CovarianceMatrixE is CovarianceMatrix, returning an error for fewer than
+// two rows, no columns, or rows of different lengths.
+func CovarianceMatrixE(data [][]float64) ([][]float64, error) {
+	if len(data) < 2 {
+		return nil, errs.New(ErrEmptyInput, fmt.Sprintf("CovarianceMatrix: need at least 2 rows, got %d", len(data)))
+	}
+	if len(data[0]) == 0 {
+		return nil, errs.New(ErrEmptyInput, "CovarianceMatrix: rows must not be empty")
+	}
+	for i, row := range data {
+		if len(row) != len(data[0]) {
+			return nil, errs.New(ErrLengthMismatch, fmt.Sprintf("CovarianceMatrix: row %d has length %d, want %d", i, len(row), len(data[0])))
+		}
+	}
+	return CovarianceMatrix(data), nil
+}
+
+// PearsonCorrelationE is PearsonCorrelation, returning an error for slices
+// of different lengths, fewer than two pairs, or a constant x or y, for
+// which the correlation is undefined.
+func PearsonCorrelationE(x, y []float64) (float64, error) {
+	if err := checkPair("PearsonCorrelation", x, y, 2); err != nil {
+		return 0, err
+	}
+	if StdDev(x) == 0 || StdDev(y) == 0 {
+		return 0, noSpread("PearsonCorrelation")
+	}
+	return PearsonCorrelation(x, y), nil
+}
+
+// SkewnessE is Skewness, returning an error for fewer than three values or
+// data with zero standard deviation.
+func SkewnessE(data []float64) (float64, error) {
+	if err := checkLen("Skewness", data, 3); err != nil {
+		return 0, err
+	}
+	if StdDev(data) == 0 {
+		return 0, noSpread("Skewness")
+	}
+	return Skewness(data), nil
+}
+
+// KurtosisE is Kurtosis, returning an error for fewer than four values or
+// data with zero standard deviation.
+func KurtosisE(data []float64) (float64, error) {
+	if err := checkLen("Kurtosis", data, 4); err != nil {
+		return 0, err
+	}
+	if StdDev(data) == 0 {
+		return 0, noSpread("Kurtosis")
+	}
+	return Kurtosis(data), nil
+}
+
+// ExponentialSmoothingE is ExponentialSmoothing, returning an error instead
+// of panicking for empty data or alpha outside (0, 1].
+func ExponentialSmoothingE(data []float64, alpha float64) (float64, error) {
+	if err := checkLen("ExponentialSmoothing", data, 1); err != nil {
+		return 0, err
+	}
+	if !(alpha > 0 && alpha <= 1) {
+		return 0, errs.New(ErrInvalidParameter, "ExponentialSmoothing: alpha must be in (0, 1]")
+	}
+	return ExponentialSmoothing(data, alpha), nil
+}
+
+// SilvermanBandwidthE is SilvermanBandwidth, returning an error for fewer
+// than two points or non-finite data.
+func SilvermanBandwidthE(data []float64) (float64, error) {
+	return checkedBandwidth("SilvermanBandwidth", data, SilvermanBandwidth)
+}
+
+// ScottBandwidthE is ScottBandwidth, returning an error for fewer than two
+// points or non-finite data.
+func ScottBandwidthE(data []float64) (float64, error) {
+	return checkedBandwidth("ScottBandwidth", data, ScottBandwidth)
+}
+
+// SheatherJonesBandwidthE is SheatherJonesBandwidth, returning an error for
+// fewer than two points, non-finite data, or a sample too sparse to give a
+// positive bandwidth.
+func SheatherJonesBandwidthE(data []float64) (float64, error) {
+	return checkedBandwidth("SheatherJonesBandwidth", data, SheatherJonesBandwidth)
+}
+
+func checkedBandwidth(name string, data []float64, rule func([]float64) float64) (float64, error) {
+	if err := checkLen(name, data, 2); err != nil {
+		return 0, err
+	}
+	for _, x := range data {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return 0, errs.New(ErrInvalidParameter, name+": data must be finite")
+		}
+	}
+	h := rule(data)
+	if !(h > 0) {
+		return 0, errs.New(ErrInvalidParameter, name+": found no spread in the data")
+	}
+	return h, nil
+}
@@ -0,0 +1,85 @@
+package stat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCheckedVariantsMatchPlain(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	y := []float64{1, 3, 2, 5, 4, 6, 8, 7}
+	tests := []struct {
+		name  string
+		got   func() (float64, error)
+		plain float64
+	}{
+		{"Mean", func() (float64, error) { return MeanE(data) }, Mean(data)},
+		{"Variance", func() (float64, error) { return VarianceE(data) }, Variance(data)},
+		{"StdDev", func() (float64, error) { return StdDevE(data) }, StdDev(data)},
+		{"Median", func() (float64, error) { return MedianE(data) }, Median(data)},
+		{"Mode", func() (float64, error) { return ModeE(data) }, Mode(data)},
+		{"Range", func() (float64, error) { return RangeE(data) }, Range(data)},
+		{"Min", func() (float64, error) { return MinE(data) }, Min(data)},
+		{"Max", func() (float64, error) { return MaxE(data) }, Max(data)},
+		{"ZScore", func() (float64, error) { return ZScoreE(7, data) }, ZScore(7, data)},
+		{"Covariance", func() (float64, error) { return CovarianceE(data, y) }, Covariance(data, y)},
+		{"PearsonCorrelation", func() (float64, error) { return PearsonCorrelationE(data, y) }, PearsonCorrelation(data, y)},
+		{"Skewness", func() (float64, error) { return SkewnessE(data) }, Skewness(data)},
+		{"Kurtosis", func() (float64, error) { return KurtosisE(data) }, Kurtosis(data)},
+		{"ExponentialSmoothing", func() (float64, error) { return ExponentialSmoothingE(data, 0.3) }, ExponentialSmoothing(data, 0.3)},
+		{"SilvermanBandwidth", func() (float64, error) { return SilvermanBandwidthE(data) }, SilvermanBandwidth(data)},
+		{"ScottBandwidth", func() (float64, error) { return ScottBandwidthE(data) }, ScottBandwidth(data)},
+	}
+	for _, tt := range tests {
+		if got, err := tt.got(); err != nil || got != tt.plain {
+			t.Errorf("%sE = %v, %v; want %v, nil", tt.name, got, err, tt.plain)
+		}
+	}
+	q1, q2, q3, err := QuartilesE(data)
+	w1, w2, w3 := Quartiles(data)
+	if err != nil || q1 != w1 || q2 != w2 || q3 != w3 {
+		t.Errorf("QuartilesE = %v, %v, %v, %v", q1, q2, q3, err)
+	}
+	if cov, err := CovarianceMatrixE([][]float64{{1, 2}, {2, 4}, {3, 7}}); err != nil || cov[0][1] != 2.5 {
+		t.Errorf("CovarianceMatrixE = %v, %v", cov, err)
+	}
+}
+
+func TestCheckedVariantsReportErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Mean empty", second(MeanE(nil)), ErrEmptyInput},
+		{"Variance one value", second(VarianceE([]float64{1})), ErrEmptyInput},
+		{"Median empty", second(MedianE(nil)), ErrEmptyInput},
+		{"Min empty", second(MinE([]float64{})), ErrEmptyInput},
+		{"ZScore constant", second(ZScoreE(1, []float64{3, 3, 3})), ErrInvalidParameter},
+		{"Covariance mismatch", second(CovarianceE([]float64{1, 2}, []float64{1})), ErrLengthMismatch},
+		{"Pearson constant", second(PearsonCorrelationE([]float64{1, 2, 3}, []float64{5, 5, 5})), ErrInvalidParameter},
+		{"Skewness short", second(SkewnessE([]float64{1, 2})), ErrEmptyInput},
+		{"Kurtosis short", second(KurtosisE([]float64{1, 2, 3})), ErrEmptyInput},
+		{"ExponentialSmoothing alpha", second(ExponentialSmoothingE([]float64{1}, 0)), ErrInvalidParameter},
+		{"ExponentialSmoothing empty", second(ExponentialSmoothingE(nil, 0.5)), ErrEmptyInput},
+		{"SheatherJones constant", second(SheatherJonesBandwidthE([]float64{2, 2, 2})), ErrInvalidParameter},
+		{"Silverman NaN", second(SilvermanBandwidthE([]float64{1, math.NaN()})), ErrInvalidParameter},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: err = %v; want %v", tt.name, tt.err, tt.want)
+		}
+	}
+	if _, _, _, err := QuartilesE(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("QuartilesE(nil) err = %v", err)
+	}
+	if _, err := CovarianceMatrixE([][]float64{{1, 2}, {3}}); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("CovarianceMatrixE ragged err = %v", err)
+	}
+	if _, err := NewKDE([]float64{1}, KDEOptions{}); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("NewKDE with one point err = %v", err)
+	}
+}
+
+func second(_ float64, err error) error { return err }
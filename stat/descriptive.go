@@ -171,6 +171,49 @@ func Covariance(x, y []float64) float64 {
 	return sum / float64(n-1)
 }
 
+// CovarianceMatrix returns the sample covariance matrix of multivariate data,
+// where each row of data is one observation and each column one variable.
+// Entry [i][j] is Covariance of columns i and j (divisor n-1).
+// Returns nil if there are fewer than 2 rows, no columns, or ragged rows.
+func CovarianceMatrix(data [][]float64) [][]float64 {
+	n := len(data)
+	if n < 2 || len(data[0]) == 0 {
+		return nil
+	}
+	d := len(data[0])
+	means := make([]float64, d)
+	for _, row := range data {
+		if len(row) != d {
+			return nil
+		}
+		for j, v := range row {
+			means[j] += v
+		}
+	}
+	for j := range means {
+		means[j] /= float64(n)
+	}
+	cov := make([][]float64, d)
+	for i := range cov {
+		cov[i] = make([]float64, d)
+	}
+	for _, row := range data {
+		for i := 0; i < d; i++ {
+			di := row[i] - means[i]
+			for j := 0; j <= i; j++ {
+				cov[i][j] += di * (row[j] - means[j])
+			}
+		}
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j <= i; j++ {
+			cov[i][j] /= float64(n - 1)
+			cov[j][i] = cov[i][j]
+		}
+	}
+	return cov
+}
+
 // PearsonCorrelation returns the Pearson correlation coefficient between x and y.
 // Returns 0 if input lengths mismatch, std dev is 0, or not enough data.
 func PearsonCorrelation(x, y []float64) float64 {
@@ -306,6 +306,35 @@ func TestCovariance(t *testing.T) {
 	}
 }
 
+func TestCovarianceMatrix(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 1, 4, 3, 7}
+	z := []float64{9, 7, 6, 2, 1}
+	data := make([][]float64, len(x))
+	for i := range x {
+		data[i] = []float64{x[i], y[i], z[i]}
+	}
+	cols := [][]float64{x, y, z}
+	cov := CovarianceMatrix(data)
+	if len(cov) != 3 {
+		t.Fatalf("CovarianceMatrix() has %d rows; want 3", len(cov))
+	}
+	for i := range cols {
+		for j := range cols {
+			if want := Covariance(cols[i], cols[j]); !almostEqual(cov[i][j], want, 1e-12) {
+				t.Errorf("cov[%d][%d] = %v; want %v", i, j, cov[i][j], want)
+			}
+		}
+	}
+
+	if CovarianceMatrix([][]float64{{1, 2}}) != nil {
+		t.Error("CovarianceMatrix with one row should return nil")
+	}
+	if CovarianceMatrix([][]float64{{1, 2}, {3}}) != nil {
+		t.Error("CovarianceMatrix with ragged rows should return nil")
+	}
+}
+
 func TestPearsonCorrelation(t *testing.T) {
 	x := []float64{1, 2, 3, 4, 5}
 	y := []float64{2, 4, 6, 8, 10}
@@ -0,0 +1,176 @@
+package stat
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cyber-mountain-man/statistical-go/internal/errs"
+)
+
+// Empirical is the empirical distribution of a sample, which puts mass 1/n
+// on each observation. Its CDF is the step-function ECDF. It has the CDF,
+// Survival, Quantile, moments and sampler of the parametric distributions,
+// so it can be used wherever a probability.Distribution is expected. Build
+// it with NewEmpirical.
+type Empirical struct {
+	data []float64 // sorted
+}
+
+// NewEmpirical returns the empirical distribution of data, which is copied.
+// It returns an error if data is empty or contains NaN; infinite values are
+// allowed.
+func NewEmpirical(data []float64) (Empirical, error) {
+	if len(data) == 0 {
+		return Empirical{}, errs.New(ErrEmptyInput, "NewEmpirical: data must not be empty")
+	}
+	sorted := append([]float64{}, data...)
+	for _, x := range sorted {
+		if math.IsNaN(x) {
+			return Empirical{}, errs.New(ErrInvalidParameter, "NewEmpirical: data must not contain NaN")
+		}
+	}
+	sort.Float64s(sorted)
+	return Empirical{data: sorted}, nil
+}
+
+// Len returns the sample size n.
+func (e Empirical) Len() int { return len(e.data) }
+
+// Values returns a sorted copy of the sample.
+func (e Empirical) Values() []float64 { return append([]float64{}, e.data...) }
+
+// Steps returns the distinct sample values in increasing order and the ECDF
+// just after each, ready to draw as a step plot. Returns nil, nil for a
+// zero-value Empirical.
+func (e Empirical) Steps() (x, p []float64) {
+	n := float64(len(e.data))
+	for i, v := range e.data {
+		if i+1 < len(e.data) && e.data[i+1] == v {
+			continue
+		}
+		x = append(x, v)
+		p = append(p, float64(i+1)/n)
+	}
+	return x, p
+}
+
+// count returns the number of observations ≤ x.
+func (e Empirical) count(x float64) int {
+	return sort.Search(len(e.data), func(i int) bool { return e.data[i] > x })
+}
+
+// CDF returns the ECDF Fₙ(x) = #{xᵢ ≤ x} / n.
+func (e Empirical) CDF(x float64) float64 {
+	if len(e.data) == 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return float64(e.count(x)) / float64(len(e.data))
+}
+
+// Survival returns #{xᵢ > x} / n.
+func (e Empirical) Survival(x float64) float64 {
+	if len(e.data) == 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	return float64(len(e.data)-e.count(x)) / float64(len(e.data))
+}
+
+// Quantile returns the smallest observation x with Fₙ(x) ≥ p, the inverse of
+// the ECDF (type 1 in R's quantile). Quantile(0) is the minimum. Unlike the
+// interpolating quartiles of Quartiles, the result is always a sample value.
+func (e Empirical) Quantile(p float64) float64 {
+	n := len(e.data)
+	if n == 0 || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	// allow for rounding in n·p, so that p = k/n returns the k-th value
+	k := int(math.Ceil(float64(n) * p * (1 - 64*2.220446049250313e-16)))
+	if k < 1 {
+		k = 1
+	}
+	return e.data[k-1]
+}
+
+// Mean returns the sample mean.
+func (e Empirical) Mean() float64 {
+	if len(e.data) == 0 {
+		return math.NaN()
+	}
+	return Mean(e.data)
+}
+
+// Variance returns the variance of the empirical distribution,
+// (1/n)·Σ(xᵢ - x̄)². This is the maximum-likelihood estimate, not the
+// unbiased sample Variance.
+func (e Empirical) Variance() float64 {
+	if len(e.data) == 0 {
+		return math.NaN()
+	}
+	mean, sum := Mean(e.data), 0.0
+	for _, x := range e.data {
+		sum += (x - mean) * (x - mean)
+	}
+	return sum / float64(len(e.data))
+}
+
+// Entropy returns -Σ pⱼ·log pⱼ in nats over the distinct values, where pⱼ
+// is the fraction of observations equal to value j.
+func (e Empirical) Entropy() float64 {
+	if len(e.data) == 0 {
+		return math.NaN()
+	}
+	_, cum := e.Steps()
+	h, prev := 0.0, 0.0
+	for _, c := range cum {
+		p := c - prev
+		h -= p * math.Log(p)
+		prev = c
+	}
+	return h
+}
+
+// Rand draws by inverse transform, Quantile(U) for a uniform U, which picks
+// each observation with probability 1/n. It uses rng, or the global
+// math/rand source if rng is nil.
+func (e Empirical) Rand(rng *rand.Rand) float64 {
+	if len(e.data) == 0 {
+		return math.NaN()
+	}
+	i := int(randFloat(rng) * float64(len(e.data)))
+	return e.data[min(i, len(e.data)-1)]
+}
+
+// Resample returns a bootstrap sample of size n drawn with replacement, as
+// n calls to Rand. Returns nil for n < 1 or a zero-value Empirical.
+func (e Empirical) Resample(n int, rng *rand.Rand) []float64 {
+	if len(e.data) == 0 || n < 1 {
+		return nil
+	}
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = e.Rand(rng)
+	}
+	return out
+}
+
+// DKWEpsilon returns the half-width ε = √(log(2/α) / 2n) of the
+// Dvoretzky-Kiefer-Wolfowitz band: with probability at least 1 - α the true
+// CDF lies within ε of the ECDF everywhere at once. Returns NaN unless
+// 0 < alpha < 1.
+func (e Empirical) DKWEpsilon(alpha float64) float64 {
+	if len(e.data) == 0 || !(alpha > 0 && alpha < 1) {
+		return math.NaN()
+	}
+	return math.Sqrt(math.Log(2/alpha) / (2 * float64(len(e.data))))
+}
+
+// DKWBand returns the simultaneous 1 - α confidence band for the CDF at x,
+// Fₙ(x) ∓ ε clipped to [0, 1].
+func (e Empirical) DKWBand(x, alpha float64) (lower, upper float64) {
+	eps, f := e.DKWEpsilon(alpha), e.CDF(x)
+	if math.IsNaN(eps) || math.IsNaN(f) {
+		return math.NaN(), math.NaN()
+	}
+	return math.Max(0, f-eps), math.Min(1, f+eps)
+}
@@ -0,0 +1,146 @@
+package stat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+var _ probability.Distribution = Empirical{}
+
+func TestEmpiricalCDF(t *testing.T) {
+	e, err := NewEmpirical([]float64{3, 1, 2, 2, 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct{ x, cdf float64 }{
+		{0, 0}, {1, 0.2}, {1.5, 0.2}, {2, 0.6}, {4.9, 0.8}, {5, 1}, {math.Inf(1), 1},
+	}
+	for _, tt := range tests {
+		if got := e.CDF(tt.x); got != tt.cdf {
+			t.Errorf("CDF(%v) = %v; want %v", tt.x, got, tt.cdf)
+		}
+		if got := e.Survival(tt.x); math.Abs(got-(1-tt.cdf)) > 1e-15 {
+			t.Errorf("Survival(%v) = %v; want %v", tt.x, got, 1-tt.cdf)
+		}
+	}
+	x, p := e.Steps()
+	wantX, wantP := []float64{1, 2, 3, 5}, []float64{0.2, 0.6, 0.8, 1}
+	for i := range wantX {
+		if x[i] != wantX[i] || p[i] != wantP[i] {
+			t.Fatalf("Steps() = %v, %v; want %v, %v", x, p, wantX, wantP)
+		}
+	}
+	if got, want := e.Entropy(), -(3*0.2*math.Log(0.2) + 0.4*math.Log(0.4)); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Entropy() = %v; want %v", got, want)
+	}
+	if e.Len() != 5 || e.Values()[0] != 1 {
+		t.Errorf("Len(), Values() = %d, %v", e.Len(), e.Values())
+	}
+}
+
+func TestEmpiricalQuantile(t *testing.T) {
+	data := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0}
+	e, _ := NewEmpirical(data)
+	tests := []struct{ p, want float64 }{
+		{0, 0.1}, {0.05, 0.1}, {0.1, 0.1}, {0.3, 0.3}, {0.30001, 0.4}, {0.7, 0.7}, {0.95, 1.0}, {1, 1.0},
+	}
+	for _, tt := range tests {
+		if got := e.Quantile(tt.p); got != tt.want {
+			t.Errorf("Quantile(%v) = %v; want %v", tt.p, got, tt.want)
+		}
+	}
+	// Quantile is the generalised inverse of the CDF
+	for k := 1; k <= 10; k++ {
+		p := float64(k) / 10
+		if q := e.Quantile(p); e.CDF(q) < p*(1-1e-12) {
+			t.Errorf("CDF(Quantile(%v)) = %v", p, e.CDF(q))
+		}
+	}
+	if !math.IsNaN(e.Quantile(1.1)) || !math.IsNaN(e.Quantile(math.NaN())) {
+		t.Error("Quantile outside [0, 1] should be NaN")
+	}
+}
+
+func TestEmpiricalMoments(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	e, _ := NewEmpirical(data)
+	if e.Mean() != 5 || e.Variance() != 4 {
+		t.Errorf("Mean(), Variance() = %v, %v; want 5, 4", e.Mean(), e.Variance())
+	}
+}
+
+func TestEmpiricalResample(t *testing.T) {
+	e, _ := NewEmpirical([]float64{1, 2, 3, 4})
+	rng := rand.New(rand.NewSource(3))
+	counts := map[float64]int{}
+	const n = 40000
+	for _, x := range e.Resample(n, rng) {
+		counts[x]++
+	}
+	for _, v := range []float64{1, 2, 3, 4} {
+		if got := float64(counts[v]) / n; math.Abs(got-0.25) > 5*math.Sqrt(0.25*0.75/n) {
+			t.Errorf("value %v drawn with frequency %v; want 0.25", v, got)
+		}
+	}
+	if len(counts) != 4 {
+		t.Errorf("resample produced values outside the sample: %v", counts)
+	}
+	if e.Resample(0, rng) != nil {
+		t.Error("Resample(0) should return nil")
+	}
+}
+
+func TestEmpiricalDKWCoverage(t *testing.T) {
+	// the band covers the true Uniform(0, 1) CDF in at least 1 - α of samples
+	rng := rand.New(rand.NewSource(11))
+	const reps, n, alpha = 2000, 50, 0.1
+	covered := 0
+	for r := 0; r < reps; r++ {
+		sample := make([]float64, n)
+		for i := range sample {
+			sample[i] = rng.Float64()
+		}
+		e, _ := NewEmpirical(sample)
+		eps := e.DKWEpsilon(alpha)
+		ok := true
+		for i, x := range e.Values() {
+			// the largest gaps are just before and at each jump
+			if float64(i+1)/n-x > eps || x-float64(i)/n > eps {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			covered++
+		}
+	}
+	if got := float64(covered) / reps; got < 1-alpha-0.015 {
+		t.Errorf("DKW band coverage = %v; want at least %v", got, 1-alpha)
+	}
+
+	e, _ := NewEmpirical([]float64{1, 2, 3, 4})
+	lo, hi := e.DKWBand(2.5, 0.05)
+	eps := math.Sqrt(math.Log(40) / 8)
+	if lo != math.Max(0, 0.5-eps) || hi != math.Min(1, 0.5+eps) {
+		t.Errorf("DKWBand(2.5, 0.05) = %v, %v; want 0.5 ∓ %v clipped", lo, hi, eps)
+	}
+	if !math.IsNaN(e.DKWEpsilon(0)) || !math.IsNaN(e.DKWEpsilon(1)) {
+		t.Error("DKWEpsilon needs 0 < alpha < 1")
+	}
+}
+
+func TestNewEmpiricalErrors(t *testing.T) {
+	if _, err := NewEmpirical(nil); err == nil {
+		t.Error("empty data should fail")
+	}
+	if _, err := NewEmpirical([]float64{1, math.NaN()}); err == nil {
+		t.Error("NaN should fail")
+	}
+	var zero Empirical
+	if !math.IsNaN(zero.CDF(0)) || !math.IsNaN(zero.Quantile(0.5)) || !math.IsNaN(zero.Rand(nil)) {
+		t.Error("zero Empirical should return NaN")
+	}
+}
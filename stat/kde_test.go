@@ -0,0 +1,242 @@
+package stat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cyber-mountain-man/statistical-go/probability"
+)
+
+var _ probability.Continuous = KDE{}
+
+var kernels = []Kernel{GaussianKernel, EpanechnikovKernel, TriangularKernel, UniformKernel}
+
+func bimodalSample(n int, seed int64) []float64 {
+	rng := rand.New(rand.NewSource(seed))
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 0.5*rng.NormFloat64() + float64(4*(i%2))
+	}
+	return x
+}
+
+func TestKernelsHaveUnitVariance(t *testing.T) {
+	for _, k := range kernels {
+		// trapezoid rule over [-8, 8]; the uniform jump costs about one step of error
+		const m = 160000
+		step := 16.0 / m
+		mass, second := 0.0, 0.0
+		for i := 0; i <= m; i++ {
+			u := -8 + float64(i)*step
+			w := step
+			if i == 0 || i == m {
+				w /= 2
+			}
+			mass += w * k.density(u)
+			second += w * u * u * k.density(u)
+		}
+		if math.Abs(mass-1) > 1e-5 || math.Abs(second-1) > 1e-5 {
+			t.Errorf("kernel %d: mass %v, variance %v; want 1, 1", k, mass, second)
+		}
+		for _, u := range []float64{-1.5, -0.2, 0.7, 2} {
+			if got, want := k.cdf(u)+k.cdf(-u), 1.0; math.Abs(got-want) > 1e-15 {
+				t.Errorf("kernel %d: cdf(%v) + cdf(%v) = %v; want 1", k, u, -u, got)
+			}
+		}
+	}
+}
+
+func TestRuleOfThumbBandwidths(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if got, want := SilvermanBandwidth(data), 1.719286404692283; math.Abs(got-want) > 1e-12 {
+		t.Errorf("SilvermanBandwidth = %v; want %v", got, want)
+	}
+	if got, want := ScottBandwidth(data), 2.0249373210820227; math.Abs(got-want) > 1e-12 {
+		t.Errorf("ScottBandwidth = %v; want %v", got, want)
+	}
+	// a zero IQR falls back to the standard deviation
+	if got, want := SilvermanBandwidth([]float64{1, 2, 2, 2, 2, 2, 2, 9}), 1.5138442317084826; math.Abs(got-want) > 1e-12 {
+		t.Errorf("SilvermanBandwidth with zero IQR = %v; want %v", got, want)
+	}
+	if SilvermanBandwidth([]float64{3}) != 0 || ScottBandwidth(nil) != 0 || SheatherJonesBandwidth([]float64{1}) != 0 {
+		t.Error("bandwidth of fewer than two points should be 0")
+	}
+}
+
+func TestSheatherJonesBandwidth(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	normal := make([]float64, 2000)
+	for i := range normal {
+		normal[i] = rng.NormFloat64()
+	}
+	// for normal data SJ approaches the AMISE-optimal 1.06·σ·n^(-1/5)
+	if got, want := SheatherJonesBandwidth(normal), 1.06*math.Pow(2000, -0.2); math.Abs(got-want) > 0.15*want {
+		t.Errorf("SJ on normal data = %v; want about %v", got, want)
+	}
+	// for well-separated modes the rule of thumb oversmooths badly
+	bi := bimodalSample(1000, 2)
+	sj, silverman := SheatherJonesBandwidth(bi), SilvermanBandwidth(bi)
+	if !(sj < silverman/2) || math.Abs(sj-0.17) > 0.03 {
+		t.Errorf("SJ on bimodal data = %v (Silverman %v); want about 0.17", sj, silverman)
+	}
+	if SheatherJonesBandwidth([]float64{5, 5, 5, 5}) != 0 {
+		t.Error("SJ of constant data should be 0")
+	}
+}
+
+func TestKDEMatchesDirectSum(t *testing.T) {
+	data := []float64{-1.3, 0.2, 0.4, 2.5, 3.1}
+	for _, kern := range kernels {
+		k, err := NewKDE(data, KDEOptions{Kernel: kern, Bandwidth: 0.8})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, x := range []float64{-4, -1, 0.3, 1.7, 3, 6} {
+			f, F := 0.0, 0.0
+			for _, xi := range data {
+				f += kern.density((x-xi)/0.8) / (5 * 0.8)
+				F += kern.cdf((x-xi)/0.8) / 5
+			}
+			if got := k.PDF(x); math.Abs(got-f) > 1e-15 {
+				t.Errorf("kernel %d: PDF(%v) = %v; want %v", kern, x, got, f)
+			}
+			if got := k.CDF(x); math.Abs(got-F) > 1e-15 {
+				t.Errorf("kernel %d: CDF(%v) = %v; want %v", kern, x, got, F)
+			}
+			if got := k.CDF(x) + k.Survival(x); math.Abs(got-1) > 1e-15 {
+				t.Errorf("kernel %d: CDF + Survival at %v = %v", kern, x, got)
+			}
+		}
+		if got, want := k.Variance(), Variance(data)*4/5+0.64; math.Abs(got-want) > 1e-12 {
+			t.Errorf("kernel %d: Variance() = %v; want %v", kern, got, want)
+		}
+	}
+}
+
+func TestKDEQuantileAndTails(t *testing.T) {
+	data := bimodalSample(300, 3)
+	for _, kern := range kernels {
+		k, _ := NewKDE(data, KDEOptions{Kernel: kern})
+		for _, p := range []float64{1e-9, 0.01, 0.3, 0.5, 0.8, 1 - 1e-9} {
+			x := k.Quantile(p)
+			if p <= 0.5 {
+				if got := k.CDF(x); math.Abs(got-p) > 1e-12*p+1e-15 {
+					t.Errorf("kernel %d: CDF(Quantile(%v)) = %v", kern, p, got)
+				}
+			} else if got := k.Survival(x); math.Abs(got-(1-p)) > 1e-12*(1-p)+1e-15 {
+				t.Errorf("kernel %d: Survival(Quantile(%v)) = %v; want %v", kern, p, got, 1-p)
+			}
+		}
+		if kern == GaussianKernel {
+			if !math.IsInf(k.Quantile(0), -1) || !math.IsInf(k.Quantile(1), 1) {
+				t.Errorf("Gaussian Quantile(0), Quantile(1) = %v, %v; want ∓Inf", k.Quantile(0), k.Quantile(1))
+			}
+			// LogPDF stays finite where the density underflows
+			if got := k.LogPDF(200); math.IsInf(got, 0) || got > -1000 {
+				t.Errorf("LogPDF(200) = %v; want a large finite negative", got)
+			}
+		} else if got, want := k.Quantile(1), Max(data)+kern.halfWidth()*k.Bandwidth(); got != want || k.PDF(want+1e-9) != 0 {
+			t.Errorf("kernel %d: Quantile(1) = %v; want %v", kern, got, want)
+		}
+	}
+}
+
+func TestKDEEntropy(t *testing.T) {
+	// two nearly equal points give the kernel itself, with entropy ½log(2πe) + log h
+	k, _ := NewKDE([]float64{0, 1e-9}, KDEOptions{Bandwidth: 2})
+	if got, want := k.Entropy(), 0.5*math.Log(2*math.Pi*math.E)+math.Log(2); math.Abs(got-want) > 1e-8 {
+		t.Errorf("Gaussian Entropy() = %v; want %v", got, want)
+	}
+	k, _ = NewKDE([]float64{0, 1e-9}, KDEOptions{Kernel: UniformKernel, Bandwidth: 2})
+	if got, want := k.Entropy(), math.Log(2*math.Sqrt(3)*2); math.Abs(got-want) > 1e-3 {
+		t.Errorf("uniform Entropy() = %v; want %v", got, want)
+	}
+}
+
+func TestKDEGridFFT(t *testing.T) {
+	data := bimodalSample(2000, 4)
+	for _, kern := range kernels[:3] {
+		k, _ := NewKDE(data, KDEOptions{Kernel: kern})
+		x, y := k.Grid(512)
+		if len(x) != 512 || len(y) != 512 {
+			t.Fatalf("Grid returned %d, %d points", len(x), len(y))
+		}
+		peak := 0.0
+		for i := range x {
+			peak = math.Max(peak, k.PDF(x[i]))
+		}
+		for i := range x {
+			if d := math.Abs(y[i] - k.PDF(x[i])); d > 1e-3*peak {
+				t.Fatalf("kernel %d: grid density at %v = %v; PDF %v", kern, x[i], y[i], k.PDF(x[i]))
+			}
+		}
+	}
+
+	// the direct path is exact
+	k, _ := NewKDE([]float64{1, 2, 4}, KDEOptions{Kernel: EpanechnikovKernel})
+	x, y := k.Grid(50)
+	for i := range x {
+		if y[i] != k.PDF(x[i]) {
+			t.Errorf("direct grid at %v = %v; want %v", x[i], y[i], k.PDF(x[i]))
+		}
+	}
+	if x, y := k.Grid(1); x != nil || y != nil {
+		t.Error("Grid(1) should return nil")
+	}
+}
+
+func TestKDERand(t *testing.T) {
+	data := bimodalSample(500, 5)
+	for _, kern := range kernels {
+		k, _ := NewKDE(data, KDEOptions{Kernel: kern, Rule: Scott})
+		rng := rand.New(rand.NewSource(6))
+		const n = 40000
+		draws := make([]float64, n)
+		for i := range draws {
+			draws[i] = k.Rand(rng)
+		}
+		if got, want := Mean(draws), k.Mean(); math.Abs(got-want) > 5*math.Sqrt(k.Variance()/n) {
+			t.Errorf("kernel %d: sample mean %v; want %v", kern, got, want)
+		}
+		if got, want := Variance(draws), k.Variance(); math.Abs(got-want) > 0.05*want {
+			t.Errorf("kernel %d: sample variance %v; want %v", kern, got, want)
+		}
+		// the fraction below a trough point matches the CDF
+		below := 0
+		for _, x := range draws {
+			if x <= 2 {
+				below++
+			}
+		}
+		p := k.CDF(2)
+		if got := float64(below) / n; math.Abs(got-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("kernel %d: fraction below 2 = %v; want %v", kern, got, p)
+		}
+	}
+}
+
+func TestNewKDEErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+		opts KDEOptions
+	}{
+		{"one point", []float64{1}, KDEOptions{}},
+		{"NaN", []float64{1, math.NaN(), 2}, KDEOptions{}},
+		{"infinite", []float64{1, math.Inf(1)}, KDEOptions{}},
+		{"unknown kernel", []float64{1, 2}, KDEOptions{Kernel: Kernel(9)}},
+		{"unknown rule", []float64{1, 2}, KDEOptions{Rule: BandwidthRule(9)}},
+		{"negative bandwidth", []float64{1, 2}, KDEOptions{Bandwidth: -1}},
+		{"no spread", []float64{3, 3, 3}, KDEOptions{Rule: SheatherJones}},
+	}
+	for _, tt := range tests {
+		if _, err := NewKDE(tt.data, tt.opts); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	var zero KDE
+	if !math.IsNaN(zero.PDF(0)) || !math.IsNaN(zero.Quantile(0.5)) || !math.IsNaN(zero.Rand(nil)) {
+		t.Error("zero KDE should return NaN")
+	}
+}
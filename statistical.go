@@ -9,6 +9,15 @@ import (
 	"github.com/cyber-mountain-man/statistical-go/regression/models"
 )
 
+// Sentinel errors returned by the error-returning variants below and by
+// the functions of every package in this module. Match them with errors.Is.
+var (
+	ErrEmptyInput       = stat.ErrEmptyInput
+	ErrLengthMismatch   = stat.ErrLengthMismatch
+	ErrInvalidParameter = stat.ErrInvalidParameter
+	ErrSingularMatrix   = stat.ErrSingularMatrix
+)
+
 //
 // DESCRIPTIVE STATISTICS
 //
@@ -74,12 +83,12 @@ func EstimatePiParallel(total, workers int) float64 {
 // PROBABILITY DISTRIBUTIONS
 //
 
-func BinomialCDF(k, n int, p float64) float64 {
-	return probability.BinomialCDF(k, n, p)
+func BinomialCDF(n, k int, p float64) float64 {
+	return probability.BinomialCDF(n, k, p)
 }
 
-func BinomialPMF(k, n int, p float64) float64 {
-	return probability.BinomialPMF(k, n, p)
+func BinomialPMF(n, k int, p float64) float64 {
+	return probability.BinomialPMF(n, k, p)
 }
 
 func BinomialQuantile(p float64, n int, prob float64) int {
@@ -182,3 +191,124 @@ func LassoRegression(X [][]float64, y []float64, lambda float64, maxIter int) ([
 	return models.LassoRegression(X, y, lambda, maxIter)
 }
 
+//
+// ERROR-RETURNING VARIANTS
+//
+
+func CovarianceE(x, y []float64) (float64, error)         { return stat.CovarianceE(x, y) }
+func KurtosisE(data []float64) (float64, error)           { return stat.KurtosisE(data) }
+func MaxE(data []float64) (float64, error)                { return stat.MaxE(data) }
+func MeanE(data []float64) (float64, error)               { return stat.MeanE(data) }
+func MedianE(data []float64) (float64, error)             { return stat.MedianE(data) }
+func MinE(data []float64) (float64, error)                { return stat.MinE(data) }
+func ModeE(data []float64) (float64, error)               { return stat.ModeE(data) }
+func PearsonCorrelationE(x, y []float64) (float64, error) { return stat.PearsonCorrelationE(x, y) }
+func QuartilesE(data []float64) (float64, float64, float64, error) {
+	return stat.QuartilesE(data)
+}
+func RangeE(data []float64) (float64, error)             { return stat.RangeE(data) }
+func SkewnessE(data []float64) (float64, error)          { return stat.SkewnessE(data) }
+func StdDevE(data []float64) (float64, error)            { return stat.StdDevE(data) }
+func VarianceE(data []float64) (float64, error)          { return stat.VarianceE(data) }
+func ZScoreE(x float64, data []float64) (float64, error) { return stat.ZScoreE(x, data) }
+
+func ExponentialSmoothingE(data []float64, alpha float64) (float64, error) {
+	return stat.ExponentialSmoothingE(data, alpha)
+}
+
+func AdditionRuleE(probA, probB, probAandB float64) (float64, error) {
+	return probability.AdditionRuleE(probA, probB, probAandB)
+}
+
+func ComplementE(probA float64) (float64, error) {
+	return probability.ComplementRuleE(probA)
+}
+
+func ConditionalProbabilityE(probAandB, probB float64) (float64, error) {
+	return probability.ConditionalE(probAandB, probB)
+}
+
+func MultiplicationRuleDependentE(probA, probBgivenA float64) (float64, error) {
+	return probability.MultiplicationDependentE(probA, probBgivenA)
+}
+
+func MultiplicationRuleIndependentE(probA, probB float64) (float64, error) {
+	return probability.MultiplicationIndependentE(probA, probB)
+}
+
+func EstimatePiE(iterations int) (float64, error) {
+	return montecarlo.EstimatePiE(iterations)
+}
+
+func EstimatePiParallelE(total, workers int) (float64, error) {
+	return montecarlo.EstimatePiParallelE(total, workers)
+}
+
+func BinomialCDFE(n, k int, p float64) (float64, error) {
+	return probability.BinomialCDFE(n, k, p)
+}
+
+func BinomialPMFE(n, k int, p float64) (float64, error) {
+	return probability.BinomialPMFE(n, k, p)
+}
+
+func BinomialQuantileE(p float64, n int, prob float64) (int, error) {
+	return probability.BinomialQuantileE(p, n, prob)
+}
+
+func NormalCDFE(x, mean, stddev float64) (float64, error) {
+	return probability.NormalCDFE(x, mean, stddev)
+}
+
+func NormalPDFE(x, mean, stddev float64) (float64, error) {
+	return probability.NormalPDFE(x, mean, stddev)
+}
+
+func NormalInverseCDFE(p, mean, stddev float64) (float64, error) {
+	return probability.NormalInverseCDFE(p, mean, stddev)
+}
+
+func OneSampleTTestE(sampleMean, populationMean, sampleStdDev float64, n int) (float64, float64, error) {
+	res := hypothesis.OneSampleTTest(sampleMean, populationMean, sampleStdDev, n)
+	return res.Statistic, res.PValue, res.Err
+}
+
+func OneSampleZTestE(sampleMean, populationMean, stddev float64, n int) (float64, float64, error) {
+	res := hypothesis.OneSampleZTest(sampleMean, populationMean, stddev, n)
+	return res.Statistic, res.PValue, res.Err
+}
+
+func PairedTTestE(before, after []float64) (float64, float64, error) {
+	res := hypothesis.PairedTTest(before, after)
+	return res.Statistic, res.PValue, res.Err
+}
+
+func TwoSampleTTestWelchE(mean1, mean2, stddev1, stddev2 float64, n1, n2 int) (float64, float64, error) {
+	res := hypothesis.TwoSampleTTestWelch(mean1, mean2, stddev1, stddev2, n1, n2)
+	return res.Statistic, res.PValue, res.Err
+}
+
+func TwoSampleZTestE(mean1, mean2, stddev1, stddev2 float64, n1, n2 int) (float64, float64, error) {
+	res := hypothesis.TwoSampleZTest(mean1, mean2, stddev1, stddev2, n1, n2)
+	return res.Statistic, res.PValue, res.Err
+}
+
+func EvaluateLinearRegressionE(x, y []float64) (float64, error) {
+	return regression.EvaluateLinearRegressionE(x, y)
+}
+
+func MSEE(yTrue, yPred []float64) (float64, error) {
+	return regression.MeanSquaredErrorE(yTrue, yPred)
+}
+
+func RMSEE(yTrue, yPred []float64) (float64, error) {
+	return regression.RootMeanSquaredErrorE(yTrue, yPred)
+}
+
+func RSquaredE(x, y []float64, slope, intercept float64) (float64, error) {
+	return regression.RSquaredE(x, y, slope, intercept)
+}
+
+func SimpleLinearRegressionE(x, y []float64) (float64, float64, error) {
+	return regression.SimpleLinearRegressionE(x, y)
+}
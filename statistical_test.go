@@ -1,6 +1,7 @@
 package statistical
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -124,4 +125,31 @@ func TestStatisticalWrapper_ExponentialSmoothing(t *testing.T) {
 	if math.Abs(got-expected) > 1e-6 {
 		t.Errorf("ExponentialSmoothing wrapper mismatch: got %.6f, want %.6f", got, expected)
 	}
-}
\ No newline at end of file
+}
+func TestStatisticalErrorVariants(t *testing.T) {
+	if got, err := MeanE([]float64{1, 2, 3}); err != nil || got != 2 {
+		t.Errorf("MeanE = %v, %v; want 2, nil", got, err)
+	}
+	if _, err := MeanE(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("MeanE(nil) error = %v; want ErrEmptyInput", err)
+	}
+	if _, err := CovarianceE([]float64{1, 2}, []float64{1}); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("CovarianceE error = %v; want ErrLengthMismatch", err)
+	}
+	if _, err := ComplementE(1.5); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("ComplementE error = %v; want ErrInvalidParameter", err)
+	}
+	if _, _, err := OneSampleTTestE(5, 4, 1, 1); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("OneSampleTTestE error = %v; want ErrInvalidParameter", err)
+	}
+	if _, _, err := SimpleLinearRegressionE([]float64{1, 1}, []float64{2, 3}); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("SimpleLinearRegressionE error = %v; want ErrSingularMatrix", err)
+	}
+	// n = 10 trials, k = 3 successes: C(10, 3) / 2^10
+	if got, err := BinomialPMFE(10, 3, 0.5); err != nil || math.Abs(got-120.0/1024) > 1e-12 {
+		t.Errorf("BinomialPMFE(10, 3, 0.5) = %v, %v; want %v", got, err, 120.0/1024)
+	}
+	if got, err := BinomialCDFE(10, 3, 0.5); err != nil || math.Abs(got-176.0/1024) > 1e-12 {
+		t.Errorf("BinomialCDFE(10, 3, 0.5) = %v, %v; want %v", got, err, 176.0/1024)
+	}
+}